@@ -1,5 +1,6 @@
 // goenums is a tool to generate type-safe enums in from your idiomatic iota based enums.
-// It generates a new file with the pluralised name of your input file with the suffix "_enums.go".
+// It generates a new file with the pluralised name of your input file with the suffix "_enums.go",
+// or "_enums_test.go" for enums declared in a test file.
 // Access to the enum values is done through the container struct which is the pluralised
 // name of the enum type. All the enum values are constants and can be accessed through the container struct.
 // The generated enum wrapper type will implement the interfaces:
@@ -12,10 +13,111 @@
 // Usage:
 //
 //	goenums [options] filename
+//	goenums [options] -src file -out file [-src file -out file ...]
+//	goenums [options] [-from-stdin] -to-stdout filename
+//	goenums gen [-pkg name] [-dir dir] dataset
+//	goenums batch [options] [-j n] [-retries n] [-dir dir] [-stats file]
+//	goenums docs [options] [-dir dir] [-out dir]
+//	goenums export [options] [-dir dir] [-format csv|xlsx] [-out file]
+//	goenums rename [options] [-dir dir] [-refs] type old new
+//	goenums reorder [options] [-dir dir] [-since rev] [-pin]
+//	goenums serve-lsp [options]
+//	goenums usage [options] [-dir dir] [-all]
+//	goenums vet [options] [-dir dir] [-since rev]
 //
 // Options:
 //
 //	-f, -failfast   Enable failfast mode - fail on generation of invalid enum while parsing (default: false)
+//	-strict         Fail in the unmarshal and scan methods on invalid input without failfast (default: false)
+//	-marshal-invalid  Marshal values that are not valid as an error or their number instead of their name: error or number (default: name)
+//	-invalid-placeholder  Marshal values that are not valid as the given placeholder instead of their name (default: none)
+//	-yaml           Generate YAML methods compatible with the given yaml library, v2 or v3 (default: disabled)
+//	-jsonv2         Generate encoding/json/v2 methods into a GOEXPERIMENT=jsonv2 file (default: false)
+//	-accessors      Generate getter methods for the extra values instead of exported fields (default: false)
+//	-immutable      Expose the container through a function returning a copy instead of a variable (default: false)
+//	-shared         Write the helpers common to every enum in the package to enums_common.go (default: false)
+//	-emptyinvalid   Marshal the invalid value to JSON as an empty string and unmarshal empty strings and null to it (default: false)
+//	-emptydefault   Unmarshal and scan empty strings, null and NULL to the //goenums:default value (default: false)
+//	-sqlint         Store the enum in SQL as its underlying integer instead of its name (default: false)
+//	-pgx            Generate pgx v5 pgtype scanner and valuer methods (default: false)
+//	-insensitive    Parse names case-insensitively (default: false)
+//	-parse-lookup   Look names up in Parse with a switch, a switch on their length or a perfect hash: switch, length or hash (default: switch)
+//	-suggest        Generate a Closest function and "did you mean" hints in failfast parse errors (default: false)
+//	-float-input    Parse float inputs: reject, integral for floats holding an integer, or round to the nearest integer (default: reject)
+//	-zero-valid     Return a value no constant has from Parse for unknown input, as the zero value is a valid constant (default: false)
+//	-prefix         Add a prefix to the wrapper type and container names, e.g. Gen for GenStatus (default: none)
+//	-suffix         Add a suffix to the wrapper type and container names, e.g. Enum for StatusEnum (default: none)
+//	-trimprefix     Remove a prefix from the constant names before deriving the container fields and names (default: none)
+//	-type           Only generate the enum of the named type, for files declaring several enums (default: none)
+//	-coverage       Mark the generated files with //coverage:ignore and look values up in maps instead of switches (default: false)
+//	-roundtrip-check  Fail unless every name and alias parses back to its own value and every written name decodes unchanged (default: false)
+//	-freeze-names   Fail if a name in the previously generated file would be removed or renamed (default: false)
+//	-lock           Record names and values in <type>.enums.lock and fail if an entry is renamed, renumbered or removed (default: false)
+//	-manifest       Record the inputs, outputs, versions and options of the enums of the package in goenums-manifest.json (default: false)
+//	-unique-names   Fail if a name is also parsed by another enum generated into the package (default: false)
+//	-max-diff-lines  Fail if regenerating would change more than this many lines of a generated file, unless -force is given (default: no limit)
+//	-force          Write the generated files whatever the number of lines changed (default: false)
+//	-base-methods   Generate String, IsValid, Parse and the JSON and SQL handlers on the base type instead of a wrapper type (default: false)
+//	-stringer       Only generate <type>_string.go with a String method compatible with golang.org/x/tools/cmd/stringer, for enums without extra values (default: false)
+//	-report         Print the values added, removed and renamed since the previously generated file (default: false)
+//	-src, -out      Generate each -src file into the matching -out file without writing next to it
+//	-data           File named by a //goenums:data directive for -src or -to-stdout as name=path, may be repeated
+//	-from-stdin     Read the source from stdin, named by the filename argument; needs -to-stdout (default: false)
+//	-to-stdout      Write the generated file to stdout instead of next to the source (default: false)
+//	-check          Check the generated file is up to date with the source and options without generating it (default: false)
+//	-pin            Rewrite the const block to give each constant its current value under //goenums:pinned instead of iota before generating (default: false)
+//	-release-notes  Print markdown release notes of the enum changes between git revisions old..new, or old and the working tree
+//	-o              Comma separated list of outputs to generate: go, sqlc, csv, json, avro, java, kotlin, python, rust, csharp (default: go)
+//	-postprocess    Comma separated list of formatters to run over the generated Go files after gofmt: compact, group-imports (default: none)
+//	-style-receiver  Name the receiver of the generated methods (default: p)
+//	-style-errors   Message of the generated errors for invalid values, with {action} and {type} placeholders (default: "failed to {action} invalid {type}")
+//	-style-comments  Comments written into the generated Go files: full or none, keeping only the header and directives (default: full)
+//	-style-order    Comma separated list of sections written first in the generated Go file (default: none)
+//	-owner          Team or person owning the enum, recorded in the header of the generated files (default: none)
+//	-doc            Link to the documentation of the enum recorded in the header of the generated files, may be repeated
+//	-description    Description of the enum written into the header of the generated files (default: none)
+//	-q, -quiet      Quiet mode - suppress the logo and all log output except errors (default: false)
+//	-log-level      Set the log level to one of debug, info, warn or error (default: info)
+//
+// The batch command finds every enum in the enclosing module and generates them in one run,
+// a package per CPU or -j at a time, printing a report of each enum generated. Files with a
+// goenums go:generate directive use its flags and the others use the options given to the command.
+// With -stats it also writes a JSON summary of the number of enums and values, the types of their
+// extra values and the handlers, outputs and options they use, computed locally from the sources.
+//
+// The docs command renders every enum in the enclosing module into a static HTML site in -out,
+// an index with a searchable table of the enums and a page per enum listing its values, names,
+// aliases, descriptions and extra values, for browsing the catalog without reading the code.
+//
+// The export command writes every value of every enum in the enclosing module to a CSV file or
+// an Excel workbook, with its type, package, name, number, aliases, description and extra values.
+//
+// The gen command writes one of the built-in datasets (countries, currencies, httpstatuses, mimetypes or timezones)
+// into a package as an enum source file and generates the enum from it.
+//
+// The rename command renames the constant old of the enum type, given as the wrapper or the base
+// type, to new in its source file, keeping the name it was parsed with as a parse alias when
+// that changes, and regenerates it. With -refs the references across the module are rewritten too.
+//
+// The reorder command reports every value of the enums in the enclosing module whose number
+// changed since the enum was last generated, or since the git revision given with -since, as
+// inserting, removing or moving a constant of an iota block renumbers the constants after it and
+// corrupts the numbers already stored. It exits with 1 when it finds any, or with -pin rewrites
+// the const blocks to give each constant an explicit value, its previous number for those that
+// changed, under the //goenums:pinned directive and regenerates them.
+//
+// The serve-lsp command is a language server on stdin and stdout for editors. It reports
+// the problems generating the enums of open buffers as diagnostics while they are edited
+// and regenerates an enum when its file is saved, with the flags of its go:generate directive.
+//
+// The usage command counts the references to each value of the enums in the enclosing module,
+// in switch cases, comparisons and elsewhere, and lists the values never referenced, or every
+// value with -all, to find dead values before removing them.
+//
+// The vet command reports every use of the base type or constants of an enum in the other
+// files of its package, naming the wrapper type or container value to use instead, and
+// exits with 1 when it finds any. With -since rev only the uses on lines changed since the
+// git revision are reported, so new code can be held to the wrappers during a migration.
 //
 // This can also be used in a go generate directive.
 // Example:
@@ -28,20 +130,62 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/zarldev/goenums/pkg/generator"
 )
 
 const VERSION = "v0.3.5"
 
+// logLevel is the level of the default logger, set by the logging flags as they are parsed.
+var logLevel = new(slog.LevelVar)
+
+// quiet suppresses the logo and all log output except errors.
+var quiet bool
+
+// commands are the subcommands of goenums by name, each run with the arguments
+// after its name and returning the exit code.
+var commands = map[string]func(args []string) int{
+	"gen":       gen,
+	"batch":     batch,
+	"docs":      docs,
+	"export":    export,
+	"rename":    rename,
+	"reorder":   reorder,
+	"serve-lsp": serveLSP,
+	"usage":     usage,
+	"vet":       vet,
+}
+
 func main() {
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel})))
+	if len(os.Args) > 1 {
+		if run, ok := commands[os.Args[1]]; ok {
+			os.Exit(run(os.Args[2:]))
+		}
+	}
 	var (
-		help, version, failfast bool
-		err                     error
+		help, version       bool
+		report, check, pin  bool
+		fromStdin, toStdout bool
+		srcs, outs          []string
+		releaseNotes        string
+		cfg                 generator.Config
+		err                 error
 	)
 	flag.BoolVar(&help, "help", false,
 		"Print help information")
@@ -49,20 +193,73 @@ func main() {
 	flag.BoolVar(&version, "version", false,
 		"Print version information")
 	flag.BoolVar(&version, "v", false, "")
-	flag.BoolVar(&failfast, "failfast", false,
-		"Enable failfast mode - fail on generation of invalid enum while parsing (default: false)")
-	flag.BoolVar(&failfast, "f", false, "")
+	configFlags(flag.CommandLine, &cfg)
+	flag.BoolVar(&report, "report", false,
+		"Print the values added, removed and renamed since the previously generated file (default: false)")
+	flag.BoolVar(&check, "check", false,
+		"Check the generated file is up to date with the source and options without generating it (default: false)")
+	flag.BoolVar(&pin, "pin", false,
+		"Rewrite the const block to give each constant its current value under //goenums:pinned instead of iota before generating (default: false)")
+	flag.StringVar(&releaseNotes, "release-notes", "",
+		"Print markdown release notes of the enum changes between git revisions old..new, or old and the working tree")
+	flag.Func("src", "Source file to generate into the matching -out file, may be repeated", func(s string) error {
+		srcs = append(srcs, s)
+		return nil
+	})
+	flag.Func("out", "File the matching -src is generated into, may be repeated", func(s string) error {
+		outs = append(outs, s)
+		return nil
+	})
+	flag.Func("data", "File named by a //goenums:data directive for -src or -to-stdout as name=path, may be repeated", func(s string) error {
+		name, file, ok := strings.Cut(s, "=")
+		if !ok {
+			return fmt.Errorf("expected name=path, got %s", s)
+		}
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if cfg.Data == nil {
+			cfg.Data = make(map[string][]byte)
+		}
+		cfg.Data[name] = b
+		return nil
+	})
+	flag.BoolVar(&fromStdin, "from-stdin", false,
+		"Read the source from stdin, named by the filename argument; needs -to-stdout (default: false)")
+	flag.BoolVar(&toStdout, "to-stdout", false,
+		"Write the generated file to stdout instead of next to the source (default: false)")
+	logFlags(flag.CommandLine)
 	flag.Parse()
 
 	args := flag.Args()
 
 	if help {
-		printHelp()
+		printHelp(quiet)
 		return
 	}
 
 	if version {
-		printVersion()
+		printVersion(quiet)
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if fromStdin && !toStdout {
+		slog.Error("Error: -from-stdin needs -to-stdout")
+		os.Exit(1)
+	}
+	if len(srcs) > 0 || len(outs) > 0 {
+		if toStdout {
+			slog.Error("Error: -src and -out cannot be used with -from-stdin or -to-stdout")
+			os.Exit(1)
+		}
+		err = generatePairs(ctx, srcs, outs, cfg)
+		if err != nil {
+			slog.Error("failed to generate enums", "error", err)
+			os.Exit(1)
+		}
 		return
 	}
 
@@ -72,21 +269,707 @@ func main() {
 	}
 
 	filename := flag.Arg(0)
-	err = generator.ParseAndGenerate(filename, failfast)
+	if releaseNotes != "" {
+		err = printReleaseNotes(filename, releaseNotes)
+		if err != nil {
+			slog.Error("failed to write release notes", "file", filename, "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if report {
+		cfg.Report = os.Stdout
+	}
+	if toStdout {
+		err = generateStdout(ctx, filename, fromStdin, cfg)
+		if err != nil {
+			slog.Error("failed to generate enums", "file", filename, "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if check {
+		err = generator.Check(ctx, filename, cfg)
+		if err != nil {
+			slog.Error("enums are out of date", "file", filename, "error", err)
+			os.Exit(1)
+		}
+		slog.Info("enums are up to date", "file", filename)
+		return
+	}
+	if pin {
+		err = generator.PinValues(ctx, filename, cfg, nil)
+		if err != nil {
+			slog.Error("failed to pin values", "file", filename, "error", err)
+			os.Exit(1)
+		}
+		slog.Info("pinned values and generated enums", "file", filename)
+		return
+	}
+	slog.Debug("generating enums", "file", filename, "config", cfg)
+	err = generator.ParseAndGenerateWithConfig(ctx, filename, cfg)
 	if err != nil {
-		slog.Error("Failed to generate enums: %v", err)
+		slog.Error("failed to generate enums", "file", filename, "error", err)
 		os.Exit(1)
 	}
+	slog.Debug("generated enums", "file", filename)
 }
 
-func printHelp() {
-	printTitle()
+// generatePairs generates each -src file into its -out file, with any further
+// outputs written next to the -out file. Nothing is read or written relative to
+// the working directory or next to the sources, for hermetic build systems.
+func generatePairs(ctx context.Context, srcs, outs []string, cfg generator.Config) error {
+	if len(srcs) != len(outs) {
+		return fmt.Errorf("got %d -src and %d -out files, each -src needs an -out", len(srcs), len(outs))
+	}
+	for i, src := range srcs {
+		b, err := os.ReadFile(src)
+		if err != nil {
+			return fmt.Errorf("failed to read source: %w", err)
+		}
+		files, err := generator.Generate(ctx, src, b, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to generate %s: %w", src, err)
+		}
+		for j, f := range files {
+			out := outs[i]
+			if j > 0 {
+				out = filepath.Join(filepath.Dir(outs[i]), f.Name)
+			}
+			if err := os.WriteFile(out, f.Content, 0644); err != nil {
+				return fmt.Errorf("failed to write file: %w", err)
+			}
+		}
+		slog.Debug("generated enums", "file", src, "out", outs[i])
+	}
+	return nil
+}
+
+// generateStdout generates the enum in filename, or read from stdin and named by
+// filename, and writes it to stdout. Only a single generated file can be written.
+func generateStdout(ctx context.Context, filename string, fromStdin bool, cfg generator.Config) error {
+	var (
+		src []byte
+		err error
+	)
+	if fromStdin {
+		src, err = io.ReadAll(os.Stdin)
+	} else {
+		src, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read source: %w", err)
+	}
+	files, err := generator.Generate(ctx, filename, src, cfg)
+	if err != nil {
+		return err
+	}
+	if len(files) != 1 {
+		return fmt.Errorf("-to-stdout writes a single file but %d were generated", len(files))
+	}
+	_, err = os.Stdout.Write(files[0].Content)
+	return err
+}
+
+// configFlags binds the flags for the generation options in cfg to fs.
+func configFlags(fs *flag.FlagSet, cfg *generator.Config) {
+	fs.BoolVar(&cfg.Failfast, "failfast", false,
+		"Enable failfast mode - fail on generation of invalid enum while parsing (default: false)")
+	fs.BoolVar(&cfg.Failfast, "f", false, "")
+	fs.BoolVar(&cfg.Strict, "strict", false,
+		"Fail in the unmarshal and scan methods on invalid input without failfast (default: false)")
+	fs.StringVar(&cfg.MarshalInvalid, "marshal-invalid", "",
+		"Marshal values that are not valid as an error or their number instead of their name: error or number")
+	fs.StringVar(&cfg.InvalidPlaceholder, "invalid-placeholder", "",
+		"Marshal values that are not valid as the given placeholder instead of their name")
+	fs.StringVar(&cfg.YAML, "yaml", "",
+		"Generate YAML methods compatible with the given yaml library, v2 (gopkg.in/yaml.v2) or v3 (gopkg.in/yaml.v3)")
+	fs.BoolVar(&cfg.JSONv2, "jsonv2", false,
+		"Generate encoding/json/v2 MarshalJSONTo and UnmarshalJSONFrom methods into a GOEXPERIMENT=jsonv2 file (default: false)")
+	fs.BoolVar(&cfg.Accessors, "accessors", false,
+		"Generate getter methods for the extra values instead of exported fields (default: false)")
+	fs.BoolVar(&cfg.Immutable, "immutable", false,
+		"Expose the container through a function returning a copy instead of a variable (default: false)")
+	fs.BoolVar(&cfg.Shared, "shared", false,
+		"Write the helpers common to every enum in the package to enums_common.go (default: false)")
+	fs.BoolVar(&cfg.EmptyInvalid, "emptyinvalid", false,
+		"Marshal the invalid value to JSON as an empty string and unmarshal empty strings and null to it (default: false)")
+	fs.BoolVar(&cfg.EmptyDefault, "emptydefault", false,
+		"Unmarshal and scan empty strings, null and NULL to the //goenums:default value (default: false)")
+	fs.BoolVar(&cfg.SQLInt, "sqlint", false,
+		"Store the enum in SQL as its underlying integer instead of its name (default: false)")
+	fs.BoolVar(&cfg.Pgx, "pgx", false,
+		"Generate pgx v5 pgtype scanner and valuer methods (default: false)")
+	fs.BoolVar(&cfg.Insensitive, "insensitive", false,
+		"Parse names case-insensitively (default: false)")
+	fs.StringVar(&cfg.ParseLookup, "parse-lookup", "",
+		"Look names up in Parse with a switch, a switch on their length or a perfect hash: switch, length or hash (default: switch)")
+	fs.BoolVar(&cfg.Suggest, "suggest", false,
+		"Generate a Closest function and \"did you mean\" hints in failfast parse errors (default: false)")
+	fs.StringVar(&cfg.FloatInput, "float-input", "",
+		"Parse float inputs: reject, integral for floats holding an integer, or round to the nearest integer (default: reject)")
+	fs.BoolVar(&cfg.ZeroValid, "zero-valid", false,
+		"Return a value no constant has from Parse for unknown input, as the zero value is a valid constant (default: false)")
+	fs.StringVar(&cfg.Prefix, "prefix", "",
+		"Add a prefix to the wrapper type and container names, e.g. Gen for GenStatus")
+	fs.StringVar(&cfg.Suffix, "suffix", "",
+		"Add a suffix to the wrapper type and container names, e.g. Enum for StatusEnum")
+	fs.StringVar(&cfg.TrimPrefix, "trimprefix", "",
+		"Remove a prefix from the constant names before deriving the container fields and names")
+	fs.StringVar(&cfg.Type, "type", "",
+		"Only generate the enum of the named type, for files declaring several enums")
+	fs.BoolVar(&cfg.Coverage, "coverage", false,
+		"Mark the generated files with //coverage:ignore and look values up in maps instead of switches (default: false)")
+	fs.BoolVar(&cfg.RoundTripCheck, "roundtrip-check", false,
+		"Fail unless every name and alias parses back to its own value and every written name decodes unchanged (default: false)")
+	fs.BoolVar(&cfg.FreezeNames, "freeze-names", false,
+		"Fail if a name in the previously generated file would be removed or renamed (default: false)")
+	fs.BoolVar(&cfg.Lock, "lock", false,
+		"Record names and values in <type>.enums.lock and fail if an entry is renamed, renumbered or removed (default: false)")
+	fs.BoolVar(&cfg.Manifest, "manifest", false,
+		"Record the inputs, outputs, versions and options of the enums of the package in goenums-manifest.json (default: false)")
+	fs.BoolVar(&cfg.UniqueNames, "unique-names", false,
+		"Fail if a name is also parsed by another enum generated into the package (default: false)")
+	fs.BoolVar(&cfg.BaseMethods, "base-methods", false,
+		"Generate String, IsValid, Parse and the JSON and SQL handlers on the base type instead of a wrapper type (default: false)")
+	fs.BoolVar(&cfg.Stringer, "stringer", false,
+		"Only generate <type>_string.go with a String method compatible with golang.org/x/tools/cmd/stringer, for enums without extra values (default: false)")
+	fs.IntVar(&cfg.MaxDiffLines, "max-diff-lines", 0,
+		"Fail if regenerating would change more than this many lines of a generated file, unless -force is given (default: no limit)")
+	fs.BoolVar(&cfg.Force, "force", false,
+		"Write the generated files whatever the number of lines changed (default: false)")
+	fs.Func("o", "Comma separated list of outputs to generate: go, sqlc, csv, json, avro, java, kotlin, python, rust, csharp (default: go)", func(s string) error {
+		cfg.Outputs = strings.Split(s, ",")
+		return nil
+	})
+	fs.Func("postprocess", "Comma separated list of formatters to run over the generated Go files after gofmt: compact, group-imports (default: none)", func(s string) error {
+		cfg.PostProcess = strings.Split(s, ",")
+		return nil
+	})
+	fs.StringVar(&cfg.Style.Receiver, "style-receiver", "",
+		"Name the receiver of the generated methods (default: p)")
+	fs.StringVar(&cfg.Style.ErrorFormat, "style-errors", "",
+		"Message of the generated errors for invalid values, with {action} and {type} placeholders (default: \"failed to {action} invalid {type}\")")
+	fs.StringVar(&cfg.Style.Comments, "style-comments", "",
+		"Comments written into the generated Go files: full or none, keeping only the header and directives (default: full)")
+	fs.Func("style-order", "Comma separated list of sections written first in the generated Go file: "+strings.Join(generator.Sections(), ", "), func(s string) error {
+		cfg.Style.Order = strings.Split(s, ",")
+		return nil
+	})
+	fs.StringVar(&cfg.Header.Owner, "owner", "",
+		"Team or person owning the enum, recorded in the header of the generated files")
+	fs.Func("doc", "Link to the documentation of the enum recorded in the header of the generated files, may be repeated", func(s string) error {
+		cfg.Header.Docs = append(cfg.Header.Docs, s)
+		return nil
+	})
+	fs.StringVar(&cfg.Header.Description, "description", "",
+		"Description of the enum written into the header of the generated files")
+}
+
+// gen runs the gen command writing a built-in dataset into a package and returns the exit code.
+func gen(args []string) int {
+	fs := flag.NewFlagSet("gen", flag.ExitOnError)
+	logFlags(fs)
+	pkg := fs.String("pkg", "", "Package name of the generated files (default: the name of the output directory)")
+	dir := fs.String("dir", ".", "Directory to write the generated files to")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: goenums gen [-pkg name] [-dir dir] dataset\nDatasets: %s\nOptions:\n", strings.Join(generator.Datasets(), ", "))
+		fs.PrintDefaults()
+	}
+	var dataset string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		dataset, args = args[0], args[1:]
+	}
+	fs.Parse(args)
+	if dataset == "" {
+		dataset = fs.Arg(0)
+	}
+	if dataset == "" {
+		fs.Usage()
+		return 2
+	}
+	if *pkg == "" {
+		abs, err := filepath.Abs(*dir)
+		if err != nil {
+			slog.Error("failed to resolve output directory", "dir", *dir, "error", err)
+			return 1
+		}
+		*pkg = filepath.Base(abs)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	filename, err := generator.GenerateDataset(ctx, *dir, *pkg, dataset, generator.Config{})
+	if err != nil {
+		slog.Error("failed to generate dataset", "dataset", dataset, "error", err)
+		return 1
+	}
+	slog.Debug("generated enums", "file", filename)
+	return 0
+}
+
+// batch runs the batch command generating every enum in the module and returns the exit code.
+// Files with a goenums go:generate directive are generated with the flags of the directive,
+// the others with the flags given to the command.
+func batch(args []string) int {
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+	logFlags(fs)
+	var cfg generator.Config
+	configFlags(fs, &cfg)
+	dir := fs.String("dir", ".", "Directory inside the module to generate the enums of")
+	jobs := fs.Int("j", 0, "Number of packages to generate concurrently (default: the number of CPUs)")
+	retries := fs.Int("retries", 0, "Number of times to retry an enum after a transient file system error (default: 0)")
+	stats := fs.String("stats", "", "Write a JSON summary of the enums, their fields, handlers and options to the file (default: none)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: goenums batch [options] [-j n] [-retries n] [-dir dir] [-stats file]\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	candidates, err := generator.FindEnums(*dir)
+	if err != nil {
+		slog.Error("failed to find enums", "dir", *dir, "error", err)
+		return 1
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	wd, err := os.Getwd()
+	if err != nil {
+		slog.Error("failed to get working directory", "error", err)
+		return 1
+	}
+	config := func(c generator.Candidate) (generator.Config, error) {
+		return candidateConfig(c, cfg)
+	}
+	var metrics generator.BatchMetrics
+	errs := generator.GenerateBatch(ctx, candidates, generator.BatchOptions{
+		Jobs:    *jobs,
+		Retries: *retries,
+		Config:  config,
+		Metrics: func(m generator.BatchMetrics) {
+			metrics = m
+		},
+	})
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for i, c := range candidates {
+		status := "ok"
+		if err := errs[i]; err != nil {
+			status = "FAIL\t" + errors.Unwrap(err).Error()
+		}
+		filename := c.Filename
+		if rel, err := filepath.Rel(wd, filename); err == nil {
+			filename = rel
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.ImportPath, c.Type, filename, status)
+	}
+	w.Flush()
+	fmt.Printf("%d enums in %d packages, %d failed\n", metrics.Enums, metrics.Packages, metrics.Failed)
+	slog.Debug("batch finished", "retries", metrics.Retries, "duration", metrics.Duration)
+	if *stats != "" {
+		if err := writeStats(*stats, generator.CollectStats(candidates, config)); err != nil {
+			slog.Error("failed to write stats", "file", *stats, "error", err)
+			return 1
+		}
+	}
+	if metrics.Failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// docs runs the docs command writing the documentation site of the enums in the
+// module and returns the exit code.
+func docs(args []string) int {
+	fs := flag.NewFlagSet("docs", flag.ExitOnError)
+	logFlags(fs)
+	var cfg generator.Config
+	configFlags(fs, &cfg)
+	dir := fs.String("dir", ".", "Directory inside the module to document the enums of")
+	out := fs.String("out", "enums-docs", "Directory the site is written to")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: goenums docs [options] [-dir dir] [-out dir]\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	candidates, err := generator.FindEnums(*dir)
+	if err != nil {
+		slog.Error("failed to find enums", "dir", *dir, "error", err)
+		return 1
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	err = generator.WriteSite(ctx, *out, candidates, func(c generator.Candidate) (generator.Config, error) {
+		return candidateConfig(c, cfg)
+	})
+	if err != nil {
+		slog.Error("failed to write docs", "dir", *out, "error", err)
+		return 1
+	}
+	slog.Info("wrote docs", "enums", len(candidates), "dir", *out)
+	return 0
+}
+
+// export runs the export command writing the values of the enums in the module as
+// a CSV table or an Excel workbook and returns the exit code.
+func export(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	logFlags(fs)
+	var cfg generator.Config
+	configFlags(fs, &cfg)
+	dir := fs.String("dir", ".", "Directory inside the module to export the enums of")
+	format := fs.String("format", generator.ExportCSV, "Format of the export: csv or xlsx")
+	out := fs.String("out", "", "File the export is written to (default: stdout)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: goenums export [options] [-dir dir] [-format csv|xlsx] [-out file]\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	candidates, err := generator.FindEnums(*dir)
+	if err != nil {
+		slog.Error("failed to find enums", "dir", *dir, "error", err)
+		return 1
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	var b bytes.Buffer
+	err = generator.WriteExport(ctx, &b, *format, candidates, func(c generator.Candidate) (generator.Config, error) {
+		return candidateConfig(c, cfg)
+	})
+	if err != nil {
+		slog.Error("failed to export enums", "error", err)
+		return 1
+	}
+	if *out == "" {
+		os.Stdout.Write(b.Bytes())
+		return 0
+	}
+	if err := os.WriteFile(*out, b.Bytes(), 0644); err != nil {
+		slog.Error("failed to write export", "file", *out, "error", err)
+		return 1
+	}
+	return 0
+}
+
+// rename runs the rename command renaming a constant of an enum in the module and
+// returns the exit code.
+func rename(args []string) int {
+	fs := flag.NewFlagSet("rename", flag.ExitOnError)
+	logFlags(fs)
+	var cfg generator.Config
+	configFlags(fs, &cfg)
+	dir := fs.String("dir", ".", "Directory inside the module to find the enum in")
+	refs := fs.Bool("refs", false, "Rewrite the references to the constant and its container field across the module (default: false)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: goenums rename [options] [-dir dir] [-refs] type old new\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 3 {
+		fs.Usage()
+		return 2
+	}
+	typ, oldName, newName := fs.Arg(0), fs.Arg(1), fs.Arg(2)
+	candidates, err := generator.FindEnums(*dir)
+	if err != nil {
+		slog.Error("failed to find enums", "dir", *dir, "error", err)
+		return 1
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	for _, c := range candidates {
+		cfg, err := candidateConfig(c, cfg)
+		if err != nil {
+			slog.Error("failed to read options", "file", c.Filename, "error", err)
+			return 1
+		}
+		src, err := os.ReadFile(c.Filename)
+		if err != nil {
+			slog.Error("failed to read file", "file", c.Filename, "error", err)
+			return 1
+		}
+		rep, err := generator.Parse(ctx, c.Filename, src, cfg)
+		if err != nil || (rep.TypeInfo.Camel != typ && rep.TypeInfo.Name != typ) {
+			continue
+		}
+		changed, err := generator.Rename(ctx, c.Filename, cfg, oldName, newName, *refs)
+		for _, file := range changed {
+			slog.Info("rewrote file", "file", file)
+		}
+		if err != nil {
+			slog.Error("failed to rename", "type", typ, "constant", oldName, "error", err)
+			return 1
+		}
+		return 0
+	}
+	slog.Error("no enum found", "type", typ, "dir", *dir)
+	return 1
+}
+
+// reorder runs the reorder command reporting the values of the enums in the module
+// whose number changed, pinning them back to their previous number with -pin, and
+// returns the exit code, 1 when any are found and not pinned.
+func reorder(args []string) int {
+	fs := flag.NewFlagSet("reorder", flag.ExitOnError)
+	logFlags(fs)
+	var cfg generator.Config
+	configFlags(fs, &cfg)
+	dir := fs.String("dir", ".", "Directory inside the module to check the enums of")
+	since := fs.String("since", "", "Compare with the source at the git revision instead of the generated files (default: none)")
+	pin := fs.Bool("pin", false, "Pin the constants to explicit values, their previous number for those renumbered, and regenerate them (default: false)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: goenums reorder [options] [-dir dir] [-since rev] [-pin]\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	candidates, err := generator.FindEnums(*dir)
+	if err != nil {
+		slog.Error("failed to find enums", "dir", *dir, "error", err)
+		return 1
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	wd, err := os.Getwd()
+	if err != nil {
+		slog.Error("failed to get working directory", "error", err)
+		return 1
+	}
+	found := 0
+	for _, c := range candidates {
+		cfg, err := candidateConfig(c, cfg)
+		if err != nil {
+			slog.Error("failed to read options", "file", c.Filename, "error", err)
+			return 1
+		}
+		filename := c.Filename
+		if rel, err := filepath.Rel(wd, filename); err == nil {
+			filename = rel
+		}
+		var previous []byte
+		if *since != "" {
+			// enums added since the revision have nothing to be renumbered from
+			if previous, err = gitShow(*since, filename); err != nil {
+				continue
+			}
+		}
+		renumbered, err := generator.FindRenumbered(ctx, c.Filename, previous, cfg)
+		if err != nil {
+			slog.Error("failed to check enum", "file", filename, "error", err)
+			return 1
+		}
+		if len(renumbered) == 0 {
+			continue
+		}
+		if *pin {
+			values := make(map[string]int, len(renumbered))
+			for _, r := range renumbered {
+				values[r.Name] = r.Previous
+			}
+			if err := generator.PinValues(ctx, c.Filename, cfg, values); err != nil {
+				slog.Error("failed to pin values", "file", filename, "error", err)
+				return 1
+			}
+			slog.Info("pinned values", "file", filename, "renumbered", len(renumbered))
+			continue
+		}
+		for _, r := range renumbered {
+			fmt.Printf("%s: %s\n", filename, r)
+		}
+		found += len(renumbered)
+	}
+	if found > 0 {
+		return 1
+	}
+	return 0
+}
+
+// usage runs the usage command listing the values of the enums in the module that
+// are never referenced, or the references to every value with -all, and returns the exit code.
+func usage(args []string) int {
+	fs := flag.NewFlagSet("usage", flag.ExitOnError)
+	logFlags(fs)
+	var cfg generator.Config
+	configFlags(fs, &cfg)
+	dir := fs.String("dir", ".", "Directory inside the module to count the references of")
+	all := fs.Bool("all", false, "List the references to every value instead of only the values never referenced (default: false)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: goenums usage [options] [-dir dir] [-all]\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	candidates, err := generator.FindEnums(*dir)
+	if err != nil {
+		slog.Error("failed to find enums", "dir", *dir, "error", err)
+		return 1
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	usages, err := generator.FindUsage(ctx, *dir, candidates, func(c generator.Candidate) (generator.Config, error) {
+		return candidateConfig(c, cfg)
+	})
+	if err != nil {
+		slog.Error("failed to count references", "error", err)
+		return 1
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	if *all {
+		fmt.Fprintln(w, "PACKAGE\tVALUE\tCASES\tCOMPARISONS\tOTHER")
+	}
+	values, unreferenced := 0, 0
+	for _, u := range usages {
+		for _, v := range u.Values {
+			values++
+			if !v.Referenced() {
+				unreferenced++
+			}
+			switch {
+			case *all:
+				fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", u.ImportPath, v.Constant, v.Cases, v.Comparisons, v.Other)
+			case !v.Referenced():
+				fmt.Fprintf(w, "%s\t%s\tnever referenced\n", u.ImportPath, v.Constant)
+			}
+		}
+	}
+	w.Flush()
+	fmt.Printf("%d of %d values in %d enums never referenced\n", unreferenced, values, len(usages))
+	return 0
+}
+
+// writeStats writes the stats of the batch run to filename as indented JSON.
+func writeStats(filename string, stats generator.Stats) error {
+	b, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(b, '\n'), 0644)
+}
+
+// generateCandidate generates a single enum found by the batch command or saved in
+// the language server, using the flags of its go:generate directive when it has one
+// and cfg otherwise.
+func generateCandidate(ctx context.Context, c generator.Candidate, cfg generator.Config) error {
+	errs := generator.GenerateBatch(ctx, []generator.Candidate{c}, generator.BatchOptions{
+		Config: func(c generator.Candidate) (generator.Config, error) {
+			return candidateConfig(c, cfg)
+		},
+	})
+	// the error is already reported against the file
+	return errors.Unwrap(errs[0])
+}
+
+// candidateConfig returns the options the enum found by the batch command is
+// generated with, parsed from its go:generate directive when it has one and cfg otherwise.
+func candidateConfig(c generator.Candidate, cfg generator.Config) (generator.Config, error) {
+	if c.Args == nil {
+		return cfg, nil
+	}
+	cfg = generator.Config{}
+	fs := flag.NewFlagSet(c.Filename, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	configFlags(fs, &cfg)
+	// flags that do not change the generated code
+	var report bool
+	fs.BoolVar(&report, "report", false, "")
+	fs.Bool("q", false, "")
+	fs.Bool("quiet", false, "")
+	fs.String("log-level", "", "")
+	if err := fs.Parse(c.Args); err != nil {
+		return cfg, fmt.Errorf("invalid go:generate flags: %w", err)
+	}
+	if report {
+		cfg.Report = io.Discard
+	}
+	return cfg, nil
+}
+
+// printReleaseNotes prints the release notes for the enum in filename between the
+// git revisions in revs, given as old..new or just old to compare against the working tree.
+func printReleaseNotes(filename, revs string) error {
+	oldRev, newRev, _ := strings.Cut(revs, "..")
+	previous, err := gitShow(oldRev, filename)
+	if err != nil {
+		return err
+	}
+	var current []byte
+	if newRev == "" {
+		current, err = os.ReadFile(filename)
+	} else {
+		current, err = gitShow(newRev, filename)
+	}
+	if err != nil {
+		return err
+	}
+	return generator.WriteReleaseNotes(os.Stdout, filename, previous, current)
+}
+
+// gitShow returns the contents of filename at the git revision rev.
+func gitShow(rev, filename string) ([]byte, error) {
+	if !filepath.IsAbs(filename) {
+		filename = "./" + filepath.ToSlash(filename)
+	}
+	out, err := exec.Command("git", "show", rev+":"+filename).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("git show %s:%s: %s", rev, filename, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git show %s:%s: %w", rev, filename, err)
+	}
+	return out, nil
+}
+
+// logFlags binds the -q, -quiet and -log-level flags to fs. They set the level of the
+// default logger as they are parsed, quiet mode winning whichever order they are in.
+func logFlags(fs *flag.FlagSet) {
+	fs.BoolFunc("quiet",
+		"Quiet mode - suppress the logo and all log output except errors (default: false)", setQuiet)
+	fs.BoolFunc("q", "", setQuiet)
+	fs.Func("log-level", "Set the log level to one of debug, info, warn or error (default: info)", func(s string) error {
+		level, err := parseLogLevel(s)
+		if err != nil {
+			return err
+		}
+		if !quiet {
+			logLevel.Set(level)
+		}
+		return nil
+	})
+}
+
+// setQuiet sets quiet mode from the value of the -q flag.
+func setQuiet(s string) error {
+	q, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	quiet = q
+	if quiet {
+		logLevel.Set(slog.LevelError)
+	}
+	return nil
+}
+
+// parseLogLevel converts the -log-level flag value into a slog.Level.
+func parseLogLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(s))
+	if err != nil {
+		return level, fmt.Errorf("unknown log level %q: %w", s, err)
+	}
+	return level, nil
+}
+
+func printHelp(quiet bool) {
+	if !quiet {
+		printTitle()
+	}
 	fmt.Println("Usage: goenums [options] filename")
 	fmt.Println("Options:")
 	flag.PrintDefaults()
 }
 
-func printVersion() {
+func printVersion(quiet bool) {
+	if quiet {
+		fmt.Println(VERSION)
+		return
+	}
 	printTitle()
 	fmt.Printf("\t\tversion: %s\n", VERSION)
 }
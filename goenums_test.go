@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zarldev/goenums/pkg/generator"
+)
+
+const (
+	statusSource = "package status\n\ntype status int\n\nconst (\n\tunknown status = iota // invalid\n\tactive\n)\n"
+	kindSource   = "package status\n\ntype kind int\n\nconst (\n\tsmall kind = iota\n\tlarge\n)\n"
+)
+
+// writeSources writes the files into a new directory and returns its path.
+func writeSources(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// redirect replaces *f with a file holding input for the duration of the test
+// and returns the file, read back after the test wrote to it.
+func redirect(t *testing.T, f **os.File, input string) *os.File {
+	t.Helper()
+	tmp, err := os.CreateTemp(t.TempDir(), "std")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tmp.WriteString(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	prev := *f
+	*f = tmp
+	t.Cleanup(func() {
+		*f = prev
+		tmp.Close()
+	})
+	return tmp
+}
+
+func TestCandidateConfig(t *testing.T) {
+	fallback := generator.Config{Insensitive: true}
+	cfg, err := candidateConfig(generator.Candidate{Filename: "status.go"}, fallback)
+	if err != nil {
+		t.Fatalf("failed to get the config, got %v", err)
+	}
+	if !cfg.Insensitive {
+		t.Errorf("expected the given config without a directive, got %+v", cfg)
+	}
+	c := generator.Candidate{Filename: "status.go", Args: []string{"-f", "-sqlint", "-o", "go,json", "-report", "-q", "-log-level", "debug"}}
+	cfg, err = candidateConfig(c, fallback)
+	if err != nil {
+		t.Fatalf("failed to get the config, got %v", err)
+	}
+	if !cfg.Failfast || !cfg.SQLInt || !slices.Equal(cfg.Outputs, []string{"go", "json"}) {
+		t.Errorf("expected the flags of the directive, got %+v", cfg)
+	}
+	if cfg.Insensitive {
+		t.Errorf("expected the given config to be ignored with a directive")
+	}
+	if cfg.Report != io.Discard {
+		t.Errorf("expected -report to discard the report, got %v", cfg.Report)
+	}
+	if quiet || logLevel.Level() != 0 {
+		t.Errorf("expected the logging flags of the directive to be ignored")
+	}
+	_, err = candidateConfig(generator.Candidate{Filename: "status.go", Args: []string{"-bogus"}}, fallback)
+	if err == nil || !strings.Contains(err.Error(), "invalid go:generate flags") {
+		t.Errorf("expected an error for an unknown flag, got %v", err)
+	}
+}
+
+func TestGeneratePairs(t *testing.T) {
+	ctx := context.Background()
+	src := writeSources(t, map[string]string{"status.go": statusSource, "kind.go": kindSource})
+	out := t.TempDir()
+	srcs := []string{filepath.Join(src, "status.go"), filepath.Join(src, "kind.go")}
+	outs := []string{filepath.Join(out, "a.go"), filepath.Join(out, "b.go")}
+	cfg := generator.Config{Outputs: []string{generator.OutputGo, generator.OutputJSON}}
+	if err := generatePairs(ctx, srcs, outs, cfg); err != nil {
+		t.Fatalf("failed to generate, got %v", err)
+	}
+	for _, name := range []string{"a.go", "statuses_mapping.json", "b.go", "kinds_mapping.json"} {
+		if _, err := os.Stat(filepath.Join(out, name)); err != nil {
+			t.Errorf("expected %s to be generated next to the -out file, got %v", name, err)
+		}
+	}
+	b, err := os.ReadFile(outs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "type Kind struct") {
+		t.Errorf("expected each -src to be generated into its -out file, got\n%s", b)
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("expected nothing to be written next to the sources, got %d files", len(entries))
+	}
+	if err := generatePairs(ctx, srcs, outs[:1], cfg); err == nil {
+		t.Errorf("expected an error for a -src without an -out")
+	}
+	if err := generatePairs(ctx, []string{filepath.Join(src, "missing.go")}, outs[:1], cfg); err == nil {
+		t.Errorf("expected an error for a missing source")
+	}
+	if err := generatePairs(ctx, srcs[:1], outs[:1], generator.Config{Lock: true}); err == nil {
+		t.Errorf("expected an error for an option needing the source directory")
+	}
+}
+
+func TestGenerateStdout(t *testing.T) {
+	ctx := context.Background()
+	dir := writeSources(t, map[string]string{"status.go": statusSource})
+	filename := filepath.Join(dir, "status.go")
+	tests := []struct {
+		name      string
+		filename  string
+		fromStdin bool
+		cfg       generator.Config
+		ok        bool
+	}{
+		{"file", filename, false, generator.Config{}, true},
+		{"stdin", filepath.Join(dir, "named.go"), true, generator.Config{}, true},
+		{"missing file", filepath.Join(dir, "missing.go"), false, generator.Config{}, false},
+		{"several files", filename, false, generator.Config{Outputs: []string{generator.OutputGo, generator.OutputJSON}}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			redirect(t, &os.Stdin, statusSource)
+			stdout := redirect(t, &os.Stdout, "")
+			err := generateStdout(ctx, tc.filename, tc.fromStdin, tc.cfg)
+			if (err == nil) != tc.ok {
+				t.Fatalf("expected ok %v, got %v", tc.ok, err)
+			}
+			if _, err := stdout.Seek(0, io.SeekStart); err != nil {
+				t.Fatal(err)
+			}
+			b, err := io.ReadAll(stdout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.ok != strings.Contains(string(b), "type Status struct") {
+				t.Errorf("expected the generated file on stdout %v, got\n%s", tc.ok, b)
+			}
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 1 {
+				t.Errorf("expected nothing to be written next to the source, got %d files", len(entries))
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/textproto"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/zarldev/goenums/pkg/generator"
+)
+
+// serveLSP runs the serve-lsp command, a language server on stdin and stdout, and
+// returns the exit code. Only files with a goenums go:generate directive are handled:
+// their buffers are diagnosed as they change and the enum is regenerated on save,
+// with the flags of the directive.
+func serveLSP(args []string) int {
+	fs := flag.NewFlagSet("serve-lsp", flag.ExitOnError)
+	logFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: goenums serve-lsp [options]\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return newLSPServer(os.Stdin, os.Stdout).run(ctx)
+}
+
+// lspServer is a language server speaking JSON-RPC over a Content-Length framed stream.
+type lspServer struct {
+	in  *bufio.Reader
+	out io.Writer
+	// docs are the contents of the open buffers by URI
+	docs map[string][]byte
+	// shutdown is set once the client has asked the server to shut down
+	shutdown bool
+}
+
+// newLSPServer returns a language server reading messages from in and writing
+// them to out.
+func newLSPServer(in io.Reader, out io.Writer) *lspServer {
+	return &lspServer{
+		in:   bufio.NewReader(in),
+		out:  out,
+		docs: make(map[string][]byte),
+	}
+}
+
+// run serves the client and returns the exit code, which is 0 only when the
+// client asked the server to shut down before exiting.
+func (s *lspServer) run(ctx context.Context) int {
+	err := s.serve(ctx)
+	if err != nil && !errors.Is(err, io.EOF) {
+		slog.Error("language server failed", "error", err)
+		return 1
+	}
+	if !s.shutdown {
+		return 1
+	}
+	return 0
+}
+
+// lspMessage is a JSON-RPC request, notification or response.
+type lspMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *lspError       `json:"error,omitempty"`
+}
+
+// lspError is the error of a JSON-RPC response.
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// JSON-RPC error codes.
+const (
+	lspParseError     = -32700
+	lspInvalidParams  = -32602
+	lspMethodNotFound = -32601
+)
+
+type lspDocument struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type lspDocumentParams struct {
+	TextDocument   lspDocument `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+// LSP message types and diagnostic severities.
+const (
+	lspSeverityError = 1
+	lspMessageError  = 1
+	lspMessageInfo   = 3
+)
+
+// serve handles messages until the client exits or the input ends.
+func (s *lspServer) serve(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		body, err := s.read()
+		if err != nil {
+			return err
+		}
+		var msg lspMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			if err := s.respondError(nil, lspParseError, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		if err := s.handle(ctx, msg); err != nil {
+			return err
+		}
+	}
+}
+
+// handle handles a single request or notification, returning an error only
+// when the response cannot be written.
+func (s *lspServer) handle(ctx context.Context, msg lspMessage) error {
+	var params lspDocumentParams
+	if msg.Params != nil && strings.HasPrefix(msg.Method, "textDocument/") {
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			if msg.ID == nil {
+				return nil
+			}
+			return s.respondError(msg.ID, lspInvalidParams, err.Error())
+		}
+	}
+	uri := params.TextDocument.URI
+	switch msg.Method {
+	case "initialize":
+		return s.respond(msg.ID, map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync": map[string]any{
+					"openClose": true,
+					// full document sync
+					"change": 1,
+					"save":   map[string]any{"includeText": false},
+				},
+			},
+			"serverInfo": map[string]string{"name": "goenums", "version": VERSION},
+		})
+	case "shutdown":
+		s.shutdown = true
+		return s.respond(msg.ID, nil)
+	case "textDocument/didOpen":
+		s.docs[uri] = []byte(params.TextDocument.Text)
+		return s.diagnose(ctx, uri)
+	case "textDocument/didChange":
+		if n := len(params.ContentChanges); n > 0 {
+			s.docs[uri] = []byte(params.ContentChanges[n-1].Text)
+		}
+		return s.diagnose(ctx, uri)
+	case "textDocument/didSave":
+		if err := s.regenerate(ctx, uri); err != nil {
+			return err
+		}
+		return s.diagnose(ctx, uri)
+	case "textDocument/didClose":
+		delete(s.docs, uri)
+		return s.publish(uri, []lspDiagnostic{})
+	}
+	if msg.ID == nil {
+		// notifications the server does not handle are ignored
+		return nil
+	}
+	return s.respondError(msg.ID, lspMethodNotFound, "method not found: "+msg.Method)
+}
+
+// diagnose publishes the problems generating the enum in the buffer of uri,
+// which are only reported for files with a goenums go:generate directive.
+func (s *lspServer) diagnose(ctx context.Context, uri string) error {
+	src, ok := s.docs[uri]
+	if !ok {
+		return nil
+	}
+	diags := []lspDiagnostic{}
+	filename, err := uriPath(uri)
+	if err != nil {
+		return s.publish(uri, diags)
+	}
+	c := generator.Candidate{Filename: filename, Args: generator.DirectiveArgs(filename, src)}
+	if c.Args == nil {
+		return s.publish(uri, diags)
+	}
+	cfg, err := candidateConfig(c, generator.Config{})
+	if err != nil {
+		diags = append(diags, newDiagnostic(src, generator.Diagnostic{Line: 1, Column: 1, Message: err.Error()}))
+		return s.publish(uri, diags)
+	}
+	found, err := generator.Diagnose(ctx, filename, src, cfg)
+	if err != nil {
+		return err
+	}
+	for _, d := range found {
+		diags = append(diags, newDiagnostic(src, d))
+	}
+	return s.publish(uri, diags)
+}
+
+// regenerate generates the enum in the saved file of uri when it has a goenums
+// go:generate directive, telling the client when generation fails.
+func (s *lspServer) regenerate(ctx context.Context, uri string) error {
+	filename, err := uriPath(uri)
+	if err != nil {
+		return nil
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return s.showMessage(lspMessageError, fmt.Sprintf("goenums: failed to read %s: %v", filename, err))
+	}
+	c := generator.Candidate{Filename: filename, Args: generator.DirectiveArgs(filename, src)}
+	if c.Args == nil {
+		return nil
+	}
+	if err := generateCandidate(ctx, c, generator.Config{}); err != nil {
+		slog.Error("failed to generate enums", "file", filename, "error", err)
+		return s.showMessage(lspMessageError, fmt.Sprintf("goenums: failed to generate %s: %v", filepath.Base(filename), err))
+	}
+	slog.Debug("generated enums", "file", filename)
+	return s.notify("window/logMessage", map[string]any{
+		"type":    lspMessageInfo,
+		"message": "goenums: generated " + filepath.Base(filename),
+	})
+}
+
+// newDiagnostic converts a diagnostic with a 1-based byte column into an LSP
+// diagnostic with a 0-based UTF-16 position in src.
+func newDiagnostic(src []byte, d generator.Diagnostic) lspDiagnostic {
+	pos := lspPosition{Line: max(d.Line-1, 0)}
+	lines := strings.SplitN(string(src), "\n", pos.Line+2)
+	if pos.Line < len(lines) {
+		line := lines[pos.Line]
+		col := min(max(d.Column-1, 0), len(line))
+		pos.Character = len(utf16.Encode([]rune(line[:col])))
+	}
+	return lspDiagnostic{
+		Range:    lspRange{Start: pos, End: pos},
+		Severity: lspSeverityError,
+		Source:   "goenums",
+		Message:  d.Message,
+	}
+}
+
+// uriPath returns the path of the file URI.
+func uriPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported URI scheme %q", u.Scheme)
+	}
+	p := u.Path
+	// file:///C:/dir on windows
+	if runtime.GOOS == "windows" && len(p) > 2 && p[0] == '/' && p[2] == ':' {
+		p = p[1:]
+	}
+	return filepath.FromSlash(p), nil
+}
+
+// publish sends the diagnostics of uri to the client, replacing the previous ones.
+func (s *lspServer) publish(uri string, diags []lspDiagnostic) error {
+	return s.notify("textDocument/publishDiagnostics", map[string]any{
+		"uri":         uri,
+		"diagnostics": diags,
+	})
+}
+
+// showMessage asks the client to show the message to the user.
+func (s *lspServer) showMessage(typ int, message string) error {
+	return s.notify("window/showMessage", map[string]any{"type": typ, "message": message})
+}
+
+// notify sends a notification to the client.
+func (s *lspServer) notify(method string, params any) error {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return s.write(lspMessage{JSONRPC: "2.0", Method: method, Params: b})
+}
+
+// respond sends the result of the request with the id to the client.
+func (s *lspServer) respond(id json.RawMessage, result any) error {
+	b, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return s.write(lspMessage{JSONRPC: "2.0", ID: id, Result: b})
+}
+
+// respondError sends an error response to the request with the id to the client.
+func (s *lspServer) respondError(id json.RawMessage, code int, message string) error {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return s.write(lspMessage{JSONRPC: "2.0", ID: id, Error: &lspError{Code: code, Message: message}})
+}
+
+// read returns the body of the next message from the client.
+func (s *lspServer) read() ([]byte, error) {
+	header, err := textproto.NewReader(s.in).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Length header: %w", err)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.in, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// write sends a message to the client.
+func (s *lspServer) write(msg lspMessage) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(b), b)
+	return err
+}
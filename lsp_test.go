@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/zarldev/goenums/pkg/generator"
+)
+
+// lspClient drives a language server over pipes.
+type lspClient struct {
+	t    *testing.T
+	in   *io.PipeWriter
+	out  *bufio.Reader
+	code chan int
+}
+
+// startLSP starts a language server, stopped when the test ends.
+func startLSP(t *testing.T) *lspClient {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	c := &lspClient{t: t, in: inW, out: bufio.NewReader(outR), code: make(chan int, 1)}
+	go func() {
+		c.code <- newLSPServer(inR, outW).run(context.Background())
+		outW.Close()
+	}()
+	t.Cleanup(func() {
+		inW.Close()
+		outR.Close()
+	})
+	return c
+}
+
+// write sends the raw bytes to the server.
+func (c *lspClient) write(s string) {
+	c.t.Helper()
+	if _, err := io.WriteString(c.in, s); err != nil {
+		c.t.Fatalf("failed to write to the server, got %v", err)
+	}
+}
+
+// send sends a message with the id, or a notification when id is empty.
+func (c *lspClient) send(id, method string, params any) {
+	c.t.Helper()
+	msg := map[string]any{"jsonrpc": "2.0", "method": method}
+	if id != "" {
+		msg["id"] = json.RawMessage(id)
+	}
+	if params != nil {
+		msg["params"] = params
+	}
+	b, err := json.Marshal(msg)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	c.write(fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(b), b))
+}
+
+// receive returns the next message from the server.
+func (c *lspClient) receive() lspMessage {
+	c.t.Helper()
+	header, err := textproto.NewReader(c.out).ReadMIMEHeader()
+	if err != nil {
+		c.t.Fatalf("failed to read the header, got %v", err)
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		c.t.Fatalf("expected a Content-Length header, got %v", header)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.out, body); err != nil {
+		c.t.Fatalf("failed to read the body, got %v", err)
+	}
+	var msg lspMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		c.t.Fatalf("failed to decode %s, got %v", body, err)
+	}
+	return msg
+}
+
+// diagnostics returns the diagnostics of the next message, which must publish
+// them for uri.
+func (c *lspClient) diagnostics(uri string) []lspDiagnostic {
+	c.t.Helper()
+	msg := c.receive()
+	if msg.Method != "textDocument/publishDiagnostics" {
+		c.t.Fatalf("expected diagnostics, got %s %s", msg.Method, msg.Params)
+	}
+	var params struct {
+		URI         string          `json:"uri"`
+		Diagnostics []lspDiagnostic `json:"diagnostics"`
+	}
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		c.t.Fatal(err)
+	}
+	if params.URI != uri {
+		c.t.Errorf("expected diagnostics for %s, got %s", uri, params.URI)
+	}
+	if params.Diagnostics == nil {
+		c.t.Errorf("expected the diagnostics to be a list, got null")
+	}
+	return params.Diagnostics
+}
+
+// wait returns the exit code of the server.
+func (c *lspClient) wait() int {
+	c.t.Helper()
+	select {
+	case code := <-c.code:
+		return code
+	case <-time.After(5 * time.Second):
+		c.t.Fatal("timed out waiting for the server to exit")
+		return 0
+	}
+}
+
+func TestLSPLifecycle(t *testing.T) {
+	c := startLSP(t)
+	c.send("1", "initialize", map[string]any{"capabilities": map[string]any{}})
+	msg := c.receive()
+	if string(msg.ID) != "1" || msg.Error != nil {
+		t.Fatalf("expected the result of request 1, got %+v", msg)
+	}
+	var result struct {
+		Capabilities struct {
+			TextDocumentSync struct {
+				OpenClose bool `json:"openClose"`
+				Change    int  `json:"change"`
+			} `json:"textDocumentSync"`
+		} `json:"capabilities"`
+		ServerInfo struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"serverInfo"`
+	}
+	if err := json.Unmarshal(msg.Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if sync := result.Capabilities.TextDocumentSync; !sync.OpenClose || sync.Change != 1 {
+		t.Errorf("expected open and close and full document sync, got %+v", sync)
+	}
+	if result.ServerInfo.Name != "goenums" || result.ServerInfo.Version != VERSION {
+		t.Errorf("expected goenums %s, got %+v", VERSION, result.ServerInfo)
+	}
+	c.send("2", "shutdown", nil)
+	if msg := c.receive(); string(msg.ID) != "2" || string(msg.Result) != "null" || msg.Error != nil {
+		t.Errorf("expected a null result for request 2, got %+v", msg)
+	}
+	c.send("", "exit", nil)
+	if code := c.wait(); code != 0 {
+		t.Errorf("expected exit code 0 after shutdown, got %d", code)
+	}
+}
+
+func TestLSPExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(c *lspClient)
+		code int
+	}{
+		{"exit without shutdown", func(c *lspClient) {
+			c.send("", "exit", nil)
+		}, 1},
+		{"end of input without shutdown", func(c *lspClient) {
+			c.in.Close()
+		}, 1},
+		{"end of input after shutdown", func(c *lspClient) {
+			c.send("1", "shutdown", nil)
+			c.receive()
+			c.in.Close()
+		}, 0},
+		{"invalid Content-Length", func(c *lspClient) {
+			c.write("Content-Length: many\r\n\r\n{}")
+		}, 1},
+		{"truncated body", func(c *lspClient) {
+			c.write("Content-Length: 100\r\n\r\n{}")
+			c.in.Close()
+		}, 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := startLSP(t)
+			tc.run(c)
+			if code := c.wait(); code != tc.code {
+				t.Errorf("expected exit code %d, got %d", tc.code, code)
+			}
+		})
+	}
+}
+
+func TestLSPFraming(t *testing.T) {
+	c := startLSP(t)
+	// the body is not JSON
+	c.write("Content-Length: 1\r\n\r\n{")
+	if msg := c.receive(); string(msg.ID) != "null" || msg.Error == nil || msg.Error.Code != lspParseError {
+		t.Errorf("expected a parse error, got %+v", msg)
+	}
+	// other headers are allowed and the length counts bytes, not characters
+	body := `{"jsonrpc":"2.0","id":"café","method":"shutdown"}`
+	c.write("Content-Type: application/vscode-jsonrpc; charset=utf-8\r\nContent-Length: " + strconv.Itoa(len(body)) + "\r\n\r\n" + body)
+	if msg := c.receive(); string(msg.ID) != `"café"` || msg.Error != nil {
+		t.Errorf("expected the result of request café, got %+v", msg)
+	}
+	// unknown notifications are ignored and unknown requests rejected
+	c.send("", "$/cancelRequest", map[string]any{"id": 1})
+	c.send("3", "textDocument/hover", map[string]any{})
+	if msg := c.receive(); string(msg.ID) != "3" || msg.Error == nil || msg.Error.Code != lspMethodNotFound {
+		t.Errorf("expected method not found for request 3, got %+v", msg)
+	}
+	c.send("4", "textDocument/didOpen", "not an object")
+	if msg := c.receive(); string(msg.ID) != "4" || msg.Error == nil || msg.Error.Code != lspInvalidParams {
+		t.Errorf("expected invalid params for request 4, got %+v", msg)
+	}
+	c.send("", "exit", nil)
+	if code := c.wait(); code != 0 {
+		t.Errorf("expected exit code 0 after shutdown, got %d", code)
+	}
+}
+
+func TestLSPDocuments(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "status.go")
+	uri := "file://" + filepath.ToSlash(filename)
+	if runtime.GOOS == "windows" {
+		uri = "file:///" + filepath.ToSlash(filename)
+	}
+	valid := "package status\n\n//go:generate goenums status.go\n\ntype status int\n\nconst (\n\tunknown status = iota // invalid\n\tactive\n)\n"
+	broken := "package status\n\n//go:generate goenums status.go\n\ntype status int\n\nconst (\n\tunknown status = iota // invalid\n\tcafé // \"Café\"\n\t\"thé\" ☕ x\n)\n"
+	c := startLSP(t)
+	c.send("", "textDocument/didOpen", map[string]any{"textDocument": map[string]any{"uri": uri, "text": broken}})
+	diags := c.diagnostics(uri)
+	if len(diags) < 2 {
+		t.Fatalf("expected the syntax errors, got %+v", diags)
+	}
+	// the character is counted in UTF-16 code units after the é
+	if got := diags[1].Range.Start; got != (lspPosition{Line: 9, Character: 7}) {
+		t.Errorf("expected the illegal character at 9:7, got %+v", got)
+	}
+	c.send("", "textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri},
+		"contentChanges": []map[string]any{{"text": broken}, {"text": valid}},
+	})
+	if diags := c.diagnostics(uri); len(diags) != 0 {
+		t.Errorf("expected the last change to have no diagnostics, got %+v", diags)
+	}
+	// saving generates the enum from the file on disk
+	if err := os.WriteFile(filename, []byte(valid), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c.send("", "textDocument/didSave", map[string]any{"textDocument": map[string]any{"uri": uri}})
+	if msg := c.receive(); msg.Method != "window/logMessage" {
+		t.Errorf("expected the generation to be logged, got %s %s", msg.Method, msg.Params)
+	}
+	c.diagnostics(uri)
+	if _, err := os.Stat(filepath.Join(dir, "statuses_enums.go")); err != nil {
+		t.Errorf("expected the enum to be generated on save, got %v", err)
+	}
+	c.send("", "textDocument/didClose", map[string]any{"textDocument": map[string]any{"uri": uri}})
+	if diags := c.diagnostics(uri); len(diags) != 0 {
+		t.Errorf("expected closing to clear the diagnostics, got %+v", diags)
+	}
+	// files without a goenums directive are not diagnosed
+	other := "file://" + filepath.ToSlash(filepath.Join(dir, "other.go"))
+	c.send("", "textDocument/didOpen", map[string]any{"textDocument": map[string]any{"uri": other, "text": "package status\n\nfunc {"}})
+	if diags := c.diagnostics(other); len(diags) != 0 {
+		t.Errorf("expected no diagnostics without a directive, got %+v", diags)
+	}
+}
+
+func TestNewDiagnostic(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		line   int
+		column int
+		want   lspPosition
+	}{
+		{"ascii", "package x\nconst y\n", 2, 7, lspPosition{Line: 1, Character: 6}},
+		{"two byte rune", "\tcafé x", 1, 8, lspPosition{Line: 0, Character: 6}},
+		{"surrogate pair", "🙂 x", 1, 6, lspPosition{Line: 0, Character: 3}},
+		{"column past the line", "é\nx", 1, 10, lspPosition{Line: 0, Character: 1}},
+		{"line past the source", "x", 5, 3, lspPosition{Line: 4, Character: 0}},
+		{"unset position", "x", 0, 0, lspPosition{Line: 0, Character: 0}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newDiagnostic([]byte(tc.src), generator.Diagnostic{Line: tc.line, Column: tc.column, Message: "m"})
+			want := lspDiagnostic{
+				Range:    lspRange{Start: tc.want, End: tc.want},
+				Severity: lspSeverityError,
+				Source:   "goenums",
+				Message:  "m",
+			}
+			if !reflect.DeepEqual(d, want) {
+				t.Errorf("expected %+v, got %+v", want, d)
+			}
+		})
+	}
+}
+
+func TestURIPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file URIs have a drive letter on windows")
+	}
+	tests := []struct {
+		uri  string
+		want string
+		ok   bool
+	}{
+		{"file:///src/status.go", "/src/status.go", true},
+		{"file:///src/my%20enums/caf%C3%A9.go", "/src/my enums/café.go", true},
+		{"untitled:Untitled-1", "", false},
+		{"https://example.com/status.go", "", false},
+		{"file://%zz", "", false},
+	}
+	for _, tc := range tests {
+		got, err := uriPath(tc.uri)
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: expected ok %v, got %v", tc.uri, tc.ok, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.uri, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidField is returned when the annotations of an extra value are not
+// understood or one of its values breaks them.
+var ErrInvalidField = fmt.Errorf("invalid extra value")
+
+// Annotations of an extra value, written after its type in the schema comment,
+// e.g. Gravity[float64,min=0,unit=m/s²], or in the goenums tag of the field of
+// the struct named by the fields directive.
+const (
+	// unitAnnotation is the unit of the value, written into the documentation.
+	unitAnnotation = "unit"
+	// minAnnotation and maxAnnotation bound the value of a numeric extra value,
+	// inclusively, and generation fails for a constant outside the bounds.
+	minAnnotation = "min"
+	maxAnnotation = "max"
+)
+
+// annotateField sets the annotations of the extra value from their key=value
+// options, such as min=0 and unit=m/s².
+func annotateField(pair nameTypePair, options []string) (nameTypePair, error) {
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(option, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || value == "" {
+			return pair, fmt.Errorf("%w: %s: expected key=value, got %s", ErrInvalidField, pair.Name, strconv.Quote(option))
+		}
+		switch key {
+		case unitAnnotation:
+			pair.Unit = value
+		case minAnnotation, maxAnnotation:
+			if _, err := fieldConstant(pair.Type, value); err != nil {
+				return pair, fmt.Errorf("%w: %s: %s %s: %w", ErrInvalidField, pair.Name, key, value, err)
+			}
+			if key == minAnnotation {
+				pair.Min = value
+			} else {
+				pair.Max = value
+			}
+		default:
+			return pair, fmt.Errorf("%w: %s: unknown annotation %s, expected %s, %s or %s", ErrInvalidField, pair.Name, key, unitAnnotation, minAnnotation, maxAnnotation)
+		}
+	}
+	return pair, nil
+}
+
+// applyAnnotations moves the annotations written after the types of the schema
+// comment into the extra values of the type and of each constant, leaving their
+// types alone.
+func applyAnnotations(enums []Enum, nameTPairs []nameTypePair) ([]Enum, []nameTypePair, error) {
+	annotated := make(map[string]nameTypePair)
+	for i, pair := range nameTPairs {
+		typ, options, ok := strings.Cut(pair.Type, ",")
+		if !ok {
+			continue
+		}
+		pair.Type = strings.TrimSpace(typ)
+		pair, err := annotateField(pair, strings.Split(options, ","))
+		if err != nil {
+			return nil, nil, err
+		}
+		nameTPairs[i] = pair
+		annotated[pair.Name] = pair
+	}
+	if len(annotated) == 0 {
+		return enums, nameTPairs, nil
+	}
+	for i := range enums {
+		for j, pair := range enums[i].TypeInfo.NameTypePairs {
+			if a, ok := annotated[pair.Name]; ok {
+				a.Value = pair.Value
+				a.Unexported = pair.Unexported
+				enums[i].TypeInfo.NameTypePairs[j] = a
+			}
+		}
+	}
+	return enums, nameTPairs, nil
+}
+
+// checkFieldBounds returns ErrInvalidField when the extra value of a valid
+// constant is outside the bounds of its field.
+func checkFieldBounds(enums []Enum, nameTPairs []nameTypePair) error {
+	bounded := make(map[string]nameTypePair)
+	for _, pair := range nameTPairs {
+		if pair.Min != "" || pair.Max != "" {
+			bounded[pair.Name] = pair
+		}
+	}
+	if len(bounded) == 0 {
+		return nil
+	}
+	for _, e := range enums {
+		if !e.Info.Valid {
+			continue
+		}
+		for _, pair := range e.TypeInfo.NameTypePairs {
+			field, ok := bounded[pair.Name]
+			if !ok {
+				continue
+			}
+			v, err := fieldConstant(field.Type, pair.Value)
+			if err != nil {
+				return fmt.Errorf("%w: %s of %s cannot be checked against its bounds: %w", ErrInvalidField, pair.Name, e.Info.Name, err)
+			}
+			if field.Min != "" {
+				if lo, _ := fieldConstant(field.Type, field.Min); constant.Compare(v, token.LSS, lo) {
+					return fmt.Errorf("%w: %s of %s is %s, below the minimum %s", ErrInvalidField, pair.Name, e.Info.Name, pair.Value, field.Min)
+				}
+			}
+			if field.Max != "" {
+				if hi, _ := fieldConstant(field.Type, field.Max); constant.Compare(v, token.GTR, hi) {
+					return fmt.Errorf("%w: %s of %s is %s, above the maximum %s", ErrInvalidField, pair.Name, e.Info.Name, pair.Value, field.Max)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// fieldConstant returns the value of the Go constant expression expr converted
+// to typ, which must be a numeric type.
+func fieldConstant(typ, expr string) (constant.Value, error) {
+	tv, err := types.Eval(token.NewFileSet(), nil, token.NoPos, typ+"("+expr+")")
+	if err != nil {
+		return nil, err
+	}
+	if basic, ok := tv.Type.Underlying().(*types.Basic); !ok || basic.Info()&types.IsNumeric == 0 {
+		return nil, fmt.Errorf("%s is not a numeric type", typ)
+	}
+	if tv.Value == nil {
+		return nil, fmt.Errorf("%s is not constant", expr)
+	}
+	return tv.Value, nil
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// BaseUsage is a use of the unexported base type of an enum or one of its
+// constants outside the source and generated files, found by FindBaseUsage.
+type BaseUsage struct {
+	// Filename, Line and Column of the identifier, starting at 1.
+	Filename string
+	Line     int
+	Column   int
+	// Name is the base type or constant used.
+	Name string
+	// Replacement is the wrapper type or container value to use instead.
+	Replacement string
+}
+
+// String formats the usage as go vet reports its findings.
+func (u BaseUsage) String() string {
+	return fmt.Sprintf("%s:%d:%d: use %s instead of %s", u.Filename, u.Line, u.Column, u.Replacement, u.Name)
+}
+
+// FindBaseUsage reports every identifier referring to the base type or a
+// constant of the enums found by FindEnums in the other files of their package,
+// tests included, so code can be migrated from the raw constants to the wrapper
+// one file at a time. config returns the options each enum is generated with,
+// which decide the names of the wrapper and its container. The check is
+// syntactic: identifiers declared in the file itself shadow the package ones.
+func FindBaseUsage(ctx context.Context, candidates []Candidate, config func(Candidate) (Config, error)) ([]BaseUsage, error) {
+	type pkg struct {
+		name         string
+		sources      map[string]bool
+		replacements map[string]string
+	}
+	pkgs := make(map[string]*pkg)
+	var dirs []string
+	for _, c := range candidates {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		cfg, err := config(c)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Filename, err)
+		}
+		src, err := os.ReadFile(c.Filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", c.Filename, err)
+		}
+		rep, err := Parse(ctx, c.Filename, src, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Filename, err)
+		}
+		dir := filepath.Dir(c.Filename)
+		p, ok := pkgs[dir]
+		if !ok {
+			p = &pkg{name: rep.PackageName, sources: make(map[string]bool), replacements: make(map[string]string)}
+			pkgs[dir] = p
+			dirs = append(dirs, dir)
+		}
+		p.sources[filepath.Clean(c.Filename)] = true
+		p.replacements[rep.TypeInfo.Name] = rep.TypeInfo.Camel
+		for _, e := range rep.Enums {
+			p.replacements[e.Info.Name] = rep.containerRef() + "." + e.Info.Upper
+		}
+	}
+	sort.Strings(dirs)
+	var usages []BaseUsage
+	fset := token.NewFileSet()
+	for _, dir := range dirs {
+		p := pkgs[dir]
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if p.sources[filepath.Clean(file)] {
+				continue
+			}
+			node, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			// generated files and external test packages cannot be migrated here
+			if ast.IsGenerated(node) || node.Name.Name != p.name {
+				continue
+			}
+			// the identifiers not declared in the file refer to the package scope
+			for _, ident := range node.Unresolved {
+				replacement, ok := p.replacements[ident.Name]
+				if !ok {
+					continue
+				}
+				pos := fset.Position(ident.Pos())
+				usages = append(usages, BaseUsage{
+					Filename:    file,
+					Line:        pos.Line,
+					Column:      pos.Column,
+					Name:        ident.Name,
+					Replacement: replacement,
+				})
+			}
+		}
+	}
+	return usages, nil
+}
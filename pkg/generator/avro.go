@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+// avroSections are the writers for the Avro enum schema.
+var avroSections = []func(io.StringWriter, EnumRepresentation){
+	writeAvroSchema,
+}
+
+// avroSymbol matches the names Avro accepts as enum symbols.
+var avroSymbol = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// checkAvroSymbols returns ErrInvalidConfig when a name of the enum is not a
+// valid Avro symbol, as renaming it in the schema would break the lockstep with
+// the names the Go code writes.
+func checkAvroSymbols(rep EnumRepresentation) error {
+	for _, e := range rep.Enums {
+		if !avroSymbol.MatchString(e.Info.AlternateName) {
+			return fmt.Errorf("%w: %s output: %s name %q is not a valid Avro symbol", ErrInvalidConfig, OutputAvro, e.Info.Name, e.Info.AlternateName)
+		}
+	}
+	return nil
+}
+
+// writeAvroSchema writes the enum as an Avro enum schema with a symbol for every
+// constant in declaration order. The first constant marked invalid, preferring the
+// sentinel, is the default readers fall back to for symbols they do not know.
+func writeAvroSchema(w io.StringWriter, rep EnumRepresentation) {
+	schema := struct {
+		Type      string   `json:"type"`
+		Name      string   `json:"name"`
+		Namespace string   `json:"namespace,omitempty"`
+		Doc       string   `json:"doc"`
+		Symbols   []string `json:"symbols"`
+		Default   string   `json:"default,omitempty"`
+	}{
+		Type:      "enum",
+		Name:      rep.TypeInfo.Camel,
+		Namespace: rep.PackageName,
+		Doc:       "Code generated by goenums. DO NOT EDIT.",
+	}
+	for _, e := range rep.Enums {
+		schema.Symbols = append(schema.Symbols, e.Info.AlternateName)
+		if !e.Info.Valid && schema.Default == "" {
+			schema.Default = e.Info.AlternateName
+		}
+	}
+	if s, ok := rep.sentinel(); ok {
+		schema.Default = s.Info.AlternateName
+	}
+	// strings always marshal
+	b, _ := json.MarshalIndent(schema, "", "  ")
+	w.WriteString(string(b) + "\n")
+}
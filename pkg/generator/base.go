@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// baseSections write the BaseMethods output, the methods and Parse function
+// of the enum on its base type rather than on a wrapper type.
+var baseSections = []func(io.StringWriter, EnumRepresentation){
+	writeGeneratedComment,
+	writePackage,
+	writeBaseImports,
+	writeCompileCheck,
+	writeStringMethod,
+	writeBaseIsValidMethod,
+	writeBaseParseMethod,
+	writeBaseJSONMethods,
+	writeBaseSQLMethods,
+}
+
+// checkBaseMethods rejects enums with extra values, which have no wrapper type
+// to be stored in when the methods are on the base type.
+func checkBaseMethods(rep EnumRepresentation) error {
+	if len(rep.TypeInfo.NameTypePairs) > 0 {
+		return fmt.Errorf("base methods only generate plain enums, %s declares extra values", rep.TypeInfo.Name)
+	}
+	return nil
+}
+
+func writeBaseImports(w io.StringWriter, rep EnumRepresentation) {
+	imports := []string{"fmt", "strconv"}
+	if rep.hasHandler(HandlerJSON) {
+		imports = append(imports, "bytes")
+	}
+	if rep.hasHandler(HandlerSQL) {
+		imports = append(imports, "database/sql/driver")
+	}
+	slices.Sort(imports)
+	w.WriteString("import (\n")
+	for _, imp := range imports {
+		w.WriteString("\t" + strconv.Quote(imp) + "\n")
+	}
+	w.WriteString(")\n\n")
+}
+
+func writeBaseIsValidMethod(w io.StringWriter, rep EnumRepresentation) {
+	var valid []string
+	for _, e := range rep.Enums {
+		if e.Info.Valid {
+			valid = append(valid, e.Info.Name)
+		}
+	}
+	w.WriteString("\n// IsValid reports whether the " + rep.TypeInfo.Name + " is one of the valid constants.\n")
+	w.WriteString("func (i " + rep.TypeInfo.Name + ") IsValid() bool {\n")
+	if len(valid) == 0 {
+		w.WriteString("\treturn false\n")
+		w.WriteString("}\n\n")
+		return
+	}
+	w.WriteString("\tswitch i {\n")
+	w.WriteString("\tcase " + strings.Join(valid, ", ") + ":\n")
+	w.WriteString("\t\treturn true\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn false\n")
+	w.WriteString("}\n\n")
+}
+
+// writeBaseParseMethod writes Parse returning the base type, which has no
+// invalid value of its own, so anything but a valid constant is an error.
+func writeBaseParseMethod(w io.StringWriter, rep EnumRepresentation) {
+	typ := rep.TypeInfo.Name
+	w.WriteString("// Parse" + rep.TypeInfo.Camel + " parses a name, alias or number to a valid " + typ + ".\n")
+	w.WriteString("func Parse" + rep.TypeInfo.Camel + "(a any) (" + typ + ", error) {\n")
+	w.WriteString("\tvar res " + typ + "\n")
+	w.WriteString("\tok := false\n")
+	w.WriteString("\tswitch v := a.(type) {\n")
+	w.WriteString("\tcase " + typ + ":\n")
+	w.WriteString("\t\tres, ok = v, true\n")
+	w.WriteString("\tcase []byte:\n")
+	w.WriteString("\t\tres, ok = stringTo" + rep.TypeInfo.Camel + "(string(v))\n")
+	w.WriteString("\tcase string:\n")
+	w.WriteString("\t\tres, ok = stringTo" + rep.TypeInfo.Camel + "(v)\n")
+	w.WriteString("\tcase fmt.Stringer:\n")
+	w.WriteString("\t\tres, ok = stringTo" + rep.TypeInfo.Camel + "(v.String())\n")
+	// numbers the base type cannot hold would wrap around to another constant
+	for _, t := range []string{"int", "int64", "int32"} {
+		w.WriteString("\tcase " + t + ":\n")
+		w.WriteString("\t\tres = " + typ + "(v)\n")
+		w.WriteString("\t\tok = " + t + "(res) == v\n")
+	}
+	w.WriteString("\t}\n")
+	w.WriteString("\tif !ok || !res.IsValid() {\n")
+	w.WriteString("\t\treturn res, fmt.Errorf(" + strconv.Quote(rep.errorMessage("parse")+": %v") + ", a)\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn res, nil\n")
+	w.WriteString("}\n\n")
+	names := rep.parseNames()
+	w.WriteString("func stringTo" + rep.TypeInfo.Camel + "(s string) (" + typ + ", bool) {\n")
+	w.WriteString("\tswitch s {\n")
+	for i, e := range rep.Enums {
+		cases := make([]string, len(names[i]))
+		for j, name := range names[i] {
+			cases[j] = strconv.Quote(name)
+		}
+		w.WriteString("\tcase " + strings.Join(cases, ", ") + ":\n")
+		w.WriteString("\t\treturn " + e.Info.Name + ", true\n")
+	}
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn 0, false\n")
+	w.WriteString("}\n\n")
+}
+
+func writeBaseJSONMethods(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.hasHandler(HandlerJSON) {
+		return
+	}
+	typ := rep.TypeInfo.Name
+	w.WriteString("func (i " + typ + ") MarshalJSON() ([]byte, error) {\n")
+	w.WriteString("\treturn []byte(`\"` + i.String() + `\"`), nil\n")
+	w.WriteString("}\n\n")
+	w.WriteString("func (i *" + typ + ") UnmarshalJSON(b []byte) error {\n")
+	w.WriteString("\tb = bytes.Trim(bytes.Trim(b, `\"`), ` `)\n")
+	w.WriteString("\tv, err := Parse" + rep.TypeInfo.Camel + "(b)\n")
+	w.WriteString("\tif err != nil {\n")
+	w.WriteString("\t\treturn err\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\t*i = v\n")
+	w.WriteString("\treturn nil\n")
+	w.WriteString("}\n\n")
+}
+
+func writeBaseSQLMethods(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.hasHandler(HandlerSQL) {
+		return
+	}
+	typ := rep.TypeInfo.Name
+	w.WriteString("func (i *" + typ + ") Scan(value any) error {\n")
+	if rep.SQLInt {
+		// some drivers return numeric columns as text
+		w.WriteString("\tif b, ok := value.([]byte); ok {\n")
+		w.WriteString("\t\tvalue = string(b)\n")
+		w.WriteString("\t}\n")
+		w.WriteString("\tif s, ok := value.(string); ok {\n")
+		w.WriteString("\t\tif n, err := strconv.ParseInt(s, 10, 64); err == nil {\n")
+		w.WriteString("\t\t\tvalue = n\n")
+		w.WriteString("\t\t}\n")
+		w.WriteString("\t}\n")
+	}
+	w.WriteString("\tv, err := Parse" + rep.TypeInfo.Camel + "(value)\n")
+	w.WriteString("\tif err != nil {\n")
+	w.WriteString("\t\treturn err\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\t*i = v\n")
+	w.WriteString("\treturn nil\n")
+	w.WriteString("}\n\n")
+	w.WriteString("func (i " + typ + ") Value() (driver.Value, error) {\n")
+	if rep.SQLInt {
+		w.WriteString("\treturn int64(i), nil\n")
+	} else {
+		w.WriteString("\treturn i.String(), nil\n")
+	}
+	w.WriteString("}\n\n")
+}
@@ -0,0 +1,329 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Candidate is a source file declaring an enum, found by FindEnums.
+type Candidate struct {
+	// Filename is the path of the source file declaring the enum constants.
+	Filename string
+	// ImportPath is the import path of the package holding the file.
+	ImportPath string
+	// Type is the name of the enum type.
+	Type string
+	// Args are the goenums flags of the go:generate directive for the file,
+	// nil when the file has no directive.
+	Args []string
+}
+
+// FindEnums walks the module enclosing dir, or dir itself outside a module, and
+// returns every file declaring iota constants of an unexported type, which is
+// what goenums generates from. Test files, generated files, testdata, vendor
+// and hidden directories and nested modules are skipped.
+func FindEnums(dir string) ([]Candidate, error) {
+	var candidates []Candidate
+	err := walkModule(dir, false, func(_ *token.FileSet, path string, node *ast.File) error {
+		typ := iotaType(node)
+		if typ == "" {
+			return nil
+		}
+		importPath, err := packageImportPath(filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, Candidate{
+			Filename:   path,
+			ImportPath: importPath,
+			Type:       typ,
+			Args:       generateArgs(node, filepath.Base(path)),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find enums: %w", err)
+	}
+	return candidates, nil
+}
+
+// walkModule parses every Go file of the module enclosing dir, or of dir itself
+// outside a module, with its comments and calls fn with each in lexical order
+// and the file set holding its positions.
+// Generated files, testdata, vendor and hidden directories and nested modules
+// are skipped, and test files unless tests is set.
+func walkModule(dir string, tests bool, fn func(fset *token.FileSet, path string, node *ast.File) error) error {
+	root, err := moduleRoot(dir)
+	if err != nil {
+		return err
+	}
+	fset := token.NewFileSet()
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			name := d.Name()
+			if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || (!tests && strings.HasSuffix(path, "_test.go")) {
+			return nil
+		}
+		node, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if ast.IsGenerated(node) {
+			return nil
+		}
+		return fn(fset, path, node)
+	})
+}
+
+// moduleRoot returns the directory of the go.mod enclosing dir, or dir when
+// it is not inside a module.
+func moduleRoot(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve directory: %w", err)
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		if _, err := os.Stat(filepath.Join(root, "go.mod")); err == nil {
+			return root, nil
+		}
+		if filepath.Dir(root) == root {
+			return abs, nil
+		}
+	}
+}
+
+// iotaType returns the first unexported type given to a constant declared with
+// iota, or with an explicit value in a pinned block, in the file, or an empty
+// string when there is none.
+func iotaType(node *ast.File) string {
+	for _, typ := range iotaTypes(node) {
+		if r, _ := utf8.DecodeRuneInString(typ); !unicode.IsUpper(r) {
+			return typ
+		}
+	}
+	return ""
+}
+
+// iotaTypes returns the types given to constants declared with iota, or with
+// an explicit value in a pinned block, in the file in declaration order.
+func iotaTypes(node *ast.File) []string {
+	var types []string
+	for _, decl := range node.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		pinned := hasDirective(gen.Doc, pinnedDirective)
+		for _, spec := range gen.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok || len(valueSpec.Values) == 0 {
+				continue
+			}
+			typ, ok := valueSpec.Type.(*ast.Ident)
+			if !ok || !usesIota(valueSpec.Values[0]) && !(pinned && pinnedSpec(valueSpec, typ.Name)) {
+				continue
+			}
+			if !slices.Contains(types, typ.Name) {
+				types = append(types, typ.Name)
+			}
+		}
+	}
+	return types
+}
+
+// usesIota reports whether the expression refers to iota.
+func usesIota(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == "iota" {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// generateArgs returns the flags of the goenums go:generate directive in the
+// file that generates filename, or nil when there is none.
+func generateArgs(node *ast.File, filename string) []string {
+	for _, group := range node.Comments {
+		for _, c := range group.List {
+			directive, ok := strings.CutPrefix(c.Text, "//go:generate ")
+			if !ok {
+				continue
+			}
+			fields := strings.Fields(directive)
+			for i, f := range fields {
+				command, _, _ := strings.Cut(filepath.Base(f), "@")
+				if command != "goenums" {
+					continue
+				}
+				args := fields[i+1:]
+				if len(args) > 0 && args[len(args)-1] == filename {
+					return append([]string{}, args[:len(args)-1]...)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// BatchOptions are the options of GenerateBatch.
+type BatchOptions struct {
+	// Jobs is the number of packages generated concurrently, GOMAXPROCS when not
+	// positive. The enums of a package are generated one at a time as they share
+	// files such as enums_common.go and the manifest.
+	Jobs int
+	// Retries is the number of times an enum is generated again after a transient
+	// file system error, such as a file busy or locked by another process.
+	Retries int
+	// Config returns the options the enum is generated with, the zero Config when nil.
+	Config func(Candidate) (Config, error)
+	// Metrics is called with the statistics of the run once every enum is generated.
+	Metrics func(BatchMetrics)
+}
+
+// BatchMetrics are the statistics of a GenerateBatch run.
+type BatchMetrics struct {
+	// Enums and Packages are the number of enums and packages generated.
+	Enums, Packages int
+	// Failed is the number of enums that could not be generated.
+	Failed int
+	// Retries is the number of times an enum was generated again after a transient error.
+	Retries int
+	// Duration is the wall time of the run.
+	Duration time.Duration
+}
+
+// retryDelay is the delay before the first retry, growing linearly with each one.
+const retryDelay = 50 * time.Millisecond
+
+// GenerateBatch generates the enums found by FindEnums next to their sources,
+// recording the file name relative to the package directory like go generate so
+// the command in the headers is the same. The packages are generated concurrently.
+// The errors returned are those of each candidate by index, nil for the enums
+// generated, wrapped with the file they came from.
+func GenerateBatch(ctx context.Context, candidates []Candidate, opts BatchOptions) []error {
+	start := time.Now()
+	jobs := opts.Jobs
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	// the candidates of each package directory in the order they were found
+	var dirs []string
+	byDir := make(map[string][]int)
+	for i, c := range candidates {
+		dir := filepath.Dir(c.Filename)
+		if _, ok := byDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		byDir[dir] = append(byDir[dir], i)
+	}
+	errs := make([]error, len(candidates))
+	var (
+		retries atomic.Int64
+		wg      sync.WaitGroup
+	)
+	sem := make(chan struct{}, jobs)
+	for _, dir := range dirs {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			for _, i := range byDir[dir] {
+				n, err := generateCandidate(ctx, candidates[i], opts)
+				retries.Add(int64(n))
+				if err != nil {
+					errs[i] = fmt.Errorf("%s: %w", candidates[i].Filename, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if opts.Metrics != nil {
+		m := BatchMetrics{
+			Enums:    len(candidates),
+			Packages: len(dirs),
+			Retries:  int(retries.Load()),
+			Duration: time.Since(start),
+		}
+		for _, err := range errs {
+			if err != nil {
+				m.Failed++
+			}
+		}
+		opts.Metrics(m)
+	}
+	return errs
+}
+
+// generateCandidate generates the enum, retrying after transient file system
+// errors, and returns the number of retries.
+func generateCandidate(ctx context.Context, c Candidate, opts BatchOptions) (int, error) {
+	var cfg Config
+	if opts.Config != nil {
+		var err error
+		cfg, err = opts.Config(c)
+		if err != nil {
+			return 0, err
+		}
+	}
+	for retry := 0; ; retry++ {
+		err := generateCandidateOnce(ctx, c.Filename, cfg)
+		if err == nil || retry == opts.Retries || !transient(err) {
+			return retry, err
+		}
+		select {
+		case <-ctx.Done():
+			return retry, ctx.Err()
+		case <-time.After(time.Duration(retry+1) * retryDelay):
+		}
+	}
+}
+
+// generateCandidateOnce generates the enum in filename into its directory.
+func generateCandidateOnce(ctx context.Context, filename string, cfg Config) error {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read source: %w", err)
+	}
+	return parseAndGenerate(ctx, filepath.Dir(filename), filepath.Base(filename), src, cfg)
+}
+
+// transient reports whether err is a file system error that may not happen again.
+func transient(err error) bool {
+	return errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EBUSY) || errors.Is(err, syscall.EINTR)
+}
@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// catalogEnum is an enum of the module as listed by the documentation site and
+// the export.
+type catalogEnum struct {
+	Type       string
+	Container  string
+	ImportPath string
+	Source     string
+	Header     Header
+	Fields     []catalogField
+	Values     []catalogValue
+	// Page is the file of the enum on the documentation site and Related the
+	// other enums of its package, set by WriteSite.
+	Page    string
+	Related []*catalogEnum
+}
+
+// catalogField is an extra value of an enum, linked to the page of its type
+// when the type is another enum of the package.
+type catalogField struct {
+	Name string
+	Type string
+	Unit string
+	Link string
+}
+
+// catalogValue is a constant of an enum.
+type catalogValue struct {
+	Constant string
+	Name     string
+	// DisplayName is the display tag of the constant, or its name when it has
+	// none, as returned by DisplayName.
+	DisplayName string
+	Value       int
+	Valid       bool
+	Default     bool
+	Aliases     []string
+	Description string
+	// Fields are the extra values in the order of the fields of the enum.
+	Fields []string
+}
+
+// catalog parses the enums found by FindEnums with the options returned by
+// config, sorted by import path and type.
+func catalog(ctx context.Context, candidates []Candidate, config func(Candidate) (Config, error)) ([]*catalogEnum, error) {
+	var enums []*catalogEnum
+	for _, c := range candidates {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		cfg, err := config(c)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Filename, err)
+		}
+		src, err := os.ReadFile(c.Filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", c.Filename, err)
+		}
+		rep, err := Parse(ctx, c.Filename, src, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Filename, err)
+		}
+		enums = append(enums, newCatalogEnum(c, rep))
+	}
+	sort.SliceStable(enums, func(i, j int) bool {
+		if enums[i].ImportPath != enums[j].ImportPath {
+			return enums[i].ImportPath < enums[j].ImportPath
+		}
+		return enums[i].Type < enums[j].Type
+	})
+	return enums, nil
+}
+
+// newCatalogEnum returns the catalog entry of the enum parsed from the candidate.
+func newCatalogEnum(c Candidate, rep EnumRepresentation) *catalogEnum {
+	e := &catalogEnum{
+		Type:       rep.TypeInfo.Camel,
+		Container:  rep.containerRef(),
+		ImportPath: c.ImportPath,
+		Source:     path.Join(c.ImportPath, filepath.Base(c.Filename)),
+		Header:     rep.Header,
+	}
+	for _, f := range rep.TypeInfo.NameTypePairs {
+		if f.Name != descriptionField {
+			e.Fields = append(e.Fields, catalogField{Name: f.Name, Type: f.Type, Unit: f.Unit})
+		}
+	}
+	names := rep.parseNames()
+	for i, info := range rep.Enums {
+		v := catalogValue{
+			Constant:    e.Container + "." + info.Info.Upper,
+			Name:        names[i][0],
+			DisplayName: names[i][0],
+			Value:       info.Info.Value + rep.TypeInfo.Index,
+			Valid:       info.Info.Valid,
+			Default:     info.Info.Default,
+			Aliases:     names[i][1:],
+		}
+		if display, ok := info.tagValue(displayTag); ok {
+			v.DisplayName = display
+		}
+		// only the valid values are given their extra values
+		for _, f := range info.TypeInfo.NameTypePairs {
+			if !info.Info.Valid {
+				break
+			}
+			value := f.Value
+			if s, err := strconv.Unquote(value); err == nil {
+				value = s
+			}
+			if f.Name == descriptionField {
+				v.Description = value
+				continue
+			}
+			v.Fields = append(v.Fields, value)
+		}
+		e.Values = append(e.Values, v)
+	}
+	return e
+}
@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ErrStale is returned by Check when the generated file is missing or was not
+// generated from the current source and options.
+var ErrStale = fmt.Errorf("generated enum is stale")
+
+// checksumPrefix starts the header line recording the checksum of the source.
+const checksumPrefix = "// source checksum: "
+
+// sourceChecksum returns a short checksum of the declarations the enum is generated
+// from: the enum type and every const declaration in the file, with their comments,
+// the struct named by its fields directive and the contents of its data file when
+// it has them.
+// They are printed from the syntax tree so reformatting the source does not change it.
+func sourceChecksum(fset *token.FileSet, node *ast.File, typeName string, data []byte) (string, error) {
+	h := sha256.New()
+	fields, hasFields := directiveValue(typeDoc(node, typeName), fieldsDirective)
+	for _, decl := range node.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST && !declaresType(gen, typeName) && !(hasFields && declaresType(gen, fields)) {
+			continue
+		}
+		var buf bytes.Buffer
+		err := printer.Fprint(&buf, fset, &printer.CommentedNode{Node: gen, Comments: node.Comments})
+		if err != nil {
+			return "", fmt.Errorf("failed to print source declaration: %w", err)
+		}
+		buf.WriteByte('\n')
+		h.Write(buf.Bytes())
+	}
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)[:8]), nil
+}
+
+// declaresType reports whether the declaration declares the named type.
+func declaresType(gen *ast.GenDecl, typeName string) bool {
+	if gen.Tok != token.TYPE {
+		return false
+	}
+	for _, spec := range gen.Specs {
+		if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// Check reports whether the enum generated from filename is up to date without
+// generating it, returning ErrStale when the generated Go file is missing, its
+// source checksum differs from the source or it was generated with other options.
+func Check(ctx context.Context, filename string, cfg Config) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	rep, err := parseRepresentation(filename, nil, cfg)
+	if err != nil {
+		return err
+	}
+	generated := path.Join(path.Dir(filename), rep.TypeInfo.Lower+rep.goSuffix("_enums"))
+	previous, err := readGenerated(generated, rep.TypeInfo.Camel)
+	if err != nil {
+		return err
+	}
+	switch {
+	case previous == nil:
+		return fmt.Errorf("%w: %s has not been generated", ErrStale, generated)
+	case previous.Checksum != rep.TypeInfo.Checksum:
+		return fmt.Errorf("%w: %s has changed since %s was generated", ErrStale, filename, generated)
+	case !slices.Equal(commandArgs(previous.Command), commandArgs(rep.command())):
+		return fmt.Errorf("%w: %s was generated with %q", ErrStale, generated, previous.Command)
+	}
+	return nil
+}
+
+// commandArgs returns the options of a goenums command line without the command
+// and the filename, which depends on the directory goenums was run from.
+func commandArgs(command string) []string {
+	args := splitCommand(command)
+	if len(args) < 2 {
+		return nil
+	}
+	return args[1 : len(args)-1]
+}
+
+// splitCommand splits a command line written by command into its arguments,
+// undoing the quoting of quoteArg.
+func splitCommand(command string) []string {
+	var (
+		args    []string
+		arg     strings.Builder
+		inArg   bool
+		quoted  bool
+		escaped bool
+	)
+	for _, r := range command {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && !quoted:
+			escaped, inArg = true, true
+		case r == '\'':
+			quoted, inArg = !quoted, true
+		case r == ' ' && !quoted:
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args
+}
@@ -0,0 +1,392 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Config holds the options that control how the enum file is generated.
+type Config struct {
+	// Failfast makes the generated Parse function return an error for invalid values
+	// rather than the invalid enum.
+	Failfast bool `json:"failfast,omitempty"`
+	// Strict makes the unmarshal and scan methods return an error for invalid input
+	// even without Failfast, while Parse keeps returning the invalid value.
+	Strict bool `json:"strict,omitempty"`
+	// MarshalInvalid selects what the marshalers write for a value that is not valid,
+	// either MarshalInvalidError to fail or MarshalInvalidNumber for its underlying
+	// integer. An empty value writes its name, or InvalidPlaceholder when set.
+	MarshalInvalid string `json:"marshalInvalid,omitempty"`
+	// InvalidPlaceholder is written by the marshalers in place of a value that is not valid.
+	InvalidPlaceholder string `json:"invalidPlaceholder,omitempty"`
+	// YAML selects the yaml library the generated YAML methods are compatible with,
+	// either "v2" (gopkg.in/yaml.v2 style unmarshal func) or "v3" (gopkg.in/yaml.v3 *yaml.Node).
+	// An empty value disables YAML method generation.
+	YAML string `json:"yaml,omitempty"`
+	// JSONv2 generates MarshalJSONTo and UnmarshalJSONFrom methods for encoding/json/v2
+	// into a separate file built only with GOEXPERIMENT=jsonv2.
+	JSONv2 bool `json:"jsonv2,omitempty"`
+	// Accessors stores the extra values in unexported fields exposed through getter methods,
+	// so the values in the container cannot be modified at call sites.
+	Accessors bool `json:"accessors,omitempty"`
+	// Immutable makes the container variable unexported and exposes it through a function
+	// returning a copy, so callers cannot overwrite the enum values.
+	Immutable bool `json:"immutable,omitempty"`
+	// Shared writes the helpers common to every enum into a single enums_common.go
+	// file in the package rather than repeating them in each enum file.
+	Shared bool `json:"shared,omitempty"`
+	// EmptyInvalid marshals the invalid value to JSON as an empty string and unmarshals
+	// empty strings and null to the invalid value, even in failfast mode.
+	EmptyInvalid bool `json:"emptyInvalid,omitempty"`
+	// EmptyDefault unmarshals and scans empty strings, null and NULL to the value
+	// marked with the //goenums:default directive, even in failfast mode.
+	EmptyDefault bool `json:"emptyDefault,omitempty"`
+	// SQLInt makes Value return the underlying integer rather than the name,
+	// for schemas that store enums numerically.
+	SQLInt bool `json:"sqlInt,omitempty"`
+	// Pgx generates the pgtype TextScanner and TextValuer methods (Int64Scanner and
+	// Int64Valuer with SQLInt) so pgx v5 handles the enum natively.
+	Pgx bool `json:"pgx,omitempty"`
+	// Insensitive makes parsing names case-insensitive using Unicode simple case
+	// folding, so any casing of a name parses.
+	Insensitive bool `json:"insensitive,omitempty"`
+	// ParseLookup selects how the generated Parse looks names up, one of
+	// ParseLookupSwitch, ParseLookupLength or ParseLookupHash. Empty switches on the name.
+	ParseLookup string `json:"parseLookup,omitempty"`
+	// Suggest generates a Closest function finding the nearest valid value to a
+	// string, used by the failfast Parse error to suggest what was meant.
+	Suggest bool `json:"suggest,omitempty"`
+	// FloatInput selects how Parse handles float64 and float32 values, one of
+	// FloatInputReject, FloatInputIntegral or FloatInputRound. Empty rejects them.
+	FloatInput string `json:"floatInput,omitempty"`
+	// ZeroValid is for enums whose zero value is a valid constant: without a
+	// sentinel, the invalid value Parse returns for unknown input is then the
+	// first value no constant has rather than the zero value.
+	ZeroValid bool `json:"zeroValid,omitempty"`
+	// Prefix and Suffix are added to the names of the wrapper type and container,
+	// e.g. GenStatus and GenStatuses, to avoid colliding with existing types.
+	Prefix string `json:"prefix,omitempty"`
+	Suffix string `json:"suffix,omitempty"`
+	// TrimPrefix is removed from the constant identifiers before the container field
+	// names and the names without one in their comment are derived from them.
+	TrimPrefix string `json:"trimPrefix,omitempty"`
+	// Type restricts generation to the constants of the named enum type, for files
+	// declaring several enums. Empty expects the file to declare a single enum.
+	Type string `json:"type,omitempty"`
+	// Coverage marks the generated files with a //coverage:ignore hint and looks
+	// names and values up in maps rather than switching on them, so the generated
+	// code has few branches for coverage tools to count.
+	Coverage bool `json:"coverage,omitempty"`
+	// RoundTripCheck fails generation unless every name and alias parses back to its
+	// own value and every name written by String and the marshalers decodes unchanged.
+	RoundTripCheck bool `json:"roundTripCheck,omitempty"`
+	// FreezeNames fails generation if a name in the previously generated file is
+	// no longer produced, protecting anything keyed by the enum names.
+	FreezeNames bool `json:"freezeNames,omitempty"`
+	// Lock records the names and values in a lockfile next to the source and fails
+	// generation if an entry already in it is renamed, renumbered or removed.
+	Lock bool `json:"lock,omitempty"`
+	// Manifest records the enum, its source, outputs and options in a
+	// goenums-manifest.json next to the generated files, for build systems.
+	Manifest bool `json:"manifest,omitempty"`
+	// UniqueNames fails generation if a name the enum parses is also parsed by
+	// another enum already generated into the package.
+	UniqueNames bool `json:"uniqueNames,omitempty"`
+	// MaxDiffLines fails generation when it would change more than this many lines
+	// of a file already generated, unless Force is set. Zero disables the guard.
+	MaxDiffLines int `json:"maxDiffLines,omitempty"`
+	// Force writes the generated files whatever the size of the change.
+	Force bool `json:"force,omitempty"`
+	// BaseMethods generates String, IsValid, Parse and the JSON and SQL handlers
+	// on the base type of an enum without extra values instead of a wrapper type,
+	// for base types already used in exported APIs.
+	BaseMethods bool `json:"baseMethods,omitempty"`
+	// Stringer generates only the String method of the base type of an enum
+	// without extra values into <type>_string.go, compatible with the file
+	// generated by golang.org/x/tools/cmd/stringer.
+	Stringer bool `json:"stringer,omitempty"`
+	// Report receives a report of the values added, removed and renamed since the
+	// previously generated file. Nil disables the report.
+	Report io.Writer `json:"-"`
+	// Data holds the contents of the files named by //goenums:data directives,
+	// keyed by the name in the directive, to use instead of reading them. Parse
+	// and Generate never read the files, so enums with a data directive need
+	// their contents here.
+	Data map[string][]byte `json:"-"`
+	// Outputs lists the formats to generate, defaulting to just the Go source.
+	Outputs []string `json:"outputs,omitempty"`
+	// PostProcess lists the registered post-processors run in order over the
+	// generated Go files after gofmt, see RegisterPostProcessor.
+	PostProcess []string `json:"postProcess,omitempty"`
+	// Style controls the appearance of the generated Go code.
+	Style Style `json:"style"`
+	// Header adds the owner, documentation links and a description of the enum
+	// to the header of the generated Go files.
+	Header Header `json:"header"`
+}
+
+// Output formats that can be generated for an enum.
+const (
+	// OutputGo is the Go source for the enum.
+	OutputGo = "go"
+	// OutputSQLC is a sqlc overrides snippet mapping the database type to the enum.
+	OutputSQLC = "sqlc"
+	// OutputCSV is a CSV table of the value, name and aliases of each constant,
+	// for decoding numeric enum columns in data warehouses and BI tools.
+	OutputCSV = "csv"
+	// OutputJSON is the same table as OutputCSV as a JSON document.
+	OutputJSON = "json"
+	// OutputAvro is an Avro enum schema with the names of the constants as symbols.
+	OutputAvro = "avro"
+	// OutputJava is a Java enum carrying the values, names and extra values of the constants.
+	OutputJava = "java"
+	// OutputKotlin is the Kotlin enum class counterpart of OutputJava.
+	OutputKotlin = "kotlin"
+	// OutputPython is a Python module with an enum.Enum and a metadata dict of the extra values.
+	OutputPython = "python"
+	// OutputRust is a Rust enum with FromStr and Display impls and serde attributes.
+	OutputRust = "rust"
+	// OutputCSharp is a C# enum with extension methods for display names and parsing.
+	OutputCSharp = "csharp"
+)
+
+// outputs are the known output formats.
+var outputs = []string{OutputGo, OutputSQLC, OutputCSV, OutputJSON, OutputAvro, OutputJava, OutputKotlin, OutputPython, OutputRust, OutputCSharp}
+
+// outputDirective selects the outputs of one enum, overriding -o, e.g.
+// //goenums:output go,sqlc in the doc comment of the enum type.
+const outputDirective = "output"
+
+// hasOutput reports whether the output format should be generated.
+func (c Config) hasOutput(format string) bool {
+	if len(c.Outputs) == 0 {
+		return format == OutputGo
+	}
+	return slices.Contains(c.Outputs, format)
+}
+
+// YAML library versions supported by the YAML handler.
+const (
+	YAMLv2 = "v2"
+	YAMLv3 = "v3"
+)
+
+// Marshal behaviours for values that are not valid.
+const (
+	MarshalInvalidError  = "error"
+	MarshalInvalidNumber = "number"
+)
+
+// ErrInvalidConfig is returned when the generation config contains an unsupported option.
+var ErrInvalidConfig = fmt.Errorf("invalid config")
+
+// validate checks the config options are supported.
+func (c Config) validate() error {
+	switch c.YAML {
+	case "", YAMLv2, YAMLv3:
+	default:
+		return fmt.Errorf("%w: unknown yaml library %q, expected %q or %q", ErrInvalidConfig, c.YAML, YAMLv2, YAMLv3)
+	}
+	switch c.MarshalInvalid {
+	case "", MarshalInvalidError, MarshalInvalidNumber:
+	default:
+		return fmt.Errorf("%w: unknown marshal-invalid behaviour %q, expected %q or %q", ErrInvalidConfig, c.MarshalInvalid, MarshalInvalidError, MarshalInvalidNumber)
+	}
+	if c.MarshalInvalid != "" && c.InvalidPlaceholder != "" {
+		return fmt.Errorf("%w: marshal-invalid and invalid-placeholder cannot be used together", ErrInvalidConfig)
+	}
+	if q := strconv.Quote(c.InvalidPlaceholder); q[1:len(q)-1] != c.InvalidPlaceholder {
+		return fmt.Errorf("%w: invalid-placeholder %q must not contain quotes, backslashes or control characters", ErrInvalidConfig, c.InvalidPlaceholder)
+	}
+	if r, _ := utf8.DecodeRuneInString(c.Prefix); c.Prefix != "" && (!unicode.IsUpper(r) || !token.IsIdentifier(c.Prefix)) {
+		return fmt.Errorf("%w: prefix %q must be an exported identifier", ErrInvalidConfig, c.Prefix)
+	}
+	if c.Suffix != "" && !token.IsIdentifier("X"+c.Suffix) {
+		return fmt.Errorf("%w: suffix %q must only contain letters, digits and underscores", ErrInvalidConfig, c.Suffix)
+	}
+	if c.ParseLookup != "" && !slices.Contains(parseLookups, c.ParseLookup) {
+		return fmt.Errorf("%w: unknown parse lookup %q, expected one of %s", ErrInvalidConfig, c.ParseLookup, strings.Join(parseLookups, ", "))
+	}
+	if c.FloatInput != "" && !slices.Contains(floatInputs, c.FloatInput) {
+		return fmt.Errorf("%w: unknown float input policy %q, expected one of %s", ErrInvalidConfig, c.FloatInput, strings.Join(floatInputs, ", "))
+	}
+	if c.ParseLookup != "" && c.ParseLookup != ParseLookupSwitch && (c.Insensitive || c.Coverage) {
+		return fmt.Errorf("%w: parse-lookup %s cannot be used with insensitive or coverage", ErrInvalidConfig, c.ParseLookup)
+	}
+	if c.MaxDiffLines < 0 {
+		return fmt.Errorf("%w: max-diff-lines must not be negative, got %d", ErrInvalidConfig, c.MaxDiffLines)
+	}
+	if c.Stringer && len(c.Outputs) > 0 && !slices.Equal(c.Outputs, []string{OutputGo}) {
+		return fmt.Errorf("%w: stringer only generates Go, got outputs %s", ErrInvalidConfig, strings.Join(c.Outputs, ","))
+	}
+	if c.Stringer && c.BaseMethods {
+		return fmt.Errorf("%w: stringer and base-methods cannot be used together", ErrInvalidConfig)
+	}
+	if c.EmptyInvalid && c.EmptyDefault {
+		return fmt.Errorf("%w: emptyinvalid and emptydefault cannot be used together", ErrInvalidConfig)
+	}
+	if err := validateOutputs(c.Outputs); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	if err := validatePostProcessors(c.PostProcess); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	if err := c.Style.validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	if err := c.Header.validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	return nil
+}
+
+// validateOutputs checks every output format is known.
+func validateOutputs(formats []string) error {
+	for _, o := range formats {
+		if !slices.Contains(outputs, o) {
+			return fmt.Errorf("unknown output %q, expected one of %s", o, strings.Join(outputs, ", "))
+		}
+	}
+	return nil
+}
+
+// parseOutputs splits a comma separated list of output formats.
+func parseOutputs(s string) []string {
+	formats := strings.Split(s, ",")
+	for i, f := range formats {
+		formats[i] = strings.TrimSpace(f)
+	}
+	return formats
+}
+
+// args returns the command line flags that reproduce the config,
+// used to document the generating command in the file header. Report only
+// changes what is printed, so it is left out and Check accepts a file
+// generated with or without it.
+func (c Config) args() []string {
+	var args []string
+	if c.Failfast {
+		args = append(args, "-f")
+	}
+	if c.Strict {
+		args = append(args, "-strict")
+	}
+	if c.MarshalInvalid != "" {
+		args = append(args, "-marshal-invalid", c.MarshalInvalid)
+	}
+	if c.InvalidPlaceholder != "" {
+		args = append(args, "-invalid-placeholder", c.InvalidPlaceholder)
+	}
+	if c.YAML != "" {
+		args = append(args, "-yaml", c.YAML)
+	}
+	if c.JSONv2 {
+		args = append(args, "-jsonv2")
+	}
+	if c.Accessors {
+		args = append(args, "-accessors")
+	}
+	if c.Immutable {
+		args = append(args, "-immutable")
+	}
+	if c.Shared {
+		args = append(args, "-shared")
+	}
+	if c.EmptyInvalid {
+		args = append(args, "-emptyinvalid")
+	}
+	if c.EmptyDefault {
+		args = append(args, "-emptydefault")
+	}
+	if c.SQLInt {
+		args = append(args, "-sqlint")
+	}
+	if c.Pgx {
+		args = append(args, "-pgx")
+	}
+	if c.Insensitive {
+		args = append(args, "-insensitive")
+	}
+	if c.ParseLookup != "" {
+		args = append(args, "-parse-lookup", c.ParseLookup)
+	}
+	if c.Suggest {
+		args = append(args, "-suggest")
+	}
+	if c.FloatInput != "" {
+		args = append(args, "-float-input", c.FloatInput)
+	}
+	if c.ZeroValid {
+		args = append(args, "-zero-valid")
+	}
+	if c.Prefix != "" {
+		args = append(args, "-prefix", c.Prefix)
+	}
+	if c.Suffix != "" {
+		args = append(args, "-suffix", c.Suffix)
+	}
+	if c.TrimPrefix != "" {
+		args = append(args, "-trimprefix", c.TrimPrefix)
+	}
+	if c.Type != "" {
+		args = append(args, "-type", c.Type)
+	}
+	if c.Coverage {
+		args = append(args, "-coverage")
+	}
+	if c.RoundTripCheck {
+		args = append(args, "-roundtrip-check")
+	}
+	if c.FreezeNames {
+		args = append(args, "-freeze-names")
+	}
+	if c.Lock {
+		args = append(args, "-lock")
+	}
+	if c.Manifest {
+		args = append(args, "-manifest")
+	}
+	if c.UniqueNames {
+		args = append(args, "-unique-names")
+	}
+	if c.BaseMethods {
+		args = append(args, "-base-methods")
+	}
+	if c.Stringer {
+		args = append(args, "-stringer")
+	}
+	if len(c.Outputs) > 0 && !slices.Equal(c.Outputs, []string{OutputGo}) {
+		args = append(args, "-o", strings.Join(c.Outputs, ","))
+	}
+	if len(c.PostProcess) > 0 {
+		args = append(args, "-postprocess", strings.Join(c.PostProcess, ","))
+	}
+	if c.Style.Receiver != "" {
+		args = append(args, "-style-receiver", c.Style.Receiver)
+	}
+	if c.Style.ErrorFormat != "" {
+		args = append(args, "-style-errors", c.Style.ErrorFormat)
+	}
+	if c.Style.Comments != "" {
+		args = append(args, "-style-comments", c.Style.Comments)
+	}
+	if len(c.Style.Order) > 0 {
+		args = append(args, "-style-order", strings.Join(c.Style.Order, ","))
+	}
+	if c.Header.Owner != "" {
+		args = append(args, "-owner", c.Header.Owner)
+	}
+	for _, doc := range c.Header.Docs {
+		args = append(args, "-doc", doc)
+	}
+	if c.Header.Description != "" {
+		args = append(args, "-description", c.Header.Description)
+	}
+	return args
+}
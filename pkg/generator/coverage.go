@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"io"
+	"strconv"
+)
+
+// coverageIgnore is the hint marking the generated files for coverage tools
+// that skip files or blocks with it.
+const coverageIgnore = "//coverage:ignore"
+
+// lookupMapSuffix is appended to the name of the stringTo and intTo functions to
+// name the maps they look values up in with Coverage.
+const lookupMapSuffix = "Map"
+
+// writeStringToTypeMap writes stringTo as a lookup in a map of every spelling,
+// which has a single branch however many values the enum has.
+func writeStringToTypeMap(w io.StringWriter, rep EnumRepresentation) {
+	m := "stringTo" + rep.TypeInfo.Camel + lookupMapSuffix
+	w.WriteString("var " + m + " = map[string]" + rep.TypeInfo.Camel + "{\n")
+	for i, names := range rep.parseNames() {
+		for _, name := range names {
+			w.WriteString("\t" + strconv.Quote(name) + ": " + rep.TypeInfo.Container + "." + rep.Enums[i].Info.Upper + ",\n")
+		}
+	}
+	w.WriteString("}\n\n")
+	w.WriteString("func stringTo" + rep.TypeInfo.Camel + "(s string) " + rep.TypeInfo.Camel + " {\n")
+	if rep.Insensitive {
+		w.WriteString("\tfor name, p := range " + m + " {\n")
+		w.WriteString("\t\tif strings.EqualFold(s, name) {\n")
+		w.WriteString("\t\t\treturn p\n")
+		w.WriteString("\t\t}\n")
+		w.WriteString("\t}\n")
+		w.WriteString("\treturn invalid" + rep.TypeInfo.Camel + "\n")
+		w.WriteString("}\n\n")
+		return
+	}
+	w.WriteString("\tif p, ok := " + m + "[s]; ok {\n")
+	w.WriteString("\t\treturn p\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn invalid" + rep.TypeInfo.Camel + "\n")
+	w.WriteString("}\n\n")
+}
+
+// writeIntToTypeMap writes intTo as a lookup in a map of the valid values, keyed
+// the same as setupIntToTypeMethod looks them up.
+func writeIntToTypeMap(w io.StringWriter, rep EnumRepresentation) {
+	m := "intTo" + rep.TypeInfo.Camel + lookupMapSuffix
+	w.WriteString("var " + m + " = map[int]" + rep.TypeInfo.Camel + "{\n")
+	position := rep.TypeInfo.Index
+	for _, info := range rep.Enums {
+		if !info.Info.Valid {
+			continue
+		}
+		key := "int(" + info.Info.Name + ")"
+		if !rep.valueLookup() {
+			key = strconv.Itoa(position)
+			position++
+		}
+		w.WriteString("\t" + key + ": " + rep.TypeInfo.Container + "." + info.Info.Upper + ",\n")
+	}
+	w.WriteString("}\n\n")
+	w.WriteString("func intTo" + rep.TypeInfo.Camel + "(i int) " + rep.TypeInfo.Camel + " {\n")
+	w.WriteString("\tif p, ok := " + m + "[i]; ok {\n")
+	w.WriteString("\t\treturn p\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn invalid" + rep.TypeInfo.Camel + "\n")
+	w.WriteString("}\n\n")
+}
@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"go/constant"
+	"io"
+	"strconv"
+)
+
+// csharpSections are the writers for the C# enum.
+var csharpSections = []func(io.StringWriter, EnumRepresentation){
+	writeCSharpGeneratedComment,
+	writeCSharpEnum,
+	writeCSharpExtensions,
+}
+
+// csharpReserved are the members of the generated extension class and of the
+// enum itself, which the accessors of the extra values cannot be named.
+var csharpReserved = []string{
+	"Value", "Label", "DisplayName", "IsValid", "Parse", "TryParse",
+	"ToString", "Equals", "GetHashCode", "GetType", "CompareTo", "HasFlag",
+}
+
+// csharpTypes are the C# types of the kinds of extra values.
+var csharpTypes = map[constant.Kind]string{
+	constant.Bool:   "bool",
+	constant.String: "string",
+	constant.Int:    "long",
+	constant.Float:  "double",
+}
+
+// checkCSharp rejects the enums the C# output cannot represent.
+func checkCSharp(rep EnumRepresentation) error {
+	if _, err := rep.foreignValues(OutputCSharp); err != nil {
+		return err
+	}
+	return checkForeignMembers(rep, OutputCSharp, csharpReserved, camelCase)
+}
+
+// csharpLiteral formats the value as a C# literal.
+func csharpLiteral(v constant.Value) string {
+	switch v.Kind() {
+	case constant.Bool:
+		return strconv.FormatBool(constant.BoolVal(v))
+	case constant.String:
+		return cQuote(constant.StringVal(v), "")
+	case constant.Int:
+		return foreignNumber(v) + "L"
+	}
+	return foreignNumber(v)
+}
+
+func writeCSharpGeneratedComment(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("// Code generated by goenums. DO NOT EDIT.\n\n")
+	w.WriteString("using System;\n\n")
+}
+
+// writeCSharpEnum writes the enum with the values of the Go constants, opening
+// the namespace named after the Go package.
+func writeCSharpEnum(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("namespace " + camelCase(rep.PackageName) + "\n")
+	w.WriteString("{\n")
+	w.WriteString("    /// <summary>" + rep.TypeInfo.Camel + " mirrors the " + rep.TypeInfo.Name + " constants of the Go package " + rep.PackageName + ".</summary>\n")
+	w.WriteString("    public enum " + rep.TypeInfo.Camel + " : long\n")
+	w.WriteString("    {\n")
+	for _, e := range rep.Enums {
+		w.WriteString("        " + e.Info.Camel + " = " + strconv.Itoa(e.Info.Value+rep.TypeInfo.Index) + ",\n")
+	}
+	w.WriteString("    }\n\n")
+}
+
+// writeCSharpSwitch writes an extension method returning the value of typ for each
+// enum value, as formatted by value.
+func writeCSharpSwitch(w io.StringWriter, rep EnumRepresentation, summary, typ, name string, value func(int, Enum) string) {
+	camel := rep.TypeInfo.Camel
+	if summary != "" {
+		w.WriteString("        /// <summary>" + summary + "</summary>\n")
+	}
+	w.WriteString("        public static " + typ + " " + name + "(this " + camel + " p) => p switch\n")
+	w.WriteString("        {\n")
+	for i, e := range rep.Enums {
+		w.WriteString("            " + camel + "." + e.Info.Camel + " => " + value(i, e) + ",\n")
+	}
+	w.WriteString("            _ => throw new ArgumentOutOfRangeException(nameof(p), p, null),\n")
+	w.WriteString("        };\n\n")
+}
+
+// writeCSharpExtensions writes the extension methods returning the value, name,
+// display name, validity and extra values of the enum, and the parse methods
+// accepting every spelling the Go code parses, closing the namespace.
+func writeCSharpExtensions(w io.StringWriter, rep EnumRepresentation) {
+	camel := rep.TypeInfo.Camel
+	// checked before generating
+	values, _ := rep.foreignValues("")
+	w.WriteString("    /// <summary>Extension methods for <see cref=\"" + camel + "\"/>.</summary>\n")
+	w.WriteString("    public static class " + camel + "Extensions\n")
+	w.WriteString("    {\n")
+	w.WriteString("        /// <summary>Returns the value of the Go constant.</summary>\n")
+	w.WriteString("        public static long Value(this " + camel + " p) => (long)p;\n\n")
+	writeCSharpSwitch(w, rep, "Returns the name the Go code writes for the value.", "string", "Label", func(_ int, e Enum) string {
+		return cQuote(e.Info.AlternateName, "")
+	})
+	writeCSharpSwitch(w, rep, "Returns the human readable name of the value from its display tag, or its name.", "string", "DisplayName", func(_ int, e Enum) string {
+		if v, ok := e.tagValue(displayTag); ok {
+			return cQuote(v, "")
+		}
+		return cQuote(e.Info.AlternateName, "")
+	})
+	writeCSharpSwitch(w, rep, "Reports whether the value is valid, false for the values marked invalid.", "bool", "IsValid", func(_ int, e Enum) string {
+		return strconv.FormatBool(e.Info.Valid)
+	})
+	for j, field := range rep.TypeInfo.NameTypePairs {
+		writeCSharpSwitch(w, rep, "", csharpTypes[foreignKinds[field.Type]], camelCase(field.Name), func(i int, _ Enum) string {
+			return csharpLiteral(values[i][j])
+		})
+	}
+	w.WriteString("        /// <summary>Returns the " + camel + " named s, accepting every spelling the Go code parses.</summary>\n")
+	w.WriteString("        /// <exception cref=\"FormatException\">s is not the name of a " + camel + ".</exception>\n")
+	w.WriteString("        public static " + camel + " Parse(string s) =>\n")
+	w.WriteString("            TryParse(s, out var p) ? p : throw new FormatException($\"invalid " + camel + ": {s}\");\n\n")
+	w.WriteString("        /// <summary>Sets p to the " + camel + " named s, reporting whether s is the name of one.</summary>\n")
+	w.WriteString("        public static bool TryParse(string s, out " + camel + " p)\n")
+	w.WriteString("        {\n")
+	w.WriteString("            switch (s)\n")
+	w.WriteString("            {\n")
+	for i, names := range rep.parseNames() {
+		for _, name := range names {
+			w.WriteString("                case " + cQuote(name, "") + ":\n")
+		}
+		w.WriteString("                    p = " + camel + "." + rep.Enums[i].Info.Camel + ";\n")
+		w.WriteString("                    return true;\n")
+	}
+	w.WriteString("                default:\n")
+	w.WriteString("                    p = default;\n")
+	w.WriteString("                    return false;\n")
+	w.WriteString("            }\n")
+	w.WriteString("        }\n")
+	w.WriteString("    }\n")
+	w.WriteString("}\n")
+}
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dataDirective names a YAML or JSON file, relative to the source file, holding
+// the extra values of the constants keyed by constant name and field, so long
+// values such as descriptions and URLs need not be squeezed into comments, e.g.
+// //goenums:data planets.yaml with
+//
+//	earth:
+//	  Gravity: 1
+//	  Wiki: https://en.wikipedia.org/wiki/Earth
+//	  Description: Our home, the third planet from the Sun.
+//
+// A constant in the file takes every extra value from it, while the constants
+// not in the file keep the values in their comments.
+const dataDirective = "data"
+
+// applyData sets the extra values of the enums from data, the contents of the
+// data file name. Each constant in the file must give every field but the
+// description, which is added to the fields when the file gives any.
+func applyData(name string, data []byte, enums []Enum, nameTPairs []nameTypePair) ([]Enum, []nameTypePair, error) {
+	var entries map[string]map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, nil, fmt.Errorf("%w: %s: failed to parse %s: %w", ErrInvalidDirective, dataDirective, name, err)
+	}
+	var schema []nameTypePair
+	for _, pair := range nameTPairs {
+		if pair.Name != descriptionField {
+			schema = append(schema, pair)
+		}
+	}
+	constants := make([]string, 0, len(entries))
+	for constant := range entries {
+		constants = append(constants, constant)
+	}
+	sort.Strings(constants)
+	hasDescription := false
+	for _, constant := range constants {
+		i := slices.IndexFunc(enums, func(e Enum) bool { return e.Info.Name == constant })
+		if i < 0 {
+			return nil, nil, fmt.Errorf("%w: %s: %s has no constant %s", ErrInvalidDirective, dataDirective, name, constant)
+		}
+		fields := entries[constant]
+		pairs := copyNameTPairs(schema, nil)
+		for j, pair := range pairs {
+			node, ok := fields[pair.Name]
+			if !ok || node.Tag == "!!null" {
+				return nil, nil, fmt.Errorf("%w: %s: %s gives %s no %s", ErrInvalidDirective, dataDirective, name, constant, pair.Name)
+			}
+			if node.Kind != yaml.ScalarNode {
+				return nil, nil, fmt.Errorf("%w: %s: %s gives %s.%s a value that is not a scalar", ErrInvalidDirective, dataDirective, name, constant, pair.Name)
+			}
+			pairs[j].Value = node.Value
+			if pair.Type == "string" {
+				pairs[j].Value = strconv.Quote(node.Value)
+			}
+		}
+		for field, node := range fields {
+			switch {
+			case field == descriptionField && node.Kind == yaml.ScalarNode:
+				hasDescription = true
+				pairs = setDescription(pairs, node.Value)
+			case field == descriptionField:
+				return nil, nil, fmt.Errorf("%w: %s: %s gives %s a description that is not a scalar", ErrInvalidDirective, dataDirective, name, constant)
+			case !hasField(schema, field):
+				return nil, nil, fmt.Errorf("%w: %s: %s gives %s the unknown field %s", ErrInvalidDirective, dataDirective, name, constant, field)
+			}
+		}
+		// a description in the comment is kept unless the file gives another
+		if _, ok := fields[descriptionField]; !ok {
+			for _, pair := range enums[i].TypeInfo.NameTypePairs {
+				if pair.Name == descriptionField {
+					pairs = append(pairs, pair)
+				}
+			}
+		}
+		enums[i].TypeInfo.NameTypePairs = pairs
+	}
+	if hasDescription && !hasField(nameTPairs, descriptionField) {
+		nameTPairs = append(nameTPairs, nameTypePair{Name: descriptionField, Type: "string"})
+	}
+	return enums, nameTPairs, nil
+}
@@ -0,0 +1,165 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//go:embed datasets/*.tsv
+var datasetFS embed.FS
+
+// ErrUnknownDataset is returned when the requested built-in dataset does not exist.
+var ErrUnknownDataset = fmt.Errorf("unknown dataset")
+
+// dataset describes how a built-in table is turned into an enum source file.
+type dataset struct {
+	// typeName of the iota type declared in the source
+	typeName string
+	// doc comment for the type
+	doc string
+	// fields declared in the type comment
+	fields string
+	// columns expected in each row of the table
+	columns int
+	// value returns the constant name and comment for a row
+	value func(row []string) (string, string)
+}
+
+// datasets are the built-in tables by name, generated from datasets/<name>.tsv.
+var datasets = map[string]dataset{
+	"countries": {
+		typeName: "country",
+		doc:      "country is an ISO 3166-1 alpha-2 country code.",
+		fields:   "Name[string]",
+		columns:  2,
+		value: func(row []string) (string, string) {
+			return strings.ToLower(row[0]), row[0] + " " + strconv.Quote(row[1])
+		},
+	},
+	"currencies": {
+		typeName: "currency",
+		doc:      "currency is an ISO 4217 currency code.",
+		fields:   "Numeric[int],MinorUnits[int],Name[string]",
+		columns:  4,
+		value: func(row []string) (string, string) {
+			return strings.ToLower(row[0]), row[0] + " " + row[1] + "," + row[2] + "," + strconv.Quote(row[3])
+		},
+	},
+	"timezones": {
+		typeName: "timezone",
+		doc:      "timezone is an IANA time zone name.",
+		columns:  1,
+		value: func(row []string) (string, string) {
+			return identifier(row[0]), row[0]
+		},
+	},
+	"httpstatuses": {
+		typeName: "httpStatus",
+		doc:      "httpStatus is an HTTP response status code.",
+		fields:   "Code[int],Message[string]",
+		columns:  2,
+		value: func(row []string) (string, string) {
+			name := identifier(row[1])
+			return "status_" + name, name + " " + row[0] + "," + strconv.Quote(row[1])
+		},
+	},
+	"mimetypes": {
+		typeName: "mimeType",
+		doc:      "mimeType is a media type such as application/json.",
+		fields:   "Extension[string]",
+		columns:  2,
+		value: func(row []string) (string, string) {
+			return identifier(row[0]), row[0] + " " + strconv.Quote(row[1])
+		},
+	},
+}
+
+// identifier converts a dataset value such as "America/Port-au-Prince" or
+// "I'm a teapot" into a lower snake case Go identifier.
+func identifier(in string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ReplaceAll(strings.ToLower(in), "'", "") {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			underscore = false
+			b.WriteRune(r)
+			continue
+		}
+		underscore = true
+	}
+	return b.String()
+}
+
+// Datasets returns the names of the built-in datasets.
+func Datasets() []string {
+	return []string{"countries", "currencies", "httpstatuses", "mimetypes", "timezones"}
+}
+
+// GenerateDataset writes the built-in dataset name as an enum source file in the
+// package pkg in dir and generates the enum from it, returning the source filename.
+func GenerateDataset(ctx context.Context, dir, pkg, name string, cfg Config) (string, error) {
+	ds, ok := datasets[name]
+	if !ok {
+		return "", fmt.Errorf("%w %q, expected one of %s", ErrUnknownDataset, name, strings.Join(Datasets(), ", "))
+	}
+	src, err := ds.source(pkg, name)
+	if err != nil {
+		return "", err
+	}
+	filename := filepath.Join(dir, name+".go")
+	err = os.WriteFile(filename, src, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	return filename, ParseAndGenerateWithConfig(ctx, filename, cfg)
+}
+
+// source returns the formatted enum source for the dataset.
+func (ds dataset) source(pkg, name string) ([]byte, error) {
+	table, err := datasetFS.ReadFile("datasets/" + name + ".tsv")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset: %w", err)
+	}
+	b := new(bytes.Buffer)
+	b.WriteString("// Code generated by goenums gen " + name + ". DO NOT EDIT.\n\n")
+	b.WriteString("package " + pkg + "\n\n")
+	b.WriteString("// " + ds.doc + "\n")
+	b.WriteString("type " + ds.typeName + " int")
+	if ds.fields != "" {
+		b.WriteString(" // " + ds.fields)
+	}
+	b.WriteString("\n\n")
+	b.WriteString("const (\n")
+	b.WriteString("\tunknown" + camelCase(ds.typeName) + " " + ds.typeName + " = iota // invalid\n")
+	s := bufio.NewScanner(bytes.NewReader(table))
+	for line := 1; s.Scan(); line++ {
+		text := s.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		row := strings.Split(text, "\t")
+		if len(row) != ds.columns {
+			return nil, fmt.Errorf("dataset %s line %d: expected %d columns, got %d", name, line, ds.columns, len(row))
+		}
+		ident, comment := ds.value(row)
+		if !token.IsIdentifier(ident) {
+			return nil, fmt.Errorf("dataset %s line %d: %q is not a valid identifier", name, line, ident)
+		}
+		b.WriteString("\t" + ident + " // " + comment + "\n")
+	}
+	b.WriteString(")\n")
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,191 @@
+package generator
+
+import (
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// derivedDirective declares an extra value computed from the others when the
+// enum is generated, e.g.
+//
+//	//goenums:derived Density = MassKg / VolumeKm3
+//	//goenums:derived Heavy[bool] = Gravity > 2
+//	type planet int // MassKg[float64],VolumeKm3[float64],Gravity[float64]
+//
+// The expression is a Go constant expression of the extra values of basic types,
+// and of the values derived before it, evaluated with the types of the fields so
+// the result is emitted as a literal, the same as the values written out in the
+// comments. The type of the value is that of the expression unless one is given
+// in brackets, which the result is converted to.
+const derivedDirective = "derived"
+
+// derivedField is a value declared by a derived directive.
+type derivedField struct {
+	name    string
+	typ     string
+	expr    string
+	options []string
+}
+
+// parseDerived parses the value of a derived directive, Name = expr or
+// Name[type] = expr, with the annotations of the value after its type as in
+// the schema comment, e.g. Density[float64,min=0] = MassKg / VolumeKm3.
+func parseDerived(directive string) (derivedField, error) {
+	// the = of the annotations after the type are inside the brackets
+	split := 0
+	if i := strings.IndexAny(directive, "[="); i >= 0 && directive[i] == '[' {
+		split = max(strings.Index(directive, "]"), 0)
+	}
+	decl, expr, ok := strings.Cut(directive[split:], "=")
+	decl = directive[:split] + decl
+	if !ok || strings.TrimSpace(expr) == "" {
+		return derivedField{}, fmt.Errorf("%w: %s: expected Name = expression, got %s", ErrInvalidDirective, derivedDirective, strconv.Quote(directive))
+	}
+	f := derivedField{name: strings.TrimSpace(decl), expr: strings.TrimSpace(expr)}
+	if name, typ, ok := strings.Cut(f.name, "["); ok {
+		typ, ok = strings.CutSuffix(typ, "]")
+		if !ok || strings.TrimSpace(typ) == "" {
+			return derivedField{}, fmt.Errorf("%w: %s: expected a type in brackets after %s", ErrInvalidDirective, derivedDirective, strings.TrimSpace(name))
+		}
+		typ, options, _ := strings.Cut(typ, ",")
+		f.name, f.typ = strings.TrimSpace(name), strings.TrimSpace(typ)
+		if options != "" {
+			f.options = strings.Split(options, ",")
+		}
+	}
+	if !token.IsIdentifier(f.name) {
+		return derivedField{}, fmt.Errorf("%w: %s: %s is not a valid field name", ErrInvalidDirective, derivedDirective, strconv.Quote(f.name))
+	}
+	return f, nil
+}
+
+// applyDerived adds the values declared by the derived directives to the extra
+// values of the enums, computed from the values of each valid constant. Invalid
+// constants leave them unset like their other extra values.
+func applyDerived(directives []string, enums []Enum, nameTPairs []nameTypePair) ([]Enum, []nameTypePair, error) {
+	fields := make([]derivedField, len(directives))
+	for i, directive := range directives {
+		f, err := parseDerived(directive)
+		if err != nil {
+			return nil, nil, err
+		}
+		if hasField(nameTPairs, f.name) || slices.ContainsFunc(fields[:i], func(d derivedField) bool { return d.name == f.name }) {
+			return nil, nil, fmt.Errorf("%w: %s: %s is already a field", ErrInvalidDirective, derivedDirective, f.name)
+		}
+		fields[i] = f
+	}
+	// the type of each derived value, the same for every constant as the fields
+	// it is computed from are
+	typeNames := make([]string, len(fields))
+	for j, f := range fields {
+		typeNames[j] = f.typ
+	}
+	for i := range enums {
+		if !enums[i].Info.Valid {
+			continue
+		}
+		pkg := fieldPackage(enums[i].TypeInfo.NameTypePairs)
+		for j, f := range fields {
+			tv, err := evalDerived(pkg, f)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: %s: %s of %s: %w", ErrInvalidDirective, derivedDirective, f.name, enums[i].Info.Name, err)
+			}
+			typeNames[j] = tv.Type.String()
+			pkg.Scope().Insert(types.NewConst(token.NoPos, pkg, f.name, tv.Type, tv.Value))
+			enums[i].TypeInfo.NameTypePairs = insertField(enums[i].TypeInfo.NameTypePairs, nameTypePair{
+				Name:  f.name,
+				Type:  typeNames[j],
+				Value: derivedLiteral(tv),
+			})
+		}
+	}
+	for j, f := range fields {
+		if typeNames[j] == "" {
+			return nil, nil, fmt.Errorf("%w: %s: %s has no valid constant to take its type from, give it a type", ErrInvalidDirective, derivedDirective, f.name)
+		}
+		field, err := annotateField(nameTypePair{Name: f.name, Type: typeNames[j]}, f.options)
+		if err != nil {
+			return nil, nil, err
+		}
+		nameTPairs = insertField(nameTPairs, field)
+		for i := range enums {
+			pairs := enums[i].TypeInfo.NameTypePairs
+			k := slices.IndexFunc(pairs, func(p nameTypePair) bool { return p.Name == f.name })
+			if k < 0 {
+				enums[i].TypeInfo.NameTypePairs = insertField(pairs, field)
+				continue
+			}
+			value := pairs[k].Value
+			pairs[k] = field
+			pairs[k].Value = value
+		}
+	}
+	return enums, nameTPairs, nil
+}
+
+// fieldPackage returns a package declaring the extra values of basic types as
+// constants of their types, for the derived values to be evaluated in. Values of
+// other types, or not constant, are left out and undefined in the expressions.
+func fieldPackage(pairs []nameTypePair) *types.Package {
+	pkg := types.NewPackage("goenums", "goenums")
+	for _, pair := range pairs {
+		if strings.TrimSpace(pair.Value) == "" {
+			continue
+		}
+		tv, err := types.Eval(token.NewFileSet(), nil, token.NoPos, pair.Type+"("+pair.Value+")")
+		if err != nil || tv.Value == nil {
+			continue
+		}
+		pkg.Scope().Insert(types.NewConst(token.NoPos, pkg, pair.Name, tv.Type, tv.Value))
+	}
+	return pkg
+}
+
+// evalDerived evaluates the expression of the derived value in pkg, converted to
+// its type when it has one and given the default type of an untyped constant
+// otherwise.
+func evalDerived(pkg *types.Package, f derivedField) (types.TypeAndValue, error) {
+	expr := f.expr
+	if f.typ != "" {
+		expr = f.typ + "(" + expr + ")"
+	}
+	tv, err := types.Eval(token.NewFileSet(), pkg, token.NoPos, expr)
+	if err != nil {
+		return tv, err
+	}
+	if tv.Value == nil {
+		return tv, fmt.Errorf("%s is not constant", f.expr)
+	}
+	tv.Type = types.Default(tv.Type)
+	if _, ok := tv.Type.Underlying().(*types.Basic); !ok {
+		return tv, fmt.Errorf("%s has type %s, expected a basic type", f.expr, tv.Type)
+	}
+	return tv, nil
+}
+
+// derivedLiteral formats a derived value as the Go literal of its type.
+func derivedLiteral(tv types.TypeAndValue) string {
+	basic := tv.Type.Underlying().(*types.Basic)
+	switch {
+	case basic.Info()&types.IsString != 0:
+		return strconv.Quote(constant.StringVal(tv.Value))
+	case basic.Info()&types.IsFloat != 0:
+		f, _ := constant.Float64Val(constant.ToFloat(tv.Value))
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return tv.Value.ExactString()
+}
+
+// insertField adds the extra value before the description, which stays the last.
+func insertField(pairs []nameTypePair, pair nameTypePair) []nameTypePair {
+	i := slices.IndexFunc(pairs, func(p nameTypePair) bool { return p.Name == descriptionField })
+	if i < 0 {
+		return append(pairs, pair)
+	}
+	return slices.Insert(pairs, i, pair)
+}
@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"path/filepath"
+)
+
+// Diagnostic is a problem found in an enum source file by Diagnose.
+type Diagnostic struct {
+	// Line and Column of the problem, starting at 1.
+	Line   int
+	Column int
+	// Message describing the problem.
+	Message string
+}
+
+// Diagnose reports the problems generating the enum declared in src, the
+// contents of filename, without writing anything, so editors can show them
+// for unsaved buffers. Syntax errors are reported where they are found and
+// generation errors at the enum type declaration. Files without an enum and
+// generated files have no diagnostics. The options that need the files next
+// to the source are ignored, and the error is only set when ctx is done.
+func Diagnose(ctx context.Context, filename string, src []byte, cfg Config) ([]Diagnostic, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		var list scanner.ErrorList
+		if !errors.As(err, &list) {
+			return []Diagnostic{{Line: 1, Column: 1, Message: err.Error()}}, nil
+		}
+		diags := make([]Diagnostic, len(list))
+		for i, e := range list {
+			diags[i] = Diagnostic{Line: e.Pos.Line, Column: e.Pos.Column, Message: e.Msg}
+		}
+		return diags, nil
+	}
+	typ := iotaType(node)
+	if cfg.Type != "" {
+		typ = cfg.Type
+	}
+	if typ == "" || ast.IsGenerated(node) {
+		return nil, nil
+	}
+	err = cfg.validate()
+	if err == nil {
+		var rep EnumRepresentation
+		rep, err = parseRepresentation(filename, src, cfg)
+		if err == nil {
+			_, err = generateFiles(ctx, rep)
+		}
+	}
+	if err == nil {
+		return nil, nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	pos := fset.Position(typePos(node, typ))
+	if !pos.IsValid() {
+		pos.Line, pos.Column = 1, 1
+	}
+	return []Diagnostic{{Line: pos.Line, Column: pos.Column, Message: err.Error()}}, nil
+}
+
+// typePos returns the position of the name in the declaration of the named
+// type, or token.NoPos when the file does not declare it.
+func typePos(node *ast.File, typeName string) token.Pos {
+	for _, decl := range node.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == typeName {
+				return ts.Name.Pos()
+			}
+		}
+	}
+	return token.NoPos
+}
+
+// DirectiveArgs returns the flags of the goenums go:generate directive in src,
+// the contents of filename, or nil when it has none. The directive is still
+// found in a file with syntax errors, so editors keep diagnosing it as it is
+// typed.
+func DirectiveArgs(filename string, src []byte) []string {
+	// the file parsed up to the first syntax errors holds the directive
+	node, _ := parser.ParseFile(token.NewFileSet(), filename, src, parser.ParseComments)
+	if node == nil {
+		return nil
+	}
+	return generateArgs(node, filepath.Base(filename))
+}
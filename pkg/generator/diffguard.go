@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+)
+
+// ErrDiffTooLarge is returned when regenerating would change more lines of an
+// existing generated file than allowed by MaxDiffLines.
+var ErrDiffTooLarge = fmt.Errorf("generated diff too large")
+
+// checkDiffSize fails when writing a file would change more than limit lines of the
+// file already in dir, catching a flag or config change rewriting every enum.
+func checkDiffSize(dir string, files []File, limit int) error {
+	for _, f := range files {
+		previous, err := os.ReadFile(path.Join(dir, f.Name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read previously generated file: %w", err)
+		}
+		if n := changedLines(lines(previous), lines(f.Content), limit); n > limit {
+			return fmt.Errorf("%w: regenerating %s changes more than %d lines, use -force to write it anyway", ErrDiffTooLarge, f.Name, limit)
+		}
+	}
+	return nil
+}
+
+// lines splits the file into its lines.
+func lines(b []byte) [][]byte {
+	return bytes.SplitAfter(b, []byte("\n"))
+}
+
+// changedLines returns the number of lines removed from a plus the number added
+// to make b, computed with the Myers algorithm and giving up once more than limit
+// lines changed, when it returns limit+1.
+func changedLines(a, b [][]byte, limit int) int {
+	n, m := len(a), len(b)
+	// v[k+offset] is the furthest x reached on diagonal k = x - y
+	offset := limit + 1
+	v := make([]int, 2*offset+1)
+	for d := 0; d <= limit; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[k-1+offset] < v[k+1+offset] {
+				x = v[k+1+offset]
+			} else {
+				x = v[k-1+offset] + 1
+			}
+			y := x - k
+			for x < n && y < m && bytes.Equal(a[x], b[y]) {
+				x, y = x+1, y+1
+			}
+			v[k+offset] = x
+			if x >= n && y >= m {
+				return d
+			}
+		}
+	}
+	return limit + 1
+}
@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrDuplicateName is returned when the same spelling parses to more than one
+// value, which would otherwise leave all but one of them unreachable by name.
+var ErrDuplicateName = fmt.Errorf("duplicate enum name")
+
+// foldName returns the form of name compared for duplicates, ignoring case
+// when names are parsed case-insensitively.
+func (rep EnumRepresentation) foldName(name string) string {
+	if rep.Insensitive {
+		return strings.ToLower(strings.ToUpper(name))
+	}
+	return name
+}
+
+// checkDuplicateNames returns ErrDuplicateName when two values of the enum share
+// a name, tag value or parse only spelling.
+func checkDuplicateNames(rep EnumRepresentation) error {
+	owners := make(map[string]string)
+	for _, e := range rep.Enums {
+		for _, name := range e.spellings() {
+			key := rep.foldName(name)
+			if owner, ok := owners[key]; ok && owner != e.Info.Name {
+				return fmt.Errorf("%w: %q parses to both %s and %s", ErrDuplicateName, name, owner, e.Info.Name)
+			}
+			owners[key] = e.Info.Name
+		}
+	}
+	return nil
+}
+
+// checkPackageNames returns ErrDuplicateName when a spelling of the enum is also
+// parsed by another enum generated into dir, found from the stringTo functions
+// of the other _enums.go files, and also the _enums_test.go files for a test enum.
+func checkPackageNames(dir string, rep EnumRepresentation) error {
+	names, err := packageNames(dir, rep.TypeInfo.Camel, rep.TypeInfo.Test)
+	if err != nil {
+		return err
+	}
+	others := make(map[string]string, len(names))
+	for name, typ := range names {
+		others[rep.foldName(name)] = typ
+	}
+	for _, e := range rep.Enums {
+		for _, name := range e.spellings() {
+			if other, ok := others[rep.foldName(name)]; ok {
+				return fmt.Errorf("%w: %q parses to both %s and %s", ErrDuplicateName, name, rep.TypeInfo.Camel, other)
+			}
+		}
+	}
+	return nil
+}
+
+// packageNames maps the spellings parsed by the enums generated into dir, other
+// than camel, to the enum type parsing them. Generated test files are included
+// when tests is set.
+func packageNames(dir, camel string, tests bool) (map[string]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*_enums.go"))
+	if err != nil {
+		return nil, err
+	}
+	if tests {
+		testFiles, err := filepath.Glob(filepath.Join(dir, "*_enums_test.go"))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, testFiles...)
+	}
+	sort.Strings(files)
+	names := make(map[string]string)
+	fset := token.NewFileSet()
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read generated file: %w", err)
+		}
+		node, err := parser.ParseFile(fset, file, src, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse generated file: %w", err)
+		}
+		for _, decl := range node.Decls {
+			var (
+				typ  string
+				body ast.Node
+			)
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				name, ok := strings.CutPrefix(decl.Name.Name, "stringTo")
+				if ok && decl.Recv == nil && decl.Body != nil {
+					typ, body = name, decl.Body
+				}
+			case *ast.GenDecl:
+				// the lookup map generated with Coverage
+				if decl.Tok != token.VAR || len(decl.Specs) != 1 {
+					continue
+				}
+				spec, ok := decl.Specs[0].(*ast.ValueSpec)
+				if !ok || len(spec.Names) != 1 {
+					continue
+				}
+				name, ok := strings.CutPrefix(spec.Names[0].Name, "stringTo")
+				if name, found := strings.CutSuffix(name, lookupMapSuffix); ok && found {
+					typ, body = name, decl
+				}
+			}
+			if typ == "" || typ == camel {
+				continue
+			}
+			ast.Inspect(body, func(n ast.Node) bool {
+				lit, ok := n.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					return true
+				}
+				if name, err := strconv.Unquote(lit.Value); err == nil {
+					names[name] = typ
+				}
+				return true
+			})
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,197 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Formats of the export of the enums of a module.
+const (
+	// ExportCSV is a CSV table with a header row.
+	ExportCSV = "csv"
+	// ExportXLSX is an Excel workbook with the same table on a single sheet,
+	// filterable by its header row.
+	ExportXLSX = "xlsx"
+)
+
+// exportFormats are the known export formats.
+var exportFormats = []string{ExportCSV, ExportXLSX}
+
+// exportHeader names the columns of the export.
+var exportHeader = []string{"Package", "Type", "Constant", "Name", "Display name", "Value", "Valid", "Aliases", "Description", "Extra values", "Owner"}
+
+// exportCell is a cell of the export, a number or boolean when set so
+// spreadsheets can sort and filter on it, and text otherwise.
+type exportCell struct {
+	text   string
+	number bool
+	bool   bool
+}
+
+// WriteExport parses the enums found by FindEnums with the options returned by
+// config and writes a table of every value of every enum to w in the format,
+// ExportCSV or ExportXLSX, for the people outside engineering asking for the
+// authoritative list. Each row holds the package, type, constant, name, display
+// name, number, validity, aliases, description and extra values of a value, and
+// the owner of its enum.
+func WriteExport(ctx context.Context, w io.Writer, format string, candidates []Candidate, config func(Candidate) (Config, error)) error {
+	if !slices.Contains(exportFormats, format) {
+		return fmt.Errorf("unknown export format %q, expected one of %s", format, strings.Join(exportFormats, ", "))
+	}
+	enums, err := catalog(ctx, candidates, config)
+	if err != nil {
+		return err
+	}
+	rows := exportRows(enums)
+	if format == ExportCSV {
+		return writeExportCSV(w, rows)
+	}
+	return writeExportXLSX(w, rows)
+}
+
+// exportRows returns the header and a row per value of the enums.
+func exportRows(enums []*catalogEnum) [][]exportCell {
+	header := make([]exportCell, len(exportHeader))
+	for i, h := range exportHeader {
+		header[i] = exportCell{text: h}
+	}
+	rows := [][]exportCell{header}
+	for _, e := range enums {
+		for _, v := range e.Values {
+			fields := make([]string, len(v.Fields))
+			for i, value := range v.Fields {
+				fields[i] = e.Fields[i].Name + "=" + value
+				if unit := e.Fields[i].Unit; unit != "" {
+					fields[i] += " " + unit
+				}
+			}
+			rows = append(rows, []exportCell{
+				{text: e.ImportPath},
+				{text: e.Type},
+				{text: v.Constant},
+				{text: v.Name},
+				{text: v.DisplayName},
+				{text: strconv.Itoa(v.Value), number: true},
+				{text: strconv.FormatBool(v.Valid), bool: true},
+				{text: strings.Join(v.Aliases, ", ")},
+				{text: v.Description},
+				{text: strings.Join(fields, ", ")},
+				{text: e.Header.Owner},
+			})
+		}
+	}
+	return rows
+}
+
+func writeExportCSV(w io.Writer, rows [][]exportCell) error {
+	cw := csv.NewWriter(w)
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, c := range row {
+			record[i] = c.text
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// xlsxParts are the fixed parts of the workbook around its single sheet.
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Enums" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// writeExportXLSX writes the rows as a workbook with a single sheet, its header
+// row frozen and filterable. The cells are inline strings, numbers and booleans,
+// so no shared strings or styles are needed.
+func writeExportXLSX(w io.Writer, rows [][]exportCell) error {
+	last := xlsxColumn(len(exportHeader)-1) + strconv.Itoa(len(rows))
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		if err := writeZipFile(zw, part.name, part.content); err != nil {
+			return err
+		}
+	}
+	var sheet bytes.Buffer
+	sheet.WriteString(xml.Header)
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	sheet.WriteString(`<dimension ref="A1:` + last + `"/>`)
+	sheet.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	sheet.WriteString(`<sheetData>`)
+	for r, row := range rows {
+		n := strconv.Itoa(r + 1)
+		sheet.WriteString(`<row r="` + n + `">`)
+		for c, cell := range row {
+			ref := xlsxColumn(c) + n
+			switch {
+			case cell.number:
+				sheet.WriteString(`<c r="` + ref + `"><v>` + cell.text + `</v></c>`)
+			case cell.bool:
+				v := "0"
+				if cell.text == "true" {
+					v = "1"
+				}
+				sheet.WriteString(`<c r="` + ref + `" t="b"><v>` + v + `</v></c>`)
+			case cell.text != "":
+				sheet.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">`)
+				xml.EscapeText(&sheet, []byte(cell.text))
+				sheet.WriteString(`</t></is></c>`)
+			}
+		}
+		sheet.WriteString(`</row>`)
+	}
+	sheet.WriteString(`</sheetData>`)
+	sheet.WriteString(`<autoFilter ref="A1:` + last + `"/>`)
+	sheet.WriteString(`</worksheet>`)
+	if err := writeZipFile(zw, "xl/worksheets/sheet1.xml", sheet.String()); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// writeZipFile adds a compressed file to the archive. The modification time is
+// left unset so the same enums always export to the same bytes.
+func writeZipFile(zw *zip.Writer, name, content string) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := io.WriteString(f, content); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// xlsxColumn returns the letters naming the zero based column i, A to Z, then AA.
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldsDirective names a struct type declared in the file whose fields are the
+// extra values of the enum, instead of the comment after the type, so the field
+// types are written as Go, e.g.
+//
+//	type planetData struct {
+//		Gravity float64
+//		Moons   int
+//		Day     time.Duration
+//	}
+//
+//	//goenums:fields planetData
+//	type planet int
+//
+// The values of each constant are still given in its comment, and the
+// annotations of a field in its goenums tag, e.g. `goenums:"unit=m/s²,min=0"`.
+const fieldsDirective = "fields"
+
+// fieldsTag is the key of the struct tag holding the annotations of a field.
+const fieldsTag = "goenums"
+
+// structSchemas returns the extra values of each type in the file with the fields
+// directive, from the fields of the struct type it names.
+func structSchemas(node *ast.File) (map[string][]nameTypePair, error) {
+	structs := make(map[string]*ast.StructType)
+	var enums []string
+	for _, decl := range node.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if st, ok := typeSpec.Type.(*ast.StructType); ok {
+				structs[typeSpec.Name.Name] = st
+			}
+			if _, ok := directiveValue(typeDoc(node, typeSpec.Name.Name), fieldsDirective); ok {
+				enums = append(enums, typeSpec.Name.Name)
+			}
+		}
+	}
+	schemas := make(map[string][]nameTypePair, len(enums))
+	for _, name := range enums {
+		structName, _ := directiveValue(typeDoc(node, name), fieldsDirective)
+		st, ok := structs[structName]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s: no struct type %s declared in the file", ErrInvalidDirective, fieldsDirective, strconv.Quote(structName))
+		}
+		var pairs []nameTypePair
+		for _, field := range st.Fields.List {
+			if len(field.Names) == 0 {
+				return nil, fmt.Errorf("%w: %s: embedded field %s in %s", ErrInvalidDirective, fieldsDirective, types.ExprString(field.Type), structName)
+			}
+			var options []string
+			if field.Tag != nil {
+				tag, _ := strconv.Unquote(field.Tag.Value)
+				if v, ok := reflect.StructTag(tag).Lookup(fieldsTag); ok {
+					options = strings.Split(v, ",")
+				}
+			}
+			for _, ident := range field.Names {
+				pair, err := annotateField(nameTypePair{
+					Name:  ident.Name,
+					Type:  types.ExprString(field.Type),
+					Value: strconv.Itoa(len(pairs)),
+				}, options)
+				if err != nil {
+					return nil, err
+				}
+				pairs = append(pairs, pair)
+			}
+		}
+		schemas[name] = pairs
+	}
+	return schemas, nil
+}
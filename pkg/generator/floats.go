@@ -0,0 +1,51 @@
+package generator
+
+import "io"
+
+// Policies for the float64 and float32 values passed to the generated Parse.
+const (
+	// FloatInputReject parses floats to the invalid value, the default.
+	FloatInputReject = "reject"
+	// FloatInputIntegral parses floats with no fractional part, such as the
+	// numbers decoded from JSON into an any, as the integer they hold.
+	FloatInputIntegral = "integral"
+	// FloatInputRound parses floats as the nearest integer, halves away from zero.
+	FloatInputRound = "round"
+)
+
+// floatInputs are the known float input policies.
+var floatInputs = []string{FloatInputReject, FloatInputIntegral, FloatInputRound}
+
+// parsesFloats reports whether Parse accepts float values.
+func (c Config) parsesFloats() bool {
+	return c.FloatInput == FloatInputIntegral || c.FloatInput == FloatInputRound
+}
+
+// writeFloatParseCases writes the cases of the Parse type switch for floats.
+func writeFloatParseCases(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.parsesFloats() {
+		return
+	}
+	w.WriteString("\tcase float64:\n")
+	w.WriteString("\t\tres = floatTo" + rep.TypeInfo.Camel + "(v)\n")
+	w.WriteString("\tcase float32:\n")
+	w.WriteString("\t\tres = floatTo" + rep.TypeInfo.Camel + "(float64(v))\n")
+}
+
+// writeFloatToTypeMethod writes floatTo, parsing a float holding an integer,
+// after rounding with FloatInputRound, and rejecting any other, as well as NaN,
+// the infinities and floats out of the range of int.
+func writeFloatToTypeMethod(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.parsesFloats() {
+		return
+	}
+	w.WriteString("func floatTo" + rep.TypeInfo.Camel + "(f float64) " + rep.TypeInfo.Camel + " {\n")
+	if rep.FloatInput == FloatInputRound {
+		w.WriteString("\tf = math.Round(f)\n")
+	}
+	w.WriteString("\tif i := int(f); float64(i) == f {\n")
+	w.WriteString("\t\treturn intTo" + rep.TypeInfo.Camel + "(i)\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn invalid" + rep.TypeInfo.Camel + "\n")
+	w.WriteString("}\n\n")
+}
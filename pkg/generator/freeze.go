@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrFrozenName is returned in FreezeNames mode when regenerating would drop a
+// name present in the previously generated file.
+var ErrFrozenName = fmt.Errorf("frozen name removed")
+
+// checkFrozenNames compares the names in the previously generated file against
+// the enum being generated and fails if any of them are no longer produced.
+// A missing previous file is not an error as there is nothing to compare.
+func checkFrozenNames(previous *generatedFile, rep EnumRepresentation) error {
+	if previous == nil {
+		return nil
+	}
+	var removed []string
+	for _, c := range diffEntries(previous.Entries, rep.entries()) {
+		if c.Kind == changeRemoved || c.Kind == changeRenamed {
+			removed = append(removed, strconv.Quote(c.Previous.Name))
+		}
+	}
+	if len(removed) > 0 {
+		return fmt.Errorf("%w: %s no longer generated for %s", ErrFrozenName, strings.Join(removed, ", "), rep.TypeInfo.Camel)
+	}
+	return nil
+}
+
+// generatedFile is what is recovered from a previously generated enum file.
+type generatedFile struct {
+	// Command that generated the file
+	Command string
+	// Checksum of the source the file was generated from, empty for files
+	// generated before it was recorded
+	Checksum string
+	// Entries matched by the stringTo function with the values from the compile check
+	Entries []enumEntry
+}
+
+// readGenerated reads the entries of a previously generated file, returning
+// nil when the file does not exist.
+func readGenerated(filename, camel string) (*generatedFile, error) {
+	src, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read previously generated file: %w", err)
+	}
+	node, err := parser.ParseFile(token.NewFileSet(), filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToParseFile, err)
+	}
+	gen := &generatedFile{}
+	if len(node.Comments) > 0 {
+		for _, c := range node.Comments[0].List {
+			// the command comes first, the description may start with goenums too
+			if cmd, ok := strings.CutPrefix(c.Text, "// goenums "); ok && gen.Command == "" {
+				gen.Command = "goenums " + strings.TrimSpace(cmd)
+			}
+			if sum, ok := strings.CutPrefix(c.Text, checksumPrefix); ok {
+				gen.Checksum = strings.TrimSpace(sum)
+			}
+		}
+	}
+	values := make(map[string]int)
+	for _, decl := range node.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Name.Name != "_" {
+			continue
+		}
+		// _ = x[name - value]
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			idx, ok := n.(*ast.IndexExpr)
+			if !ok {
+				return true
+			}
+			sub, ok := idx.Index.(*ast.BinaryExpr)
+			if !ok || sub.Op != token.SUB {
+				return false
+			}
+			name, ok := sub.X.(*ast.Ident)
+			lit, isLit := sub.Y.(*ast.BasicLit)
+			if !ok || !isLit {
+				return false
+			}
+			v, err := strconv.Atoi(lit.Value)
+			if err == nil {
+				values[strings.ToUpper(name.Name)] = v
+			}
+			return false
+		})
+	}
+	for _, decl := range node.Decls {
+		if vars, ok := decl.(*ast.GenDecl); ok {
+			gen.Entries = append(gen.Entries, mapEntries(vars, "stringTo"+camel+lookupMapSuffix, values)...)
+			continue
+		}
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Name.Name != "stringTo"+camel {
+			continue
+		}
+		// case "name": return Container.UPPER
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			clause, ok := n.(*ast.CaseClause)
+			if !ok {
+				return true
+			}
+			var upper string
+			if len(clause.Body) == 1 {
+				if ret, ok := clause.Body[0].(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+					if sel, ok := ret.Results[0].(*ast.SelectorExpr); ok {
+						upper = sel.Sel.Name
+					}
+				}
+			}
+			// the first case is the name, any others are accepted tag values
+			if len(clause.List) == 0 {
+				return false
+			}
+			lit, ok := clause.List[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return false
+			}
+			name, err := strconv.Unquote(lit.Value)
+			if err == nil {
+				gen.Entries = append(gen.Entries, enumEntry{Name: name, Value: values[upper]})
+			}
+			return false
+		})
+	}
+	return gen, nil
+}
+
+// mapEntries returns the entries of the stringTo lookup map generated with Coverage
+// when decl declares it as name, mapping each spelling to Container.UPPER. The first
+// spelling of a value is its name and any others are accepted tag values.
+func mapEntries(decl *ast.GenDecl, name string, values map[string]int) []enumEntry {
+	var entries []enumEntry
+	for _, spec := range decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok || len(vs.Names) != 1 || vs.Names[0].Name != name || len(vs.Values) != 1 {
+			continue
+		}
+		lit, ok := vs.Values[0].(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := kv.Key.(*ast.BasicLit)
+			sel, isSel := kv.Value.(*ast.SelectorExpr)
+			if !ok || !isSel || key.Kind != token.STRING || seen[sel.Sel.Name] {
+				continue
+			}
+			seen[sel.Sel.Name] = true
+			if name, err := strconv.Unquote(key.Value); err == nil {
+				entries = append(entries, enumEntry{Name: name, Value: values[sel.Sel.Name]})
+			}
+		}
+	}
+	return entries
+}
@@ -13,152 +13,671 @@ package generator
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/format"
 	"go/parser"
 	"go/token"
 	"io"
-	"log"
+	"math"
 	"os"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 )
 
 // camelCase is a Caser for turning strings into camelCase.
 func camelCase(in string) string {
-	first := strings.ToUpper(in[:1])
-	rest := in[1:]
-	return first + rest
+	r, size := utf8.DecodeRuneInString(in)
+	return exported(string(unicode.ToUpper(r)) + in[size:])
 }
 
+// upperCase upper-cases every rune of in, keeping the result exported.
+func upperCase(in string) string {
+	return exported(strings.ToUpper(in))
+}
+
+// exported prefixes identifiers starting with a letter that has no upper case
+// form, such as CJK ideographs, with X so they are exported.
+func exported(in string) string {
+	r, _ := utf8.DecodeRuneInString(in)
+	if unicode.IsLetter(r) && !unicode.IsUpper(r) {
+		return "X" + in
+	}
+	return in
+}
+
+// RepresentationVersion is the version of the representation returned by Parse,
+// incremented whenever a change to its fields or their meaning would make another
+// version of goenums generate different files from it.
+const RepresentationVersion = 1
+
+// ErrIncompatibleRepresentation is returned when generating from a representation
+// of a version other than RepresentationVersion.
+var ErrIncompatibleRepresentation = fmt.Errorf("incompatible representation")
+
 // EnumRepresentation is a struct to store the information to be used in writing the enum to a file.
+// Its JSON form, with the field names given by the tags, is kept stable so it can be
+// passed between processes, see Parse and GenerateRepresentation.
 type EnumRepresentation struct {
-	PackageName string
-	Failfast    bool
-	TypeInfo    typeInfo
-	Enums       []Enum
+	// Version of the representation, RepresentationVersion when returned by Parse
+	Version     int `json:"version"`
+	Config      `json:"config"`
+	PackageName string `json:"package"`
+	// ImportPath of the package the enum is declared in, empty when outside a module
+	ImportPath string   `json:"importPath,omitempty"`
+	TypeInfo   typeInfo `json:"type"`
+	Enums      []Enum   `json:"values"`
 }
 
 // Enum is a struct to store the information for each enum to be written.
 type Enum struct {
-	Info     info
-	TypeInfo typeInfo
-	Raw      raw
+	Info     info     `json:"info"`
+	TypeInfo typeInfo `json:"type"`
+	Raw      raw      `json:"raw"`
 }
 
 type raw struct {
 	// raw comment for the enum
-	Comment string
+	Comment string `json:"comment,omitempty"`
 	// raw comment for the type
-	TypeComment string
+	TypeComment string `json:"typeComment,omitempty"`
 }
 
 type info struct {
 	// base info for the enum
-	Name          string
-	AlternateName string
-	Camel         string
-	Lower         string
-	Upper         string
-	Value         int
+	Name string `json:"name"`
+	// Ident is the identifier the names are derived from, Name without any trimmed prefix
+	Ident         string `json:"ident"`
+	AlternateName string `json:"alternateName"`
+	Camel         string `json:"camel"`
+	Lower         string `json:"lower"`
+	Upper         string `json:"upper"`
+	Value         int    `json:"value"`
 	// valid or invalid
-	Valid bool
+	Valid bool `json:"valid"`
+	// Sentinel marks the constant declared as the invalid value with the //goenums:invalid directive
+	Sentinel bool `json:"sentinel,omitempty"`
+	// Default marks the constant assigned to a constant with the //goenums:default directive
+	Default bool `json:"default,omitempty"`
+	// Tags are the key:"value" metadata pairs from the value comment in declaration order
+	Tags []tag `json:"tags,omitempty"`
 }
 
 type typeInfo struct {
-	Filename string
-	Index    int
+	Filename string `json:"filename"`
+	Index    int    `json:"index"`
 	// type name for the enum in different cases
-	Name        string
-	Camel       string
-	Lower       string
-	Upper       string
-	Plural      string
-	PluralCamel string
+	Name        string `json:"name"`
+	Camel       string `json:"camel"`
+	Lower       string `json:"lower"`
+	Upper       string `json:"upper"`
+	Plural      string `json:"plural,omitempty"`
+	PluralCamel string `json:"pluralCamel,omitempty"`
+	// Container is the name of the package level container variable
+	Container string `json:"container,omitempty"`
 	// name type pairs for the enum not using iota
-	NameTypePairs []nameTypePair
+	NameTypePairs []nameTypePair `json:"fields,omitempty"`
+	// Outputs are the formats from the output directive on the type, overriding the config
+	Outputs []string `json:"outputs,omitempty"`
+	// Handlers are the handlers from the handlers directive on the type, overriding the config
+	Handlers []string `json:"handlers,omitempty"`
+	// Test is set when the enum is declared in a _test.go file, so its Go outputs
+	// are test files too
+	Test bool `json:"test,omitempty"`
+	// Checksum of the source declarations, recorded in the header for Check
+	Checksum string `json:"checksum,omitempty"`
 }
 
 // nameTypePair is a struct to store the name and type of the extra values for the enum.
 type nameTypePair struct {
 	// name of the extra value
-	Name string
+	Name string `json:"name"`
 	// type of the extra value
-	Type string
+	Type string `json:"type"`
 	// value of the extra value
-	Value string
+	Value string `json:"value"`
+	// Unexported stores the extra value in an unexported field read through an accessor method
+	Unexported bool `json:"unexported,omitempty"`
+	// Unit of the extra value, written into its documentation
+	Unit string `json:"unit,omitempty"`
+	// Min and Max bound the values of a numeric extra value, as Go literals
+	Min string `json:"min,omitempty"`
+	Max string `json:"max,omitempty"`
+}
+
+// descriptionField is the name of the extra value that is exposed through a Description method.
+const descriptionField = "Description"
+
+// Field returns the name of the wrapper struct field for the extra value.
+// Unexported values and the description are stored with a lower case first
+// letter so they can only be read through their accessor method.
+func (p nameTypePair) Field() string {
+	if p.Unexported || p.Name == descriptionField {
+		return lowerFirst(p.Name)
+	}
+	return p.Name
+}
+
+// hasAccessor reports whether the extra value is read through a generated accessor method.
+func (p nameTypePair) hasAccessor() bool {
+	return p.Field() != p.Name
+}
+
+// unexportNameTPairs marks every extra value as unexported.
+func unexportNameTPairs(nameTPairs []nameTypePair) []nameTypePair {
+	for i := range nameTPairs {
+		nameTPairs[i].Unexported = true
+	}
+	return nameTPairs
+}
+
+// lowerFirst lower cases the first letter of in.
+func lowerFirst(in string) string {
+	r, size := utf8.DecodeRuneInString(in)
+	return string(unicode.ToLower(r)) + in[size:]
 }
 
 // ErrFailedToParseFile is an error returned when the file cannot be parsed.
 var ErrFailedToParseFile = fmt.Errorf("failed to parse file")
 
 // ParseAndGenerate parses the file and generates the enum go file for the enum type with failfast mode flag.
+// It holds no shared state so it is safe to call concurrently for different files.
 func ParseAndGenerate(filename string, failfast bool) error {
+	return ParseAndGenerateContext(context.Background(), filename, failfast)
+}
+
+// ParseAndGenerateContext is ParseAndGenerate with cancellation support.
+// The context is checked between each generated section and the file is
+// only written once generation has completed, so cancelling leaves no partial output.
+func ParseAndGenerateContext(ctx context.Context, filename string, failfast bool) error {
+	return ParseAndGenerateWithConfig(ctx, filename, Config{Failfast: failfast})
+}
+
+// ParseAndGenerateWithConfig parses the file and generates the enum go file using the options in cfg.
+func ParseAndGenerateWithConfig(ctx context.Context, filename string, cfg Config) error {
+	return parseAndGenerate(ctx, path.Dir(filename), filename, nil, cfg)
+}
+
+// parseAndGenerate parses the enum in filename, or in src when it is not nil, and
+// writes the generated files to the directory p, recording filename in their headers.
+func parseAndGenerate(ctx context.Context, p string, filename string, src any, cfg Config) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	enumRep, err := parseRepresentation(filename, src, cfg)
+	if err != nil {
+		return err
+	}
+	typeLower := enumRep.TypeInfo.Lower
+	enumRep.ImportPath, err = packageImportPath(p)
+	if err != nil {
+		return err
+	}
+	// path separator
+	linuxPathSeparator := "/"
+	if cfg.FreezeNames || cfg.Report != nil {
+		previous, err := readGenerated(p+linuxPathSeparator+typeLower+enumRep.goSuffix("_enums"), enumRep.TypeInfo.Camel)
+		if err != nil {
+			return err
+		}
+		if cfg.Report != nil {
+			var changes []change
+			var previousCommand string
+			if previous != nil {
+				changes = diffEntries(previous.Entries, enumRep.entries())
+				previousCommand = previous.Command
+			}
+			err = writeReport(cfg.Report, enumRep.TypeInfo.Camel, previousCommand, enumRep.command(), changes)
+			if err != nil {
+				return fmt.Errorf("failed to write change report: %w", err)
+			}
+		}
+		if cfg.FreezeNames {
+			err = checkFrozenNames(previous, enumRep)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if cfg.UniqueNames {
+		err = checkPackageNames(p, enumRep)
+		if err != nil {
+			return err
+		}
+	}
+	lockPath := p + linuxPathSeparator + enumRep.lockFilename()
+	if cfg.Lock {
+		err = checkLock(lockPath, enumRep)
+		if err != nil {
+			return err
+		}
+	}
+	files, err := generateFiles(ctx, enumRep)
+	if err != nil {
+		return err
+	}
+	if cfg.MaxDiffLines > 0 && !cfg.Force {
+		err = checkDiffSize(p, files, cfg.MaxDiffLines)
+		if err != nil {
+			return err
+		}
+	}
+	// last chance to cancel before anything touches the disk
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, f := range files {
+		fullPath := p + linuxPathSeparator + f.Name
+		err = os.WriteFile(fullPath, f.Content, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+	}
+	if cfg.Lock {
+		err = os.WriteFile(lockPath, lockFile(enumRep), 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write lockfile: %w", err)
+		}
+	}
+	if cfg.Manifest {
+		var written []string
+		for _, f := range files {
+			written = append(written, f.Name)
+		}
+		if cfg.Lock {
+			written = append(written, enumRep.lockFilename())
+		}
+		err = writeManifest(p, enumRep, written)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// File is a file generated for an enum, named relative to the directory of its source.
+type File struct {
+	Name    string
+	Content []byte
+}
+
+// Generate generates the files for the enum declared in src, the contents of
+// filename, and returns them rather than writing them. Nothing else is read or
+// written, so build systems can run goenums hermetically. The contents of the
+// file named by a //goenums:data directive are taken from cfg.Data. The options
+// that need the files next to the source, FreezeNames, Lock, UniqueNames,
+// Report, Manifest and MaxDiffLines, are rejected, and sqlc output has no import
+// path as go.mod is not read.
+func Generate(ctx context.Context, filename string, src []byte, cfg Config) ([]File, error) {
+	rep, err := Parse(ctx, filename, src, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return generateFiles(ctx, rep)
+}
+
+// Parse parses the enum declared in src, the contents of filename, into the
+// representation Generate writes the files from. The representation marshals to
+// JSON and back unchanged, so parsing and generating can run in separate processes
+// with GenerateRepresentation. The same options as Generate are rejected.
+func Parse(ctx context.Context, filename string, src []byte, cfg Config) (EnumRepresentation, error) {
+	if err := ctx.Err(); err != nil {
+		return EnumRepresentation{}, err
+	}
+	if err := checkHermetic(cfg); err != nil {
+		return EnumRepresentation{}, err
+	}
+	if cfg.Data == nil {
+		// the data files are only taken from the config
+		cfg.Data = map[string][]byte{}
+	}
+	return parseRepresentation(filename, src, cfg)
+}
+
+// GenerateRepresentation generates the files for an enum returned by Parse,
+// typically decoded from JSON written by another process. Representations of
+// another version are rejected with ErrIncompatibleRepresentation rather than
+// generated from fields that may have changed meaning. Its config is validated
+// again as the representation may have been edited in between.
+func GenerateRepresentation(ctx context.Context, rep EnumRepresentation) ([]File, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if rep.Version != RepresentationVersion {
+		return nil, fmt.Errorf("%w: version %d, this goenums reads version %d", ErrIncompatibleRepresentation, rep.Version, RepresentationVersion)
+	}
+	if err := checkHermetic(rep.Config); err != nil {
+		return nil, err
+	}
+	if len(rep.Enums) == 0 {
+		return nil, fmt.Errorf("%w: representation of %s has no values", ErrInvalidConfig, rep.TypeInfo.Name)
+	}
+	return generateFiles(ctx, rep)
+}
+
+// checkHermetic validates cfg for generation that reads and writes nothing but
+// the source it is given.
+func checkHermetic(cfg Config) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	if cfg.FreezeNames || cfg.Lock || cfg.UniqueNames || cfg.Report != nil || cfg.Manifest || cfg.MaxDiffLines > 0 {
+		return fmt.Errorf("%w: freeze-names, lock, unique-names, report, manifest and max-diff-lines need the source directory", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// generateFiles generates every output of the enum.
+func generateFiles(ctx context.Context, rep EnumRepresentation) ([]File, error) {
+	outs := rep.outputs()
+	files := make([]File, len(outs))
+	for i, out := range outs {
+		if out.check != nil {
+			if err := out.check(rep); err != nil {
+				return nil, err
+			}
+		}
+		name := out.name(rep.TypeInfo.Lower)
+		b, err := generate(ctx, rep, out)
+		if err != nil {
+			return nil, err
+		}
+		if !out.plain {
+			b, err = applyStyle(rep, name, b)
+			if err != nil {
+				return nil, err
+			}
+			b, err = postProcess(rep.PostProcess, name, b)
+			if err != nil {
+				return nil, err
+			}
+		}
+		files[i] = File{Name: name, Content: b}
+	}
+	return files, nil
+}
+
+// parseRepresentation parses the enum in filename, or in src when it is not nil,
+// into its representation for generation.
+func parseRepresentation(filename string, src any, cfg Config) (EnumRepresentation, error) {
 	// Set up the parser
 	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	node, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
 	if err != nil {
-		return fmt.Errorf("failed to parse file while generating enum: %w", err)
+		return EnumRepresentation{}, fmt.Errorf("failed to parse file while generating enum: %w", err)
 	}
 
 	packageName := getPackageName(node)
 
 	// Traverse the AST to find type definitions and collect comments
 	// Collect comments associated with the type definition
-	typeComments := getTypeComments(node)
-	enums, iotaType, iotaIdx, nameTPairs := parseEnums(node, typeComments)
+	typeComments := getTypeComments(fset, node)
+	typeFields, err := structSchemas(node)
+	if err != nil {
+		return EnumRepresentation{}, err
+	}
+	typeName := cfg.Type
+	if typeName == "" {
+		switch types := iotaTypes(node); {
+		case len(types) > 1:
+			return EnumRepresentation{}, fmt.Errorf("%w: %s declares the enums %s, choose one with -type", ErrInvalidConfig, filename, strings.Join(types, ", "))
+		case len(types) == 1:
+			typeName = types[0]
+		}
+	}
+	enums, iotaType, iotaIdx, nameTPairs := parseEnums(node, typeComments, typeFields, typeName)
+	if cfg.Type != "" && iotaType == "" {
+		return EnumRepresentation{}, fmt.Errorf("%w: type %q has no constants declared with iota or pinned in %s", ErrInvalidConfig, cfg.Type, filename)
+	}
+	enums, nameTPairs, err = applyAnnotations(enums, nameTPairs)
+	if err != nil {
+		return EnumRepresentation{}, err
+	}
+	var data []byte
+	if name, ok := directiveValue(typeDoc(node, iotaType), dataDirective); ok {
+		data, ok = cfg.Data[name]
+		if !ok && cfg.Data != nil {
+			return EnumRepresentation{}, fmt.Errorf("%w: %s: %s is not read when generating hermetically, give its contents with data", ErrInvalidDirective, dataDirective, name)
+		}
+		if !ok {
+			data, err = os.ReadFile(path.Join(path.Dir(filename), name))
+			if err != nil {
+				return EnumRepresentation{}, fmt.Errorf("%w: %s: %w", ErrInvalidDirective, dataDirective, err)
+			}
+		}
+		enums, nameTPairs, err = applyData(name, data, enums, nameTPairs)
+		if err != nil {
+			return EnumRepresentation{}, err
+		}
+	}
+	if derived := directiveValues(typeDoc(node, iotaType), derivedDirective); len(derived) > 0 {
+		enums, nameTPairs, err = applyDerived(derived, enums, nameTPairs)
+		if err != nil {
+			return EnumRepresentation{}, err
+		}
+	}
+	if err := checkFieldBounds(enums, nameTPairs); err != nil {
+		return EnumRepresentation{}, err
+	}
+	if name, ok := defaultConstant(node, enums, cfg.Type != ""); ok {
+		enums, err = applyDefault(enums, name)
+		if err != nil {
+			return EnumRepresentation{}, err
+		}
+	} else if cfg.EmptyDefault {
+		return EnumRepresentation{}, fmt.Errorf("%w: emptydefault needs a constant marked with %s%s", ErrInvalidConfig, directivePrefix, defaultDirective)
+	}
+	if cfg.TrimPrefix != "" {
+		enums = trimPrefix(enums, cfg.TrimPrefix)
+	}
+	if style, ok := directiveValue(typeDoc(node, iotaType), namesDirective); ok {
+		enums, err = applyNameStyle(enums, style)
+		if err != nil {
+			return EnumRepresentation{}, err
+		}
+	}
+	var outs []string
+	if v, ok := directiveValue(typeDoc(node, iotaType), outputDirective); ok {
+		outs = parseOutputs(v)
+		if err := validateOutputs(outs); err != nil {
+			return EnumRepresentation{}, fmt.Errorf("%w: %s: %w", ErrInvalidDirective, outputDirective, err)
+		}
+	}
+	var selected []string
+	if v, ok := directiveValue(typeDoc(node, iotaType), handlersDirective); ok {
+		selected, err = parseHandlers(v)
+		if err != nil {
+			return EnumRepresentation{}, err
+		}
+	}
+	checksum, err := sourceChecksum(fset, node, iotaType, data)
+	if err != nil {
+		return EnumRepresentation{}, err
+	}
 	typeLower, plural := getPlural(iotaType)
-	enumRep := EnumRepresentation{
+	camel := cfg.Prefix + camelCase(iotaType) + cfg.Suffix
+	pluralCamel := cfg.Prefix + camelCase(plural) + cfg.Suffix
+	for i := range enums {
+		enums[i].TypeInfo.Camel = camel
+	}
+	if cfg.Accessors {
+		nameTPairs = unexportNameTPairs(nameTPairs)
+		for i := range enums {
+			enums[i].TypeInfo.NameTypePairs = unexportNameTPairs(enums[i].TypeInfo.NameTypePairs)
+		}
+	}
+	rep := EnumRepresentation{
+		Version:     RepresentationVersion,
+		Config:      cfg,
 		PackageName: packageName,
-		Failfast:    failfast,
 		TypeInfo: typeInfo{
 			Filename:      filename,
 			Index:         iotaIdx,
 			Name:          iotaType,
-			Camel:         camelCase(iotaType),
+			Camel:         camel,
 			Lower:         typeLower,
-			Upper:         strings.ToUpper(iotaType),
+			Upper:         upperCase(iotaType),
 			Plural:        plural,
-			PluralCamel:   camelCase(plural),
+			PluralCamel:   pluralCamel,
+			Container:     containerName(pluralCamel, cfg),
 			NameTypePairs: nameTPairs,
+			Outputs:       outs,
+			Handlers:      selected,
+			Test:          strings.HasSuffix(filename, "_test.go"),
+			Checksum:      checksum,
 		},
 		Enums: enums,
 	}
-	// create new file
-	// get the p from the filename
+	if err := checkDuplicateNames(rep); err != nil {
+		return EnumRepresentation{}, err
+	}
+	if err := checkDuplicateValues(rep); err != nil {
+		return EnumRepresentation{}, err
+	}
+	if cfg.RoundTripCheck {
+		if err := checkRoundTrip(rep); err != nil {
+			return EnumRepresentation{}, err
+		}
+	}
+	return rep, nil
+}
 
-	p := path.Dir(filename)
-	// path separator
-	linuxPathSeparator := "/"
-	fullPath := p + linuxPathSeparator + typeLower + "_enums.go"
-	f, err := os.Create(fullPath)
-	if err != nil {
-		log.Fatalf("Error creating file: %v", err)
+// output is a file generated alongside the source file.
+type output struct {
+	// suffix appended to the lower case type name to make the filename
+	suffix string
+	// filename used as is instead of the type name and suffix, for files shared by the
+	// package or named after the enum type
+	filename string
+	// sections written to the file in order
+	sections []func(io.StringWriter, EnumRepresentation)
+	// plain outputs are not Go source and are written without formatting
+	plain bool
+	// check rejects enums the output cannot represent, nil when it can represent any
+	check func(EnumRepresentation) error
+}
+
+// name returns the filename of the output for the enum type.
+func (o output) name(typeLower string) string {
+	if o.filename != "" {
+		return o.filename
 	}
-	w := io.StringWriter(f)
-	defer f.Close()
-	writeAll(w, enumRep)
-	// format the file
-	err = formatFile(fullPath)
-	if err != nil {
-		return fmt.Errorf("failed to format file: %w", err)
+	return typeLower + o.suffix
+}
+
+// sharedFilename is the file holding the helpers shared by every enum in a package.
+const sharedFilename = "enums_common.go"
+
+// hasOutput reports whether the output format should be generated for the enum,
+// with an output directive on the type taking precedence over the config.
+func (rep EnumRepresentation) hasOutput(format string) bool {
+	if rep.TypeInfo.Outputs != nil {
+		return slices.Contains(rep.TypeInfo.Outputs, format)
 	}
-	return nil
+	return rep.Config.hasOutput(format)
+}
+
+// outputs returns the files to generate for the enum.
+func (rep EnumRepresentation) outputs() []output {
+	var outs []output
+	if rep.Stringer {
+		return []output{{filename: stringerFilename(rep), sections: stringerSections, check: checkStringer}}
+	}
+	if rep.BaseMethods && rep.hasOutput(OutputGo) {
+		outs = append(outs, output{suffix: rep.goSuffix("_enums"), sections: baseSections, check: checkBaseMethods})
+	} else if rep.hasOutput(OutputGo) {
+		outs = append(outs, output{suffix: rep.goSuffix("_enums"), sections: rep.goSections()})
+		if rep.JSONv2 && rep.hasHandler(HandlerJSON) {
+			outs = append(outs, output{suffix: rep.goSuffix("_enums_jsonv2"), sections: jsonv2Sections})
+		}
+		if rep.shared() {
+			outs = append(outs, output{filename: sharedFilename, sections: sharedSections})
+		}
+	}
+	if rep.hasOutput(OutputSQLC) {
+		outs = append(outs, output{suffix: "_sqlc.yaml", sections: sqlcSections, plain: true})
+	}
+	if rep.hasOutput(OutputCSV) {
+		outs = append(outs, output{suffix: "_mapping.csv", sections: mappingCSVSections, plain: true})
+	}
+	if rep.hasOutput(OutputJSON) {
+		outs = append(outs, output{suffix: "_mapping.json", sections: mappingJSONSections, plain: true})
+	}
+	if rep.hasOutput(OutputAvro) {
+		outs = append(outs, output{suffix: ".avsc", sections: avroSections, plain: true, check: checkAvroSymbols})
+	}
+	if rep.hasOutput(OutputJava) {
+		outs = append(outs, output{filename: rep.TypeInfo.Camel + ".java", sections: javaSections, plain: true, check: checkJava})
+	}
+	if rep.hasOutput(OutputKotlin) {
+		outs = append(outs, output{filename: rep.TypeInfo.Camel + ".kt", sections: kotlinSections, plain: true, check: checkKotlin})
+	}
+	if rep.hasOutput(OutputPython) {
+		outs = append(outs, output{suffix: "_enums.py", sections: pythonSections, plain: true, check: checkPython})
+	}
+	if rep.hasOutput(OutputRust) {
+		outs = append(outs, output{suffix: "_enums.rs", sections: rustSections, plain: true, check: checkRust})
+	}
+	if rep.hasOutput(OutputCSharp) {
+		outs = append(outs, output{filename: rep.TypeInfo.Camel + ".cs", sections: csharpSections, plain: true, check: checkCSharp})
+	}
+	return outs
+}
+
+// goSuffix returns the filename suffix of a generated Go file, a _test.go file when
+// the enum is declared in one so the generated code is only compiled into tests.
+func (rep EnumRepresentation) goSuffix(stem string) string {
+	if rep.TypeInfo.Test {
+		return stem + "_test.go"
+	}
+	return stem + ".go"
+}
+
+// shared reports whether the enum uses the helpers in the shared file. Test enums
+// always carry their own, as a shared test file would clash with the shared file of
+// the package.
+func (rep EnumRepresentation) shared() bool {
+	return rep.Shared && !rep.TypeInfo.Test
+}
+
+// containerName returns the name of the container variable, which is unexported
+// when the container is only reachable through its accessor function.
+func containerName(plural string, cfg Config) string {
+	if cfg.Immutable {
+		return lowerFirst(camelCase(plural))
+	}
+	return camelCase(plural)
+}
+
+// containerRef returns the expression used by callers to reach the container.
+func (rep EnumRepresentation) containerRef() string {
+	if rep.Immutable {
+		return rep.TypeInfo.PluralCamel + "()"
+	}
+	return rep.TypeInfo.PluralCamel
 }
 
 func getPlural(iotaType string) (string, string) {
-	l := len(iotaType)
-	if l == 0 {
+	if iotaType == "" {
 		return "", ""
 	}
-	lastChar := iotaType[l-1]
+	lastChar, _ := utf8.DecodeLastRuneInString(iotaType)
 	lower := strings.ToLower(iotaType)
 	camel := camelCase(iotaType)
 	switch lastChar {
 	case 'y':
-		return lower[:l-1] + "ies", camel[:l-1] + "ies"
+		return strings.TrimSuffix(lower, "y") + "ies", strings.TrimSuffix(camel, "y") + "ies"
 	case 'x', 'z', 'h', 'o', 's':
 		return lower + "es", camel + "es"
 	default:
@@ -166,13 +685,21 @@ func getPlural(iotaType string) (string, string) {
 	}
 }
 
-func parseEnums(node *ast.File, typeComments map[string]string) ([]Enum, string, int, []nameTypePair) {
+// parseEnums returns the enum constants declared with iota, or pinned to explicit
+// values, in the file and the type they are declared with. Only the constants of
+// typeName are returned, so files declaring several enums can generate each one;
+// parseRepresentation sets it to the only enum type of the file when -type is not given.
+// The extra values of a type in typeFields are taken from there rather than
+// from its comment.
+func parseEnums(node *ast.File, typeComments map[string]string, typeFields map[string][]nameTypePair, typeName string) ([]Enum, string, int, []nameTypePair) {
 	var (
 		enums           []Enum
 		iotaName        string
 		iotaType        string
 		iotaTypeComment string
 		iotaIdx         int
+		hasDescription  bool
+		pinnedBlock     bool
 		foundConstants  = make(map[string]struct{})
 		nameTPairs      = make([]nameTypePair, 0)
 	)
@@ -181,43 +708,103 @@ func parseEnums(node *ast.File, typeComments map[string]string) ([]Enum, string,
 		if !ok || decl.Tok != token.CONST {
 			return true
 		}
+		// every block declaring constants of typeName with iota, or with
+		// explicit values when pinned, adds them to the enum, blocks of other
+		// types, such as an untyped KB = 1 << (10 * iota), are skipped
+		iotaName, iotaTypeComment = "", ""
+		pinned := hasDirective(decl.Doc, pinnedDirective)
 		for _, spec := range decl.Specs {
-			if valueSpec, ok := spec.(*ast.ValueSpec); ok && len(valueSpec.Values) == 1 {
-				iotaName, iotaType, iotaTypeComment, iotaIdx = iotaInfo(valueSpec, typeComments)
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok || len(valueSpec.Values) == 0 {
+				continue
+			}
+			info := iotaInfo
+			if pinned {
+				info = pinnedInfo
+			}
+			name, typ, typeComment, idx := info(valueSpec, typeComments)
+			if name != "" && (typeName == "" || typ == typeName) {
+				iotaName, iotaType, iotaTypeComment, iotaIdx = name, typ, typeComment, idx
+				pinnedBlock = pinned
+				break
 			}
-			continue
 		}
-		if iotaTypeComment != "" {
+		if fields, ok := typeFields[iotaType]; ok && iotaName != "" {
+			nameTPairs = append(nameTPairs, fields...)
+		} else if iotaTypeComment != "" {
 			nameTPairs = nameTPairsFromComments(iotaTypeComment, nameTPairs)
 		}
 		if iotaName != "" {
+			iotaExpr := false
+			var exprs []ast.Expr
 			for i, spec := range decl.Specs {
 				valueSpec, ok := spec.(*ast.ValueSpec)
 				if !ok {
 					continue
 				}
-				for _, name := range valueSpec.Names {
+				// constants without a value repeat the previous expressions, so
+				// only those following an iota expression are enum values and
+				// the others, such as defaultStatus = active, are skipped
+				if len(valueSpec.Values) > 0 {
+					iotaExpr = usesIota(valueSpec.Values[0])
+					exprs = valueSpec.Values
+				}
+				if pinned {
+					iotaExpr = pinnedSpec(valueSpec, iotaType)
+				}
+				if !iotaExpr {
+					continue
+				}
+				for j, name := range valueSpec.Names {
+					// blank constants skip a value, leaving a gap in the enum
+					if name.Name == "_" {
+						continue
+					}
+					// each name of a spec has its own expression, e.g.
+					// a, b status = iota * 2, iota*2 + 1
+					value := i
+					if pinned {
+						value, _ = pinnedValue(valueSpec.Values[j])
+					} else if j < len(exprs) {
+						if v, ok := iotaValue(exprs[j], i); ok {
+							value = v - iotaIdx
+						}
+					}
 					if _, found := foundConstants[name.Name]; !found {
 						iotaTypeComment = getTypeComment(valueSpec, typeComments)
-						comment := getComment(valueSpec)
-						valid := !strings.Contains(comment, "invalid")
+						comment, tags := getTags(getComment(valueSpec))
+						sentinel := hasDirective(valueSpec.Doc, invalidDirective)
+						comment, description := getDescription(comment)
+						valid := !strings.Contains(stripQuoted(comment), "invalid")
 						comment, alternate := getAlternateName(comment, name, nameTPairs)
-						nameTPairsCopy := copyNameTPairs(nameTPairs, getValues(comment))
+						values := getValues(comment)
+						// a desc= directive gives the Description, the values the other fields
+						if i := slices.IndexFunc(nameTPairs, func(p nameTypePair) bool { return p.Name == descriptionField }); i >= 0 && description != "" && strings.TrimSpace(comment) != "" && len(values) == len(nameTPairs)-1 {
+							values = slices.Insert(values, i, "")
+						}
+						nameTPairsCopy := copyNameTPairs(nameTPairs, values)
+						if description != "" {
+							hasDescription = true
+							nameTPairsCopy = setDescription(nameTPairsCopy, description)
+						}
 						enums = append(enums, Enum{
 							Info: info{
 								Name:          name.Name,
+								Ident:         name.Name,
 								Camel:         camelCase(name.Name),
 								Lower:         strings.ToLower(name.Name),
-								Upper:         strings.ToUpper(name.Name),
+								Upper:         upperCase(name.Name),
 								AlternateName: alternate,
-								Value:         i,
+								Value:         value,
 								Valid:         valid,
+								Sentinel:      sentinel,
+								Tags:          tags,
 							},
 							TypeInfo: typeInfo{
 								Name:          iotaType,
 								Camel:         camelCase(iotaType),
 								Lower:         strings.ToLower(iotaType),
-								Upper:         strings.ToUpper(iotaType),
+								Upper:         upperCase(iotaType),
 								NameTypePairs: nameTPairsCopy,
 							},
 							Raw: raw{
@@ -232,9 +819,238 @@ func parseEnums(node *ast.File, typeComments map[string]string) ([]Enum, string,
 		}
 		return true
 	})
+	if hasDescription && !hasField(nameTPairs, descriptionField) {
+		nameTPairs = append(nameTPairs, nameTypePair{Name: descriptionField, Type: "string"})
+	}
+	if pinnedBlock {
+		enums, iotaIdx = rebasePinned(enums)
+	} else {
+		enums, iotaIdx = sortValues(enums, iotaIdx)
+	}
+	enums = applySentinel(enums)
 	return enums, iotaType, iotaIdx, nameTPairs
 }
 
+// directivePrefix starts the comment lines that configure generation, e.g. //goenums:invalid.
+const directivePrefix = "//goenums:"
+
+// invalidDirective declares the constant that is the invalid sentinel for the enum.
+const invalidDirective = "invalid"
+
+// hasDirective reports whether the comment group contains the //goenums:<name> directive.
+func hasDirective(doc *ast.CommentGroup, name string) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		directive, ok := strings.CutPrefix(c.Text, directivePrefix)
+		if ok && strings.TrimSpace(directive) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultDirective marks the default value of the enum, either on the enum
+// constant itself or on a constant assigned from one such as defaultStatus = active.
+const defaultDirective = "default"
+
+// defaultConstant returns the name of the enum constant with the default directive,
+// or assigned to the constant with it, if any. With filtered set the directives
+// naming constants that are not in enums are skipped, as they belong to the other
+// enums declared in the file.
+func defaultConstant(node *ast.File, enums []Enum, filtered bool) (string, bool) {
+	for _, decl := range node.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok || len(valueSpec.Names) == 0 {
+				continue
+			}
+			doc := valueSpec.Doc
+			if doc == nil && !gen.Lparen.IsValid() {
+				doc = gen.Doc
+			}
+			if !hasDirective(doc, defaultDirective) {
+				continue
+			}
+			name := valueSpec.Names[0].Name
+			if len(valueSpec.Values) == 1 {
+				if ident, ok := valueSpec.Values[0].(*ast.Ident); ok && ident.Name != "iota" {
+					name = ident.Name
+				}
+			}
+			if filtered && !slices.ContainsFunc(enums, func(e Enum) bool { return e.Info.Name == name }) {
+				continue
+			}
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// applyDefault marks the enum constant name as the default value.
+func applyDefault(enums []Enum, name string) ([]Enum, error) {
+	for i := range enums {
+		if enums[i].Info.Name == name {
+			enums[i].Info.Default = true
+			return enums, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s %s is not a constant of the enum", ErrInvalidDirective, defaultDirective, name)
+}
+
+// defaultEnum returns the enum constant marked as the default value, if any.
+func (rep EnumRepresentation) defaultEnum() (Enum, bool) {
+	for _, e := range rep.Enums {
+		if e.Info.Default {
+			return e, true
+		}
+	}
+	return Enum{}, false
+}
+
+// applySentinel makes an explicitly declared sentinel the only invalid constant,
+// replacing the inference from the word "invalid" in the comments.
+func applySentinel(enums []Enum) []Enum {
+	found := false
+	for _, e := range enums {
+		found = found || e.Info.Sentinel
+	}
+	if !found {
+		return enums
+	}
+	for i := range enums {
+		enums[i].Info.Valid = !enums[i].Info.Sentinel
+	}
+	return enums
+}
+
+// sentinel returns the constant declared as the invalid sentinel, if any.
+func (rep EnumRepresentation) sentinel() (Enum, bool) {
+	for _, e := range rep.Enums {
+		if e.Info.Sentinel {
+			return e, true
+		}
+	}
+	return Enum{}, false
+}
+
+// getDescription extracts a desc="..." directive from the value comment,
+// returning the remaining comment and the unquoted description.
+func getDescription(comment string) (string, string) {
+	parts := splitQuoted(strings.TrimSpace(comment), ' ')
+	for i, part := range parts {
+		value, ok := strings.CutPrefix(part, "desc=")
+		if !ok {
+			continue
+		}
+		description, err := strconv.Unquote(value)
+		if err != nil {
+			description = value
+		}
+		rest := append(parts[:i:i], parts[i+1:]...)
+		return strings.Join(rest, " "), description
+	}
+	return comment, ""
+}
+
+// setDescription sets the Description extra value, adding it when the type does not declare one.
+func setDescription(nameTPairs []nameTypePair, description string) []nameTypePair {
+	value := strconv.Quote(description)
+	for i := range nameTPairs {
+		if nameTPairs[i].Name == descriptionField {
+			nameTPairs[i].Value = value
+			return nameTPairs
+		}
+	}
+	return append(nameTPairs, nameTypePair{Name: descriptionField, Type: "string", Value: value})
+}
+
+func hasField(nameTPairs []nameTypePair, name string) bool {
+	for _, pair := range nameTPairs {
+		if pair.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// splitQuoted splits s around each sep that is not inside a double quoted string,
+// so quoted values may contain separators and arbitrary punctuation.
+func splitQuoted(s string, sep byte) []string {
+	var (
+		parts   []string
+		start   int
+		quoted  bool
+		escaped bool
+	)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case escaped:
+			escaped = false
+		case c == '\\' && quoted:
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case c == sep && !quoted:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// splitSchema splits the schema of the extra values around each comma that is
+// not inside brackets or parentheses, so the annotations after a type such as
+// Gravity[float64,min=0] stay with their field.
+func splitSchema(s string) []string {
+	var (
+		parts []string
+		start int
+		depth int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case ',':
+			if depth <= 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// stripQuoted removes the double quoted strings from s.
+func stripQuoted(s string) string {
+	var (
+		b       strings.Builder
+		quoted  bool
+		escaped bool
+	)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case escaped:
+			escaped = false
+		case c == '\\' && quoted:
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case !quoted:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
 func getTypeComment(valueSpec *ast.ValueSpec, typeComments map[string]string) string {
 	if valueSpec.Type != nil {
 		constantType := fmt.Sprintf("%s", valueSpec.Type)
@@ -253,7 +1069,9 @@ func getPackageName(node *ast.File) string {
 	return packageName
 }
 
-func getTypeComments(node *ast.File) map[string]string {
+// getTypeComments returns the field schema of each type declared in the file,
+// from the comment following the type.
+func getTypeComments(fset *token.FileSet, node *ast.File) map[string]string {
 	typeComments := make(map[string]string)
 	ast.Inspect(node, func(n ast.Node) bool {
 		decl, ok := n.(*ast.GenDecl)
@@ -266,8 +1084,7 @@ func getTypeComments(node *ast.File) map[string]string {
 				continue
 			}
 			if typeSpec.Comment != nil && len(typeSpec.Comment.List) > 0 {
-				comment := strings.TrimSpace(typeSpec.Comment.List[0].Text[2:])
-				typeComments[typeSpec.Name.Name] = comment
+				typeComments[typeSpec.Name.Name] = typeSchema(fset, node, typeSpec.Comment.List[0])
 			}
 		}
 		return true
@@ -275,14 +1092,86 @@ func getTypeComments(node *ast.File) map[string]string {
 	return typeComments
 }
 
-func getValues(comment string) []string {
-	values := strings.Split(comment, ",")
-	if len(values) > 1 {
-		for i, v := range values {
-			values[i] = strings.TrimSpace(v)
+// typeSchema returns the field schema in the comment following a type. Wide
+// schemas can span several lines, either in a /* */ comment with the fields
+// separated by commas or line breaks, or in // comments each ending with a
+// comma to continue the schema on the comment line below, e.g.
+//
+//	type planet int // Gravity[float64], RadiusKm[float64],
+//	// MassKg[float64], Moons[int]
+func typeSchema(fset *token.FileSet, node *ast.File, c *ast.Comment) string {
+	if text, ok := strings.CutPrefix(c.Text, "/*"); ok {
+		lines := strings.Split(strings.TrimSuffix(text, "*/"), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimPrefix(strings.TrimSpace(line), "*")
 		}
+		return joinSchema(lines)
 	}
-	return values
+	schema := strings.TrimSpace(c.Text[2:])
+	if !strings.HasSuffix(schema, ",") {
+		return schema
+	}
+	lines := []string{schema}
+	standalone := standaloneComments(fset, node)
+	for line := fset.Position(c.Pos()).Line + 1; strings.HasSuffix(lines[len(lines)-1], ","); line++ {
+		next, ok := standalone[line]
+		if !ok {
+			break
+		}
+		lines = append(lines, strings.TrimSpace(next.Text[2:]))
+	}
+	return joinSchema(lines)
+}
+
+// standaloneComments returns the // comments alone on their line by line.
+func standaloneComments(fset *token.FileSet, node *ast.File) map[int]*ast.Comment {
+	// the first position of the code on each line
+	code := make(map[int]token.Pos)
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n.(type) {
+		case nil, *ast.CommentGroup, *ast.Comment:
+			return false
+		}
+		line := fset.Position(n.Pos()).Line
+		if pos, ok := code[line]; !ok || n.Pos() < pos {
+			code[line] = n.Pos()
+		}
+		return true
+	})
+	comments := make(map[int]*ast.Comment)
+	for _, group := range node.Comments {
+		for _, c := range group.List {
+			line := fset.Position(c.Pos()).Line
+			if pos, ok := code[line]; (!ok || pos > c.Pos()) && strings.HasPrefix(c.Text, "//") {
+				comments[line] = c
+			}
+		}
+	}
+	return comments
+}
+
+// joinSchema joins the fields of a schema written over several lines into one,
+// separated by commas.
+func joinSchema(lines []string) string {
+	var fields []string
+	for _, line := range lines {
+		for _, field := range splitSchema(line) {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+func getValues(comment string) []string {
+	values := splitQuoted(comment, ',')
+	if len(values) > 1 {
+		for i, v := range values {
+			values[i] = strings.TrimSpace(v)
+		}
+	}
+	return values
 }
 
 func copyNameTPairs(nameTPairs []nameTypePair, values []string) []nameTypePair {
@@ -301,7 +1190,7 @@ func copyNameTPairs(nameTPairs []nameTypePair, values []string) []nameTypePair {
 func getAlternateName(comment string, name *ast.Ident, nameTPairs []nameTypePair) (string, string) {
 	// get value between the first space and the first comma
 	comment = strings.TrimLeft(comment, " ")
-	count := strings.Count(comment, " ")
+	count := len(splitQuoted(comment, ' ')) - 1
 	switch count {
 	case 0:
 		if comment == "" {
@@ -318,7 +1207,7 @@ func getAlternateName(comment string, name *ast.Ident, nameTPairs []nameTypePair
 		}
 		return comment, comment
 	case 1:
-		split := strings.Split(comment, " ")
+		split := splitQuoted(comment, ' ')
 		if len(split) == 2 {
 			if strings.Contains(split[0], "invalid") {
 				return split[1], split[1]
@@ -340,7 +1229,7 @@ func getComment(valueSpec *ast.ValueSpec) string {
 }
 
 func nameTPairsFromComments(iotaTypeComment string, nameTPairs []nameTypePair) []nameTypePair {
-	typeValues := strings.Split(iotaTypeComment, ",")
+	typeValues := splitSchema(iotaTypeComment)
 	for i, v := range typeValues {
 		if len(v) == 0 {
 			continue
@@ -348,28 +1237,21 @@ func nameTPairsFromComments(iotaTypeComment string, nameTPairs []nameTypePair) [
 		if v[0] == ' ' {
 			v = v[1:]
 		}
-		o := "["
-		c := "]"
-		if strings.Contains(v, "(") {
-			o = "("
-			c = ")"
-		}
-		if strings.Contains(v, " ") {
-			o = " "
-			c = " "
-		}
-		idx := strings.Index(v, o)
+		// the name ends at the first bracket or space, which selects the format
+		idx := strings.IndexAny(v, "[( ")
 		if idx == -1 {
 			continue
 		}
+		o := v[idx : idx+1]
+		c := map[string]string{"[": "]", "(": ")", " ": " "}[o]
 		name := v[:idx]
 		name = strings.TrimSpace(name)
 
-		endIndex := strings.Index(v, c)
+		endIndex := strings.LastIndex(v, c)
 		if o == " " {
 			endIndex = len(v)
 		}
-		typeName := v[strings.Index(v, o)+1 : endIndex]
+		typeName := v[idx+1 : endIndex]
 		nameTypePair := nameTypePair{Name: name, Type: typeName, Value: fmt.Sprintf("%d", i)}
 		nameTPairs = append(nameTPairs, nameTypePair)
 	}
@@ -377,83 +1259,179 @@ func nameTPairsFromComments(iotaTypeComment string, nameTPairs []nameTypePair) [
 	return nameTPairs
 }
 
+// iotaInfo returns the name and type of the first constant of the spec when it
+// is declared with iota, the comment of its type and the offset added to iota,
+// e.g. 1 for iota + 1.
 func iotaInfo(valueSpec *ast.ValueSpec, typeComments map[string]string) (string, string, string, int) {
 	var (
 		iotaName, iotaType, iotaTypeComment string
+		iotaIdx                             int
 	)
-	ident, ok := valueSpec.Values[0].(*ast.Ident)
-
-	iotaIdx := 0
-	if ok && ident.Name == "iota" {
-		iotaName = valueSpec.Names[0].Name
-		if valueSpec.Type != nil {
-			iotaType = fmt.Sprintf("%s", valueSpec.Type)
-			if comment, exists := typeComments[iotaType]; exists {
-				iotaTypeComment = comment
+	if !usesIota(valueSpec.Values[0]) {
+		return iotaName, iotaType, iotaTypeComment, iotaIdx
+	}
+	iotaName = valueSpec.Names[0].Name
+	if valueSpec.Type != nil {
+		iotaType = fmt.Sprintf("%s", valueSpec.Type)
+		if comment, exists := typeComments[iotaType]; exists {
+			iotaTypeComment = comment
+		}
+	}
+	if be, ok := valueSpec.Values[0].(*ast.BinaryExpr); ok && be.Op == token.ADD {
+		x, ok := be.X.(*ast.Ident)
+		y, isLit := be.Y.(*ast.BasicLit)
+		if ok && isLit && x.Name == "iota" {
+			if idx, err := strconv.Atoi(y.Value); err == nil {
+				iotaIdx = idx
 			}
 		}
 	}
-	if !ok {
-		if be, ok := valueSpec.Values[0].(*ast.BinaryExpr); ok {
-			if x, ok := be.X.(*ast.Ident); ok {
-				if x.Name == "iota" {
-					iotaName = valueSpec.Names[0].Name
-					if valueSpec.Type != nil {
-						iotaType = fmt.Sprintf("%s", valueSpec.Type)
-						if comment, exists := typeComments[iotaType]; exists {
-							iotaTypeComment = comment
-						}
-					}
-				}
+	return iotaName, iotaType, iotaTypeComment, iotaIdx
+}
+
+// iotaValue evaluates a constant integer expression of iota and literals, such
+// as iota * 2 or 1 << iota, with iota as the given value.
+func iotaValue(expr ast.Expr, iota int) (int, bool) {
+	v, ok := iotaConstant(expr, iota)
+	if !ok || v.Kind() != constant.Int {
+		return 0, false
+	}
+	i, exact := constant.Int64Val(v)
+	return int(i), exact
+}
+
+func iotaConstant(expr ast.Expr, iota int) (constant.Value, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return constant.MakeInt64(int64(iota)), e.Name == "iota"
+	case *ast.BasicLit:
+		if e.Kind != token.INT {
+			return nil, false
+		}
+		v := constant.MakeFromLiteral(e.Value, e.Kind, 0)
+		return v, v.Kind() == constant.Int
+	case *ast.ParenExpr:
+		return iotaConstant(e.X, iota)
+	case *ast.UnaryExpr:
+		x, ok := iotaConstant(e.X, iota)
+		if !ok || e.Op != token.ADD && e.Op != token.SUB && e.Op != token.XOR {
+			return nil, false
+		}
+		return constant.UnaryOp(e.Op, x, 0), true
+	case *ast.BinaryExpr:
+		x, ok := iotaConstant(e.X, iota)
+		if !ok {
+			return nil, false
+		}
+		y, ok := iotaConstant(e.Y, iota)
+		if !ok {
+			return nil, false
+		}
+		switch e.Op {
+		case token.SHL, token.SHR:
+			s, ok := constant.Uint64Val(y)
+			if !ok || s > 62 {
+				return nil, false
 			}
-			if y, ok := be.Y.(*ast.BasicLit); ok {
-				var err error
-				iotaIdx, err = strconv.Atoi(y.Value)
-				if err != nil {
-					iotaIdx = 0
-				}
+			return constant.Shift(x, e.Op, uint(s)), true
+		case token.QUO, token.REM:
+			if constant.Sign(y) == 0 {
+				return nil, false
+			}
+			if e.Op == token.QUO {
+				// integer division, as the operands are integers
+				return constant.BinaryOp(x, token.QUO_ASSIGN, y), true
 			}
+			return constant.BinaryOp(x, e.Op, y), true
+		case token.ADD, token.SUB, token.MUL, token.AND, token.OR, token.XOR, token.AND_NOT:
+			return constant.BinaryOp(x, e.Op, y), true
 		}
 	}
-	return iotaName, iotaType, iotaTypeComment, iotaIdx
+	return nil, false
+}
+
+// bufferPool holds the buffers the enum files are generated into before formatting.
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
 }
 
-func formatFile(filename string) error {
-	f, err := os.ReadFile(filename)
+// generate writes the enum file into a pooled buffer and returns the gofmt'd source.
+// Nothing is written to disk so a cancelled or failed generation leaves no partial output.
+func generate(ctx context.Context, rep EnumRepresentation, out output) ([]byte, error) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer bufferPool.Put(buf)
+	err := writeAll(ctx, buf, rep, out.sections)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return nil, fmt.Errorf("failed to generate enums: %w", err)
 	}
-	b, err := format.Source(f)
-	if err != nil {
-		return fmt.Errorf("failed to format file: %w", err)
+	if out.plain {
+		return bytes.Clone(buf.Bytes()), nil
 	}
-	err = os.WriteFile(filename, b, 0644)
+	b, err := format.Source(buf.Bytes())
 	if err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+		return nil, fmt.Errorf("failed to format file: %w", err)
 	}
-	return nil
+	return b, nil
+}
+
+// jsonv2Sections are the writers for the encoding/json/v2 file, which is only
+// built with GOEXPERIMENT=jsonv2 until the package is stable.
+var jsonv2Sections = []func(io.StringWriter, EnumRepresentation){
+	writeJSONv2BuildConstraint,
+	writeGeneratedComment,
+	writePackage,
+	writeJSONv2Imports,
+	writeJSONv2MarshalMethod,
+	writeJSONv2UnmarshalMethod,
 }
 
-func writeAll(w io.StringWriter, enum EnumRepresentation) {
-	writeGeneratedComment(w, enum)
-	writePackage(w, enum)
-	writeImports(w, enum)
-	writeWrapperType(w, enum)
-	writeAllMethod(w, enum)
-	writeParseMethod(w, enum)
-	writeExhaustiveMethod(w, enum)
-	writeIsValidMethod(w, enum)
-	writeJSONMarshalMethod(w, enum)
-	writeJSONUnmarshalMethod(w, enum)
-	writeScanMethod(w, enum)
-	writeValueMethod(w, enum)
-	writeCompileCheck(w, enum)
-	writeStringMethod(w, enum)
+// sharedSections are the writers for the helpers shared by every enum in a package.
+// The output does not depend on the enum so each generation rewrites identical content.
+var sharedSections = []func(io.StringWriter, EnumRepresentation){
+	writeSharedGeneratedComment,
+	writePackage,
+	writeSharedIntHelper,
+	writeSharedDistanceHelper,
+}
+
+// writeAll writes every section of the enum file, stopping early if the context is cancelled.
+func writeAll(ctx context.Context, w io.StringWriter, enum EnumRepresentation, sections []func(io.StringWriter, EnumRepresentation)) error {
+	for _, section := range sections {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		section(w, enum)
+	}
+	return nil
 }
 
 func writeScanMethod(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.hasHandler(HandlerSQL) {
+		return
+	}
 	w.WriteString("func (p *" + rep.TypeInfo.Camel + ") Scan(value any) error {\n")
-	w.WriteString("\tnewp, err := Parse" + rep.TypeInfo.Camel + "(value)\n")
+	if rep.EmptyDefault {
+		w.WriteString("\tif b, ok := value.([]byte); ok && len(b) == 0 {\n")
+		w.WriteString("\t\tvalue = nil\n")
+		w.WriteString("\t}\n")
+		w.WriteString("\tif value == nil || value == \"\" {\n")
+		w.WriteString("\t\t*p = " + rep.emptyValue() + "\n")
+		w.WriteString("\t\treturn nil\n")
+		w.WriteString("\t}\n")
+	}
+	if rep.SQLInt {
+		// some drivers return numeric columns as text
+		w.WriteString("\tif b, ok := value.([]byte); ok {\n")
+		w.WriteString("\t\tvalue = string(b)\n")
+		w.WriteString("\t}\n")
+		w.WriteString("\tif s, ok := value.(string); ok {\n")
+		w.WriteString("\t\tif i, err := strconv.ParseInt(s, 10, 64); err == nil {\n")
+		w.WriteString("\t\t\tvalue = i\n")
+		w.WriteString("\t\t}\n")
+		w.WriteString("\t}\n")
+	}
+	w.WriteString("\tnewp, err := " + rep.unmarshalFunc() + "(value)\n")
 	w.WriteString("\tif err != nil {\n")
 	w.WriteString("\t\treturn err\n")
 	w.WriteString("\t}\n")
@@ -462,9 +1440,75 @@ func writeScanMethod(w io.StringWriter, rep EnumRepresentation) {
 	w.WriteString("}\n\n")
 }
 
+func writeSQLConstraint(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.hasHandler(HandlerSQL) {
+		return
+	}
+	var values []string
+	for _, info := range rep.Enums {
+		if !info.Info.Valid {
+			continue
+		}
+		if rep.SQLInt {
+			values = append(values, strconv.Itoa(info.Info.Value+rep.TypeInfo.Index))
+			continue
+		}
+		name := info.Info.AlternateName
+		if v, ok := info.tagValue("db"); ok {
+			name = v
+		}
+		values = append(values, "'"+strings.ReplaceAll(name, "'", "''")+"'")
+	}
+	w.WriteString("// " + rep.TypeInfo.Camel + "SQLValues is the comma separated list of valid " + rep.TypeInfo.Camel + " values as stored by Value.\n")
+	w.WriteString("const " + rep.TypeInfo.Camel + "SQLValues = " + strconv.Quote(strings.Join(values, ", ")) + "\n\n")
+	w.WriteString("// " + rep.TypeInfo.Camel + "CheckConstraint returns a CHECK constraint restricting col to the valid " + rep.TypeInfo.Camel + " values.\n")
+	w.WriteString("func " + rep.TypeInfo.Camel + "CheckConstraint(col string) string {\n")
+	w.WriteString("\treturn \"CHECK (\" + col + \" IN (\" + " + rep.TypeInfo.Camel + "SQLValues + \"))\"\n")
+	w.WriteString("}\n\n")
+}
+
+func writePgtypeMethods(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.Pgx || !rep.hasHandler(HandlerSQL) {
+		return
+	}
+	if rep.SQLInt {
+		w.WriteString("func (p *" + rep.TypeInfo.Camel + ") ScanInt64(v pgtype.Int8) error {\n")
+		w.WriteString("\tif !v.Valid {\n")
+		w.WriteString("\t\t*p = " + rep.emptyValue() + "\n")
+		w.WriteString("\t\treturn nil\n")
+		w.WriteString("\t}\n")
+		w.WriteString("\treturn p.Scan(v.Int64)\n")
+		w.WriteString("}\n\n")
+		w.WriteString("func (p " + rep.TypeInfo.Camel + ") Int64Value() (pgtype.Int8, error) {\n")
+		w.WriteString("\treturn pgtype.Int8{Int64: int64(p." + rep.TypeInfo.Name + "), Valid: true}, nil\n")
+		w.WriteString("}\n\n")
+		return
+	}
+	w.WriteString("func (p *" + rep.TypeInfo.Camel + ") ScanText(v pgtype.Text) error {\n")
+	w.WriteString("\tif !v.Valid {\n")
+	w.WriteString("\t\t*p = " + rep.emptyValue() + "\n")
+	w.WriteString("\t\treturn nil\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn p.Scan(v.String)\n")
+	w.WriteString("}\n\n")
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") TextValue() (pgtype.Text, error) {\n")
+	name := writeMarshaledName(w, rep, "db", "pgtype.Text{}, ")
+	w.WriteString("\treturn pgtype.Text{String: " + name + ", Valid: true}, nil\n")
+	w.WriteString("}\n\n")
+}
+
 func writeValueMethod(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.hasHandler(HandlerSQL) {
+		return
+	}
 	w.WriteString("func (p " + rep.TypeInfo.Camel + ") Value() (driver.Value, error) {\n")
-	w.WriteString("\treturn p.String(), nil\n")
+	if rep.SQLInt {
+		w.WriteString("\treturn int64(p." + rep.TypeInfo.Name + "), nil\n")
+		w.WriteString("}\n\n")
+		return
+	}
+	name := writeMarshaledName(w, rep, "db", "nil, ")
+	w.WriteString("\treturn " + name + ", nil\n")
 	w.WriteString("}\n\n")
 }
 
@@ -472,44 +1516,186 @@ func writeGeneratedComment(w io.StringWriter, rep EnumRepresentation) {
 	w.WriteString("// Code generated by goenums. DO NOT EDIT.\n")
 	w.WriteString("// This file was generated by github.com/zarldev/goenums \n")
 	w.WriteString("// using the command:\n")
-	w.WriteString("// goenums ")
-	if rep.Failfast {
-		w.WriteString("-f ")
+	w.WriteString("// " + rep.command() + "\n")
+	w.WriteString(checksumPrefix + rep.TypeInfo.Checksum + "\n")
+	writeHeaderDetails(w, rep.Header)
+	if rep.Coverage {
+		w.WriteString(coverageIgnore + "\n")
+	}
+	w.WriteString("\n")
+}
+
+// command returns the goenums command line that generates the enum.
+func (rep EnumRepresentation) command() string {
+	args := append(append([]string{"goenums"}, rep.Config.args()...), rep.TypeInfo.Filename)
+	for i, arg := range args {
+		args[i] = quoteArg(arg)
+	}
+	return strings.Join(args, " ")
+}
+
+// quoteArg single quotes the argument for the shell when it is empty or holds spaces,
+// quotes or other characters the shell would interpret, so the command runs as written.
+func quoteArg(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n\"'\\$`|&;<>()*?[]#~!{}") {
+		return arg
 	}
-	w.WriteString(rep.TypeInfo.Filename + "\n")
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+func writeSharedGeneratedComment(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("// Code generated by goenums. DO NOT EDIT.\n")
+	w.WriteString("// This file was generated by github.com/zarldev/goenums \n")
+	w.WriteString("// and holds the helpers shared by every enum generated with -shared in this package.\n")
 	w.WriteString("\n")
 }
 
+// sharedIntTypes are the integer types accepted by the generated Parse functions.
+var sharedIntTypes = []string{"int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64"}
+
+func writeSharedIntHelper(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("// enumsInt converts any of the builtin integer types to an int.\n")
+	w.WriteString("func enumsInt(a any) (int, bool) {\n")
+	w.WriteString("\tswitch v := a.(type) {\n")
+	for _, t := range sharedIntTypes {
+		w.WriteString("\tcase " + t + ":\n")
+		if t == "int" {
+			w.WriteString("\t\treturn v, true\n")
+			continue
+		}
+		w.WriteString("\t\treturn int(v), true\n")
+	}
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn 0, false\n")
+	w.WriteString("}\n")
+}
+
+func writeSharedDistanceHelper(w io.StringWriter, rep EnumRepresentation) {
+	writeDistanceFunc(w, "enumsDistance")
+}
+
+// distanceFunc returns the name of the edit distance helper used by the enum.
+func (rep EnumRepresentation) distanceFunc() string {
+	if rep.shared() {
+		return "enumsDistance"
+	}
+	return rep.TypeInfo.Name + "Distance"
+}
+
+// writeDistanceFunc writes a Levenshtein distance function over runes.
+func writeDistanceFunc(w io.StringWriter, name string) {
+	w.WriteString("\n// " + name + " returns the Levenshtein distance between a and b.\n")
+	w.WriteString("func " + name + "(a, b string) int {\n")
+	w.WriteString("\tra, rb := []rune(a), []rune(b)\n")
+	w.WriteString("\tprev := make([]int, len(rb)+1)\n")
+	w.WriteString("\tfor j := range prev {\n")
+	w.WriteString("\t\tprev[j] = j\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\tfor i := 1; i <= len(ra); i++ {\n")
+	w.WriteString("\t\tcurr := make([]int, len(rb)+1)\n")
+	w.WriteString("\t\tcurr[0] = i\n")
+	w.WriteString("\t\tfor j := 1; j <= len(rb); j++ {\n")
+	w.WriteString("\t\t\tcurr[j] = prev[j-1]\n")
+	w.WriteString("\t\t\tif ra[i-1] != rb[j-1] {\n")
+	w.WriteString("\t\t\t\tcurr[j]++\n")
+	w.WriteString("\t\t\t}\n")
+	w.WriteString("\t\t\tif d := prev[j] + 1; d < curr[j] {\n")
+	w.WriteString("\t\t\t\tcurr[j] = d\n")
+	w.WriteString("\t\t\t}\n")
+	w.WriteString("\t\t\tif d := curr[j-1] + 1; d < curr[j] {\n")
+	w.WriteString("\t\t\t\tcurr[j] = d\n")
+	w.WriteString("\t\t\t}\n")
+	w.WriteString("\t\t}\n")
+	w.WriteString("\t\tprev = curr\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn prev[len(rb)]\n")
+	w.WriteString("}\n")
+}
+
+// suggestDistance is the largest edit distance the generated Parse error
+// suggests a value for in failfast mode.
+const suggestDistance = 2
+
+func writeClosestMethod(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.Suggest {
+		return
+	}
+	w.WriteString("// Closest" + rep.TypeInfo.Camel + " returns the valid " + rep.TypeInfo.Camel + " whose name is closest to s, ignoring case,\n")
+	w.WriteString("// and the edit distance to it, for \"did you mean\" hints in error messages.\n")
+	w.WriteString("func Closest" + rep.TypeInfo.Camel + "(s string) (" + rep.TypeInfo.Camel + ", int) {\n")
+	w.WriteString("\tclosest, distance := invalid" + rep.TypeInfo.Camel + ", -1\n")
+	w.WriteString("\ts = strings.ToLower(s)\n")
+	w.WriteString("\tfor _, v := range " + rep.containerRef() + ".All() {\n")
+	w.WriteString("\t\tif d := " + rep.distanceFunc() + "(s, strings.ToLower(v.String())); distance < 0 || d < distance {\n")
+	w.WriteString("\t\t\tclosest, distance = v, d\n")
+	w.WriteString("\t\t}\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn closest, distance\n")
+	w.WriteString("}\n\n")
+	if !rep.shared() {
+		writeDistanceFunc(w, rep.distanceFunc())
+		w.WriteString("\n")
+	}
+}
+
 func writeStringMethod(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.tabled() {
+		writeStringSwitch(w, rep.TypeInfo.Name, rep.TypeInfo.Lower, rep.Enums, func(e Enum) string { return e.Info.AlternateName })
+		return
+	}
 	index, nameConst := generateIndexAndNameRun(rep)
 	w.WriteString("const " + nameConst + "\n")
 	w.WriteString("var " + index + "\n")
 	w.WriteString("func (i " + rep.TypeInfo.Name + ") String() string {\n")
-	w.WriteString("\tif i < 0 || i >= " + rep.TypeInfo.Name + "(len(_" + rep.TypeInfo.Lower + "_index)-1) {\n")
+	outOfRange := "i < 0 || i >= " + rep.TypeInfo.Name + "(len(_" + rep.TypeInfo.Lower + "_index)-1)"
+	if rep.sparse() {
+		outOfRange += " || _" + rep.TypeInfo.Lower + "_index[i] == _" + rep.TypeInfo.Lower + "_index[i+1]"
+	}
+	w.WriteString("\tif " + outOfRange + " {\n")
 	w.WriteString("\t\treturn \"" + rep.TypeInfo.Lower + "(\" + (strconv.FormatInt(int64(i), 10) + \")\")\n")
 	w.WriteString("\t}\n")
 	w.WriteString("\treturn _" + rep.TypeInfo.Lower + "_name[_" + rep.TypeInfo.Lower + "_index[i]:_" + rep.TypeInfo.Lower + "_index[i+1]]\n")
 	w.WriteString("}\n")
 }
 
+// writeStringSwitch writes the String method of the base type typ returning the
+// name of each constant from a switch, for values too far apart for tables, and
+// prefix(value) for any other value.
+func writeStringSwitch(w io.StringWriter, typ, prefix string, enums []Enum, name func(Enum) string) {
+	w.WriteString("func (i " + typ + ") String() string {\n")
+	w.WriteString("\tswitch i {\n")
+	for _, e := range enums {
+		w.WriteString("\tcase " + e.Info.Name + ":\n")
+		w.WriteString("\t\treturn " + strconv.Quote(name(e)) + "\n")
+	}
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn \"" + prefix + "(\" + strconv.FormatInt(int64(i), 10) + \")\"\n")
+	w.WriteString("}\n")
+}
+
 func generateIndexAndNameRun(rep EnumRepresentation) (string, string) {
 	b := new(bytes.Buffer)
-	indexes := make([]int, len(rep.Enums))
-	for i := range rep.Enums {
-		b.WriteString(rep.Enums[i].Info.AlternateName)
-		indexes[i] = b.Len()
+	var indexes []int
+	for _, e := range rep.valueSlots() {
+		if e != nil {
+			b.WriteString(e.Info.AlternateName)
+		}
+		indexes = append(indexes, b.Len())
 	}
 	nameConst := fmt.Sprintf("_%s_name = %q\n", rep.TypeInfo.Lower, b.String())
 	b.Reset()
-	fmt.Fprintf(b, " _%s_index = [...]uint16{0", rep.TypeInfo.Lower)
+	indexType := "uint16"
+	if len(indexes) > 0 && indexes[len(indexes)-1] > math.MaxUint16 {
+		indexType = "uint32"
+	}
+	fmt.Fprintf(b, " _%s_index = [...]%s{0", rep.TypeInfo.Lower, indexType)
 	for range rep.TypeInfo.Index {
 		fmt.Fprintf(b, ", %d", 0)
 	}
+	// the offsets follow the leading 0, even when a blank first constant makes
+	// the first of them 0 too
 	for _, i := range indexes {
-		if i > 0 {
-			fmt.Fprintf(b, ", ")
-		}
-		fmt.Fprintf(b, "%d", i)
+		fmt.Fprintf(b, ", %d", i)
 	}
 	fmt.Fprintf(b, "}\n")
 	return b.String(), nameConst
@@ -529,15 +1715,276 @@ func writeCompileCheck(w io.StringWriter, rep EnumRepresentation) {
 }
 
 func writeJSONMarshalMethod(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.hasHandler(HandlerJSON) {
+		return
+	}
+	writeAppendJSONMethod(w, rep)
 	w.WriteString("func (p " + rep.TypeInfo.Camel + ") MarshalJSON() ([]byte, error) {\n")
-	w.WriteString("\treturn []byte(`\"`+p.String() + `\"`), nil\n")
+	if rep.MarshalInvalid == MarshalInvalidError {
+		w.WriteString("\tif _, err := p.marshalName(\"\"); err != nil {\n")
+		w.WriteString("\t\treturn nil, err\n")
+		w.WriteString("\t}\n")
+	}
+	w.WriteString("\treturn p.AppendJSON(nil), nil\n")
+	w.WriteString("}\n\n")
+}
+
+// quoteRaw returns s as a raw string literal, or an interpreted one when s
+// cannot be backquoted.
+func quoteRaw(s string) string {
+	if strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+// jsonQuote returns s as a JSON string, escaping only what JSON requires so
+// names holding HTML characters are written as they are.
+func jsonQuote(s string) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// writeAppendJSONMethod writes AppendJSON appending the quoted JSON names of
+// the declared values from a table, so marshaling them does not allocate.
+func writeAppendJSONMethod(w io.StringWriter, rep EnumRepresentation) {
+	table := "_" + rep.TypeInfo.Lower + "_json"
+	tabled := rep.tabled()
+	if tabled {
+		w.WriteString("// " + table + " holds the quoted JSON name of each declared " + rep.TypeInfo.Camel + ".\n")
+		w.WriteString("var " + table + " = [...]string{\n")
+		for _, e := range rep.valueSlots() {
+			if e == nil {
+				w.WriteString("\t\"\",\n")
+				continue
+			}
+			w.WriteString("\t" + quoteRaw(jsonQuote(e.jsonName())) + ",\n")
+		}
+		w.WriteString("}\n\n")
+	}
+	w.WriteString("// AppendJSON appends the JSON encoding of the " + rep.TypeInfo.Camel + " written by MarshalJSON to dst.\n")
+	if rep.MarshalInvalid == MarshalInvalidError {
+		w.WriteString("// Values MarshalJSON rejects as invalid are appended by name.\n")
+	}
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") AppendJSON(dst []byte) []byte {\n")
+	switch {
+	case rep.EmptyInvalid:
+		w.WriteString("\tif !p.IsValid() {\n")
+		w.WriteString("\t\treturn append(dst, `\"\"`...)\n")
+		w.WriteString("\t}\n")
+	case rep.MarshalInvalid == MarshalInvalidNumber:
+		w.WriteString("\tif !p.IsValid() {\n")
+		w.WriteString("\t\tdst = strconv.AppendInt(append(dst, '\"'), int64(p." + rep.TypeInfo.Name + "), 10)\n")
+		w.WriteString("\t\treturn append(dst, '\"')\n")
+		w.WriteString("\t}\n")
+	case rep.InvalidPlaceholder != "":
+		w.WriteString("\tif !p.IsValid() {\n")
+		w.WriteString("\t\treturn append(dst, " + quoteRaw(jsonQuote(rep.InvalidPlaceholder)) + "...)\n")
+		w.WriteString("\t}\n")
+	}
+	if !tabled {
+		w.WriteString("\tswitch p." + rep.TypeInfo.Name + " {\n")
+		for _, e := range rep.Enums {
+			w.WriteString("\tcase " + e.Info.Name + ":\n")
+			w.WriteString("\t\treturn append(dst, " + quoteRaw(jsonQuote(e.jsonName())) + "...)\n")
+		}
+		w.WriteString("\t}\n")
+		w.WriteString("\treturn append(append(append(dst, '\"'), p.String()...), '\"')\n")
+		w.WriteString("}\n\n")
+		return
+	}
+	index := "int(p." + rep.TypeInfo.Name + ")"
+	if rep.TypeInfo.Index != 0 {
+		index += " - " + strconv.Itoa(rep.TypeInfo.Index)
+	}
+	inRange := "i >= 0 && i < len(" + table + ")"
+	if rep.sparse() {
+		inRange += " && " + table + "[i] != \"\""
+	}
+	w.WriteString("\tif i := " + index + "; " + inRange + " {\n")
+	w.WriteString("\t\treturn append(dst, " + table + "[i]...)\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn append(append(append(dst, '\"'), p.String()...), '\"')\n")
 	w.WriteString("}\n\n")
 }
 
 func writeJSONUnmarshalMethod(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.hasHandler(HandlerJSON) {
+		return
+	}
 	w.WriteString("func (p *" + rep.TypeInfo.Camel + ") UnmarshalJSON(b []byte) error {\n")
-	w.WriteString("b = bytes.Trim(bytes.Trim(b, `\"`), ` `)\n")
-	w.WriteString("\tnewp, err := Parse" + rep.TypeInfo.Camel + "(b)\n")
+	if rep.escapesJSON() {
+		w.WriteString("\tif s := \"\"; bytes.IndexByte(b, '\\\\') >= 0 && json.Unmarshal(b, &s) == nil {\n")
+		w.WriteString("\t\tb = bytes.Trim([]byte(s), ` `)\n")
+		w.WriteString("\t} else {\n")
+		w.WriteString("\t\tb = bytes.Trim(bytes.Trim(b, `\"`), ` `)\n")
+		w.WriteString("\t}\n")
+	} else {
+		w.WriteString("b = bytes.Trim(bytes.Trim(b, `\"`), ` `)\n")
+	}
+	if rep.EmptyInvalid || rep.EmptyDefault {
+		w.WriteString("\tif len(b) == 0 || string(b) == \"null\" {\n")
+		w.WriteString("\t\t*p = " + rep.emptyValue() + "\n")
+		w.WriteString("\t\treturn nil\n")
+		w.WriteString("\t}\n")
+	}
+	w.WriteString("\tnewp, err := " + rep.unmarshalFunc() + "(b)\n")
+	w.WriteString("\tif err != nil {\n")
+	w.WriteString("\t\treturn err\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\t*p = newp\n")
+	w.WriteString("\treturn nil\n")
+	w.WriteString("}\n\n")
+}
+
+func writeAccessorMethods(w io.StringWriter, rep EnumRepresentation) {
+	for _, pair := range rep.TypeInfo.NameTypePairs {
+		if !pair.hasAccessor() {
+			continue
+		}
+		if pair.Unit != "" {
+			w.WriteString("// " + pair.Name + " returns the " + pair.Name + " in " + pair.Unit + ".\n")
+		}
+		w.WriteString("func (p " + rep.TypeInfo.Camel + ") " + pair.Name + "() " + pair.Type + " {\n")
+		w.WriteString("\treturn p." + pair.Field() + "\n")
+		w.WriteString("}\n\n")
+	}
+}
+
+func writeFormatMethod(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") Format(f fmt.State, verb rune) {\n")
+	w.WriteString("\tswitch verb {\n")
+	w.WriteString("\tcase 'd':\n")
+	w.WriteString("\t\tfmt.Fprintf(f, fmt.FormatString(f, verb), p." + rep.TypeInfo.Name + ")\n")
+	w.WriteString("\tcase 'q':\n")
+	w.WriteString("\t\tfmt.Fprintf(f, fmt.FormatString(f, verb), p.String())\n")
+	w.WriteString("\tcase 'v':\n")
+	w.WriteString("\t\tif f.Flag('#') {\n")
+	w.WriteString("\t\t\tfmt.Fprint(f, p.GoString())\n")
+	w.WriteString("\t\t\treturn\n")
+	w.WriteString("\t\t}\n")
+	if len(rep.TypeInfo.NameTypePairs) > 0 {
+		w.WriteString("\t\tif f.Flag('+') {\n")
+		format := make([]string, len(rep.TypeInfo.NameTypePairs))
+		args := make([]string, len(rep.TypeInfo.NameTypePairs))
+		for i, pair := range rep.TypeInfo.NameTypePairs {
+			format[i] = pair.Name + ": %v"
+			args[i] = "p." + pair.Field()
+		}
+		w.WriteString("\t\t\tfmt.Fprintf(f, \"%s{" + strings.Join(format, ", ") + "}\", p.String(), " + strings.Join(args, ", ") + ")\n")
+		w.WriteString("\t\t\treturn\n")
+		w.WriteString("\t\t}\n")
+	}
+	w.WriteString("\t\tfmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())\n")
+	w.WriteString("\tdefault:\n")
+	w.WriteString("\t\tfmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())\n")
+	w.WriteString("\t}\n")
+	w.WriteString("}\n\n")
+}
+
+func writeCacheKeyMethod(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("// CacheKey returns a key for the " + rep.TypeInfo.Camel + " namespaced by prefix, built from its\n")
+	w.WriteString("// canonical name so keys remain stable while the names do.\n")
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") CacheKey(prefix string) string {\n")
+	w.WriteString("\treturn prefix + \":\" + p.String()\n")
+	w.WriteString("}\n\n")
+}
+
+func writeGoStringMethod(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") GoString() string {\n")
+	w.WriteString("\tswitch p." + rep.TypeInfo.Name + " {\n")
+	for _, info := range rep.Enums {
+		if info.Info.Valid {
+			w.WriteString("\tcase " + info.Info.Name + ":\n")
+			w.WriteString("\t\treturn \"" + rep.containerRef() + "." + info.Info.Upper + "\"\n")
+		}
+	}
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn \"" + rep.TypeInfo.Camel + "{" + rep.TypeInfo.Name + ": \" + strconv.FormatInt(int64(p." + rep.TypeInfo.Name + "), 10) + \"}\"\n")
+	w.WriteString("}\n\n")
+}
+
+func writeJSONv2BuildConstraint(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("//go:build goexperiment.jsonv2\n\n")
+}
+
+func writeJSONv2Imports(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("import \"encoding/json/jsontext\"\n\n")
+}
+
+func writeJSONv2MarshalMethod(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") MarshalJSONTo(enc *jsontext.Encoder) error {\n")
+	if rep.EmptyInvalid {
+		w.WriteString("\tif !p.IsValid() {\n")
+		w.WriteString("\t\treturn enc.WriteToken(jsontext.String(\"\"))\n")
+		w.WriteString("\t}\n")
+	}
+	name := writeMarshaledName(w, rep, "json", "")
+	w.WriteString("\treturn enc.WriteToken(jsontext.String(" + name + "))\n")
+	w.WriteString("}\n\n")
+}
+
+func writeJSONv2UnmarshalMethod(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("func (p *" + rep.TypeInfo.Camel + ") UnmarshalJSONFrom(dec *jsontext.Decoder) error {\n")
+	w.WriteString("\ttok, err := dec.ReadToken()\n")
+	w.WriteString("\tif err != nil {\n")
+	w.WriteString("\t\treturn err\n")
+	w.WriteString("\t}\n")
+	if rep.EmptyInvalid || rep.EmptyDefault {
+		w.WriteString("\tif tok.Kind() == 'n' || tok.String() == \"\" {\n")
+		w.WriteString("\t\t*p = " + rep.emptyValue() + "\n")
+		w.WriteString("\t\treturn nil\n")
+		w.WriteString("\t}\n")
+	}
+	w.WriteString("\tnewp, err := " + rep.unmarshalFunc() + "(tok.String())\n")
+	w.WriteString("\tif err != nil {\n")
+	w.WriteString("\t\treturn err\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\t*p = newp\n")
+	w.WriteString("\treturn nil\n")
+	w.WriteString("}\n\n")
+}
+
+func writeYAMLMarshalMethod(w io.StringWriter, rep EnumRepresentation) {
+	if rep.yamlLibrary() == "" {
+		return
+	}
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") MarshalYAML() (any, error) {\n")
+	name := writeMarshaledName(w, rep, "yaml", "nil, ")
+	w.WriteString("\treturn " + name + ", nil\n")
+	w.WriteString("}\n\n")
+}
+
+func writeYAMLUnmarshalMethod(w io.StringWriter, rep EnumRepresentation) {
+	switch rep.yamlLibrary() {
+	case YAMLv2:
+		w.WriteString("func (p *" + rep.TypeInfo.Camel + ") UnmarshalYAML(unmarshal func(any) error) error {\n")
+		w.WriteString("\tvar s string\n")
+		w.WriteString("\tif err := unmarshal(&s); err != nil {\n")
+		w.WriteString("\t\treturn err\n")
+		w.WriteString("\t}\n")
+		if rep.EmptyDefault {
+			w.WriteString("\tif s == \"\" {\n")
+			w.WriteString("\t\t*p = " + rep.emptyValue() + "\n")
+			w.WriteString("\t\treturn nil\n")
+			w.WriteString("\t}\n")
+		}
+		w.WriteString("\tnewp, err := " + rep.unmarshalFunc() + "(s)\n")
+	case YAMLv3:
+		w.WriteString("func (p *" + rep.TypeInfo.Camel + ") UnmarshalYAML(node *yaml.Node) error {\n")
+		if rep.EmptyDefault {
+			w.WriteString("\tif node.Value == \"\" || node.Tag == \"!!null\" {\n")
+			w.WriteString("\t\t*p = " + rep.emptyValue() + "\n")
+			w.WriteString("\t\treturn nil\n")
+			w.WriteString("\t}\n")
+		}
+		w.WriteString("\tnewp, err := " + rep.unmarshalFunc() + "(node.Value)\n")
+	default:
+		return
+	}
 	w.WriteString("\tif err != nil {\n")
 	w.WriteString("\t\treturn err\n")
 	w.WriteString("\t}\n")
@@ -550,7 +1997,7 @@ func writeIsValidMethod(w io.StringWriter, rep EnumRepresentation) {
 	w.WriteString("var valid" + rep.TypeInfo.PluralCamel + " = map[" + rep.TypeInfo.Camel + "]bool{\n")
 	for _, info := range rep.Enums {
 		if info.Info.Valid {
-			w.WriteString("\t" + rep.TypeInfo.PluralCamel + "." + info.Info.Upper + ": true,\n")
+			w.WriteString("\t" + rep.TypeInfo.Container + "." + info.Info.Upper + ": true,\n")
 		}
 	}
 	w.WriteString("}\n\n")
@@ -559,9 +2006,18 @@ func writeIsValidMethod(w io.StringWriter, rep EnumRepresentation) {
 	w.WriteString("}\n\n")
 }
 
+// writeExhaustiveMethod writes Exhaustive<Type>s and Exhaustive<Type>sIncludingInvalid,
+// both named from the type with an s appended like the first always was, so one
+// name is found from the other.
 func writeExhaustiveMethod(w io.StringWriter, rep EnumRepresentation) {
 	w.WriteString("func Exhaustive" + rep.TypeInfo.Camel + "s(f func(" + rep.TypeInfo.Camel + ")) {\n")
-	w.WriteString("\tfor _, p := range " + rep.TypeInfo.PluralCamel + ".All() {\n")
+	w.WriteString("\tfor _, p := range " + rep.TypeInfo.Container + ".All() {\n")
+	w.WriteString("\t\tf(p)\n")
+	w.WriteString("\t}\n")
+	w.WriteString("}\n\n")
+	w.WriteString("// Exhaustive" + rep.TypeInfo.Camel + "sIncludingInvalid calls f with every declared " + rep.TypeInfo.Camel + ", including those marked invalid.\n")
+	w.WriteString("func Exhaustive" + rep.TypeInfo.Camel + "sIncludingInvalid(f func(" + rep.TypeInfo.Camel + ")) {\n")
+	w.WriteString("\tfor _, p := range " + rep.TypeInfo.Container + ".AllWithInvalid() {\n")
 	w.WriteString("\t\tf(p)\n")
 	w.WriteString("\t}\n")
 	w.WriteString("}\n\n")
@@ -572,38 +2028,108 @@ func writePackage(w io.StringWriter, rep EnumRepresentation) {
 }
 
 func writeImports(w io.StringWriter, rep EnumRepresentation) {
+	std, thirdParty := rep.imports()
 	w.WriteString("import (\n")
-	w.WriteString("\t\"fmt\"\n")
-	w.WriteString("\t\"strconv\"\n")
-	w.WriteString("\t\"bytes\"\n")
-	w.WriteString("\t\"database/sql/driver\"\n")
+	for _, imp := range std {
+		w.WriteString("\t" + strconv.Quote(imp) + "\n")
+	}
+	if len(std) > 0 && len(thirdParty) > 0 {
+		w.WriteString("\n")
+	}
+	for _, imp := range thirdParty {
+		w.WriteString("\t" + strconv.Quote(imp) + "\n")
+	}
+	w.WriteString(")\n\n")
+}
+
+// imports returns the sorted, deduplicated standard library and third party
+// packages imported by the enum file, so the import block is the same on every run.
+func (rep EnumRepresentation) imports() ([]string, []string) {
+	all := []string{"fmt", "strconv"}
+	if rep.hasHandler(HandlerJSON) {
+		all = append(all, "bytes")
+		if rep.escapesJSON() {
+			all = append(all, "encoding/json")
+		}
+	}
+	if rep.hasHandler(HandlerSQL) {
+		all = append(all, "database/sql/driver")
+	}
+	if rep.yamlLibrary() == YAMLv3 {
+		all = append(all, "gopkg.in/yaml.v3")
+	}
+	if rep.Pgx && rep.hasHandler(HandlerSQL) {
+		all = append(all, "github.com/jackc/pgx/v5/pgtype")
+	}
+	if rep.Suggest || rep.Insensitive {
+		all = append(all, "strings")
+	}
+	if rep.FloatInput == FloatInputRound {
+		all = append(all, "math")
+	}
 	for _, pair := range rep.TypeInfo.NameTypePairs {
-		if strings.Contains(pair.Type, ".") {
-			pkg := strings.Split(pair.Type, ".")[0]
-			w.WriteString("\t\"" + pkg + "\"\n")
+		if pkg, _, ok := strings.Cut(pair.Type, "."); ok {
+			all = append(all, strings.TrimLeft(pkg, "*[]"))
 		}
 	}
-	w.WriteString(")\n\n")
+	slices.Sort(all)
+	all = slices.Compact(all)
+	var std, thirdParty []string
+	for _, imp := range all {
+		first, _, _ := strings.Cut(imp, "/")
+		if strings.Contains(first, ".") {
+			thirdParty = append(thirdParty, imp)
+			continue
+		}
+		std = append(std, imp)
+	}
+	return std, thirdParty
 }
 
+// compactContainerThreshold is the number of values above which each container
+// entry is written on a single line, which formats several times faster for
+// generated lists such as countries or currencies.
+const compactContainerThreshold = 256
+
 func writeWrapperType(w io.StringWriter, rep EnumRepresentation) {
 	w.WriteString("type " + rep.TypeInfo.Camel + " struct {\n")
 	w.WriteString(rep.TypeInfo.Name + "\n")
 	for _, pair := range rep.TypeInfo.NameTypePairs {
-		w.WriteString("\t" + pair.Name + " " + pair.Type + "\n")
+		if pair.Unit != "" {
+			w.WriteString("\t// " + pair.Field() + " is in " + pair.Unit + ".\n")
+		}
+		w.WriteString("\t" + pair.Field() + " " + pair.Type + "\n")
 	}
 	w.WriteString("}\n\n")
 	w.WriteString("type " + rep.TypeInfo.Lower + "Container struct {\n")
 	for _, info := range rep.Enums {
+		w.WriteString("\t// " + info.Info.Upper + " is " + strconv.Quote(info.Info.AlternateName) + " with the value " + strconv.Itoa(info.Info.Value+rep.TypeInfo.Index))
+		if !info.Info.Valid {
+			w.WriteString(", marked invalid")
+		}
+		w.WriteString(".\n")
 		w.WriteString("\t" + info.Info.Upper + " " + info.TypeInfo.Camel + "\n")
 	}
 	w.WriteString("}\n\n")
-	w.WriteString("var " + rep.TypeInfo.PluralCamel + " = " + rep.TypeInfo.Lower + "Container{\n")
+	w.WriteString("var " + rep.TypeInfo.Container + " = " + rep.TypeInfo.Lower + "Container{\n")
+	compact := len(rep.Enums) > compactContainerThreshold
 	for _, info := range rep.Enums {
+		if info.Info.Sentinel {
+			w.WriteString("\t" + info.Info.Upper + ": " + info.TypeInfo.Camel + "{" + info.TypeInfo.Name + ": " + info.Info.Name + "},\n")
+			continue
+		}
+		if info.Info.Valid && compact {
+			w.WriteString("\t" + info.Info.Upper + ": " + info.TypeInfo.Camel + "{" + info.TypeInfo.Name + ": " + info.Info.Name)
+			for i := range info.TypeInfo.NameTypePairs {
+				w.WriteString(", " + info.TypeInfo.NameTypePairs[i].Field() + ": " + info.TypeInfo.NameTypePairs[i].Value)
+			}
+			w.WriteString("},\n")
+			continue
+		}
 		if info.Info.Valid {
 			w.WriteString("\t" + info.Info.Upper + ": " + info.TypeInfo.Camel + "{ \n\t" + info.TypeInfo.Name + ":" + info.Info.Name + ",\n")
 			for i := range info.TypeInfo.NameTypePairs {
-				w.WriteString(info.TypeInfo.NameTypePairs[i].Name + ": " + info.TypeInfo.NameTypePairs[i].Value + ",\n")
+				w.WriteString(info.TypeInfo.NameTypePairs[i].Field() + ": " + info.TypeInfo.NameTypePairs[i].Value + ",\n")
 			}
 			w.WriteString("},\n")
 		}
@@ -611,6 +2137,16 @@ func writeWrapperType(w io.StringWriter, rep EnumRepresentation) {
 	w.WriteString("}\n\n")
 }
 
+func writeContainerAccessor(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.Immutable {
+		return
+	}
+	w.WriteString("// " + rep.TypeInfo.PluralCamel + " returns a copy of the " + rep.TypeInfo.Camel + " values so they cannot be modified.\n")
+	w.WriteString("func " + rep.TypeInfo.PluralCamel + "() " + rep.TypeInfo.Lower + "Container {\n")
+	w.WriteString("\treturn " + rep.TypeInfo.Container + "\n")
+	w.WriteString("}\n\n")
+}
+
 func writeAllMethod(w io.StringWriter, rep EnumRepresentation) {
 	w.WriteString("func (c " + rep.TypeInfo.Lower + "Container) All() []" + rep.TypeInfo.Camel + " {\n")
 	w.WriteString("\treturn []" + rep.TypeInfo.Camel + "{\n")
@@ -623,9 +2159,223 @@ func writeAllMethod(w io.StringWriter, rep EnumRepresentation) {
 	w.WriteString("}\n\n")
 }
 
+// writeAllWithInvalidMethod writes AllWithInvalid returning every declared constant in
+// declaration order, for tooling that needs the values All leaves out. Invalid constants
+// other than the sentinel are not held by the container so are built from the constant.
+func writeAllWithInvalidMethod(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("// AllWithInvalid returns every declared " + rep.TypeInfo.Camel + ", including those marked invalid, in declaration order.\n")
+	w.WriteString("func (c " + rep.TypeInfo.Lower + "Container) AllWithInvalid() []" + rep.TypeInfo.Camel + " {\n")
+	w.WriteString("\treturn []" + rep.TypeInfo.Camel + "{\n")
+	for _, info := range rep.Enums {
+		if info.Info.Valid || info.Info.Sentinel {
+			w.WriteString("\t\tc." + info.Info.Upper + ",\n")
+			continue
+		}
+		w.WriteString("\t\t{" + rep.TypeInfo.Name + ": " + info.Info.Name + "},\n")
+	}
+	w.WriteString("\t}\n")
+	w.WriteString("}\n\n")
+}
+
+// writeCountMethods writes Count, First and Last for the valid values in declaration
+// order, with First and Last returning the invalid value when there are none.
+func writeCountMethods(w io.StringWriter, rep EnumRepresentation) {
+	var valid []Enum
+	for _, info := range rep.Enums {
+		if info.Info.Valid {
+			valid = append(valid, info)
+		}
+	}
+	first, last := "invalid"+rep.TypeInfo.Camel, "invalid"+rep.TypeInfo.Camel
+	if len(valid) > 0 {
+		first, last = "c."+valid[0].Info.Upper, "c."+valid[len(valid)-1].Info.Upper
+	}
+	w.WriteString("// Count returns the number of valid " + rep.TypeInfo.Camel + " values.\n")
+	w.WriteString("func (c " + rep.TypeInfo.Lower + "Container) Count() int {\n")
+	w.WriteString("\treturn " + strconv.Itoa(len(valid)) + "\n")
+	w.WriteString("}\n\n")
+	w.WriteString("// First returns the first valid " + rep.TypeInfo.Camel + " in declaration order.\n")
+	w.WriteString("func (c " + rep.TypeInfo.Lower + "Container) First() " + rep.TypeInfo.Camel + " {\n")
+	w.WriteString("\treturn " + first + "\n")
+	w.WriteString("}\n\n")
+	w.WriteString("// Last returns the last valid " + rep.TypeInfo.Camel + " in declaration order.\n")
+	w.WriteString("func (c " + rep.TypeInfo.Lower + "Container) Last() " + rep.TypeInfo.Camel + " {\n")
+	w.WriteString("\treturn " + last + "\n")
+	w.WriteString("}\n\n")
+}
+
+// writeOrdinalMethod writes Ordinal returning the position of the constant in the
+// declaration, its index in AllWithInvalid, which differs from its value when the
+// enum starts at an offset or skips values.
+func writeOrdinalMethod(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("// Ordinal returns the position the " + rep.TypeInfo.Camel + " is declared at, counting from 0 and including\n")
+	w.WriteString("// the values marked invalid, or -1 for a value that is not declared.\n")
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") Ordinal() int {\n")
+	w.WriteString("\tswitch p." + rep.TypeInfo.Name + " {\n")
+	for i, info := range rep.Enums {
+		w.WriteString("\tcase " + info.Info.Name + ":\n")
+		w.WriteString("\t\treturn " + strconv.Itoa(i) + "\n")
+	}
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn -1\n")
+	w.WriteString("}\n\n")
+}
+
+// writeUnderlyingMethod writes Underlying returning the raw constant, for passing
+// the value to code that still takes the unexported type.
+func writeUnderlyingMethod(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("// Underlying returns the " + rep.TypeInfo.Name + " constant the " + rep.TypeInfo.Camel + " wraps.\n")
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") Underlying() " + rep.TypeInfo.Name + " {\n")
+	w.WriteString("\treturn p." + rep.TypeInfo.Name + "\n")
+	w.WriteString("}\n\n")
+}
+
+// writeBaseMethod writes Base returning the raw constant, the inverse of As.
+func writeBaseMethod(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("// Base returns the " + rep.TypeInfo.Name + " constant the " + rep.TypeInfo.Camel + " wraps, the inverse of As" + rep.TypeInfo.Camel + ".\n")
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") Base() " + rep.TypeInfo.Name + " {\n")
+	w.WriteString("\treturn p." + rep.TypeInfo.Name + "\n")
+	w.WriteString("}\n\n")
+}
+
+// writeWrapFunction writes Wrap returning the enum for a raw constant, failing
+// when the constant is not one of the valid values. When intTo looks the valid
+// values up by position the constant is found among every declared value, whose
+// positions are then the values.
+func writeWrapFunction(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("// Wrap" + rep.TypeInfo.Camel + " returns the " + rep.TypeInfo.Camel + " wrapping the " + rep.TypeInfo.Name + " constant, or an error when it is not valid.\n")
+	w.WriteString("func Wrap" + rep.TypeInfo.Camel + "(v " + rep.TypeInfo.Name + ") (" + rep.TypeInfo.Camel + ", error) {\n")
+	if !rep.valueLookup() {
+		index := "int(v)"
+		if rep.TypeInfo.Index != 0 {
+			index += " - " + strconv.Itoa(rep.TypeInfo.Index)
+		}
+		w.WriteString("\tif i := " + index + "; i >= 0 && i < len(" + rep.TypeInfo.Container + ".AllWithInvalid()) {\n")
+		w.WriteString("\t\tif p := " + rep.TypeInfo.Container + ".AllWithInvalid()[i]; p.IsValid() {\n")
+		w.WriteString("\t\t\treturn p, nil\n")
+		w.WriteString("\t\t}\n")
+		w.WriteString("\t}\n")
+		w.WriteString("\treturn invalid" + rep.TypeInfo.Camel + ", fmt.Errorf(" + strconv.Quote(rep.errorMessage("wrap")+": %d") + ", v)\n")
+		w.WriteString("}\n\n")
+		return
+	}
+	w.WriteString("\tp := intTo" + rep.TypeInfo.Camel + "(int(v))\n")
+	w.WriteString("\tif p." + rep.TypeInfo.Name + " != v || !p.IsValid() {\n")
+	w.WriteString("\t\treturn invalid" + rep.TypeInfo.Camel + ", fmt.Errorf(" + strconv.Quote(rep.errorMessage("wrap")+": %d") + ", v)\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn p, nil\n")
+	w.WriteString("}\n\n")
+}
+
+// writeAsFunction writes As returning the enum for a raw constant like Wrap,
+// but returning the invalid value instead of an error so it can be used inline
+// while migrating code from the raw constants.
+func writeAsFunction(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("// As" + rep.TypeInfo.Camel + " returns the " + rep.TypeInfo.Camel + " wrapping the " + rep.TypeInfo.Name + " constant, or the invalid " + rep.TypeInfo.Camel + " when it is not valid.\n")
+	w.WriteString("func As" + rep.TypeInfo.Camel + "(v " + rep.TypeInfo.Name + ") " + rep.TypeInfo.Camel + " {\n")
+	if !rep.valueLookup() {
+		w.WriteString("\tp, _ := Wrap" + rep.TypeInfo.Camel + "(v)\n")
+		w.WriteString("\treturn p\n")
+		w.WriteString("}\n\n")
+		return
+	}
+	w.WriteString("\treturn intTo" + rep.TypeInfo.Camel + "(int(v))\n")
+	w.WriteString("}\n\n")
+}
+
 func setupInvalidTypeMethod(w io.StringWriter, rep EnumRepresentation) {
-	w.WriteString("var invalid" + rep.TypeInfo.Camel + " = " + rep.TypeInfo.Camel + "{}\n\n")
+	sentinel, ok := rep.sentinel()
+	if !ok && rep.ZeroValid {
+		unused := strconv.Itoa(rep.unusedValue())
+		w.WriteString("// invalid" + rep.TypeInfo.Camel + " holds " + unused + ", which no constant has, as the zero " + rep.TypeInfo.Camel + " is valid.\n")
+		w.WriteString("var invalid" + rep.TypeInfo.Camel + " = " + rep.TypeInfo.Camel + "{" + rep.TypeInfo.Name + ": " + unused + "}\n\n")
+		return
+	}
+	if !ok {
+		w.WriteString("var invalid" + rep.TypeInfo.Camel + " = " + rep.TypeInfo.Camel + "{}\n\n")
+		return
+	}
+	w.WriteString("var invalid" + rep.TypeInfo.Camel + " = " + rep.TypeInfo.Camel + "{" + rep.TypeInfo.Name + ": " + sentinel.Info.Name + "}\n\n")
+	w.WriteString("// " + rep.TypeInfo.Camel + "Invalid is the invalid " + rep.TypeInfo.Camel + " returned when parsing fails.\n")
+	w.WriteString("var " + rep.TypeInfo.Camel + "Invalid = invalid" + rep.TypeInfo.Camel + "\n\n")
 }
+
+func writeZeroMethods(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("// IsZero reports whether the " + rep.TypeInfo.Camel + " is unset, meaning it holds the invalid value.\n")
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") IsZero() bool {\n")
+	if _, ok := rep.sentinel(); ok {
+		w.WriteString("\treturn p." + rep.TypeInfo.Name + " == invalid" + rep.TypeInfo.Camel + "." + rep.TypeInfo.Name + "\n")
+	} else {
+		// without a sentinel the zero value may be a valid constant, which is set
+		w.WriteString("\treturn p." + rep.TypeInfo.Name + " == invalid" + rep.TypeInfo.Camel + "." + rep.TypeInfo.Name + " && !p.IsValid()\n")
+	}
+	w.WriteString("}\n\n")
+	w.WriteString("// IsSet reports whether the " + rep.TypeInfo.Camel + " holds a value other than the invalid value.\n")
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") IsSet() bool {\n")
+	w.WriteString("\treturn !p.IsZero()\n")
+	w.WriteString("}\n\n")
+	w.WriteString("// Ptr returns a pointer to a copy of the " + rep.TypeInfo.Camel + ", for use in optional fields.\n")
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") Ptr() *" + rep.TypeInfo.Camel + " {\n")
+	w.WriteString("\treturn &p\n")
+	w.WriteString("}\n\n")
+}
+
+// emptyValue returns the expression empty and null input decodes to, the default
+// value with EmptyDefault and the invalid value otherwise.
+func (rep EnumRepresentation) emptyValue() string {
+	if rep.EmptyDefault {
+		return "Default" + rep.TypeInfo.Camel + "()"
+	}
+	return "invalid" + rep.TypeInfo.Camel
+}
+
+func writeDefaultMethod(w io.StringWriter, rep EnumRepresentation) {
+	e, ok := rep.defaultEnum()
+	if !ok {
+		return
+	}
+	w.WriteString("// Default" + rep.TypeInfo.Camel + " returns the default " + rep.TypeInfo.Camel + ", " + rep.containerRef() + "." + e.Info.Upper + ".\n")
+	w.WriteString("func Default" + rep.TypeInfo.Camel + "() " + rep.TypeInfo.Camel + " {\n")
+	w.WriteString("\treturn " + rep.TypeInfo.Container + "." + e.Info.Upper + "\n")
+	w.WriteString("}\n\n")
+}
+
+// writeMarshalNameMethod writes the method the marshalers use to replace the
+// name of a value that is not valid, when configured to.
+func writeMarshalNameMethod(w io.StringWriter, rep EnumRepresentation) {
+	if rep.MarshalInvalid == "" && rep.InvalidPlaceholder == "" || !rep.hasHandlers() {
+		return
+	}
+	w.WriteString("// marshalName returns name when the " + rep.TypeInfo.Camel + " is valid and what is written in its place otherwise.\n")
+	w.WriteString("func (p " + rep.TypeInfo.Camel + ") marshalName(name string) (string, error) {\n")
+	w.WriteString("\tif p.IsValid() {\n")
+	w.WriteString("\t\treturn name, nil\n")
+	w.WriteString("\t}\n")
+	switch rep.MarshalInvalid {
+	case MarshalInvalidError:
+		w.WriteString("\treturn \"\", fmt.Errorf(" + strconv.Quote(rep.errorMessage("marshal")+": %d") + ", p." + rep.TypeInfo.Name + ")\n")
+	case MarshalInvalidNumber:
+		w.WriteString("\treturn strconv.Itoa(int(p." + rep.TypeInfo.Name + ")), nil\n")
+	default:
+		w.WriteString("\treturn " + strconv.Quote(rep.InvalidPlaceholder) + ", nil\n")
+	}
+	w.WriteString("}\n\n")
+}
+
+// writeMarshaledName writes the lookup of the name a marshaler writes for the tag
+// key and returns the expression holding it. zero is what the marshaler returns
+// alongside an error.
+func writeMarshaledName(w io.StringWriter, rep EnumRepresentation, key, zero string) string {
+	if rep.MarshalInvalid == "" && rep.InvalidPlaceholder == "" {
+		return rep.tagName(key)
+	}
+	w.WriteString("\tname, err := p.marshalName(" + rep.tagName(key) + ")\n")
+	w.WriteString("\tif err != nil {\n")
+	w.WriteString("\t\treturn " + zero + "err\n")
+	w.WriteString("\t}\n")
+	return "name"
+}
+
 func writeParseMethod(w io.StringWriter, rep EnumRepresentation) {
 	setupInvalidTypeMethod(w, rep)
 	w.WriteString("func Parse" + rep.TypeInfo.Camel + "(a any) (" + rep.TypeInfo.Camel + ", error) {\n")
@@ -639,43 +2389,145 @@ func writeParseMethod(w io.StringWriter, rep EnumRepresentation) {
 	w.WriteString("\t\tres = stringTo" + rep.TypeInfo.Camel + "(v)\n")
 	w.WriteString("\tcase fmt.Stringer:\n")
 	w.WriteString("\t\tres = stringTo" + rep.TypeInfo.Camel + "(v.String())\n")
-	w.WriteString("\tcase int:\n")
-	w.WriteString("\t\tres = intTo" + rep.TypeInfo.Camel + "(v)\n")
-	w.WriteString("\tcase int64:\n")
-	w.WriteString("\t\tres = intTo" + rep.TypeInfo.Camel + "(int(v))\n")
-	w.WriteString("\tcase int32:\n")
-	w.WriteString("\t\tres = intTo" + rep.TypeInfo.Camel + "(int(v))\n")
+	if rep.shared() {
+		w.WriteString("\tdefault:\n")
+		w.WriteString("\t\tif i, ok := enumsInt(v); ok {\n")
+		w.WriteString("\t\t\tres = intTo" + rep.TypeInfo.Camel + "(i)\n")
+		w.WriteString("\t\t}\n")
+	} else {
+		w.WriteString("\tcase int:\n")
+		w.WriteString("\t\tres = intTo" + rep.TypeInfo.Camel + "(v)\n")
+		w.WriteString("\tcase int64:\n")
+		w.WriteString("\t\tres = intTo" + rep.TypeInfo.Camel + "(int(v))\n")
+		w.WriteString("\tcase int32:\n")
+		w.WriteString("\t\tres = intTo" + rep.TypeInfo.Camel + "(int(v))\n")
+	}
+	writeFloatParseCases(w, rep)
 	w.WriteString("\t}\n")
 	if rep.Failfast {
 		w.WriteString("\tif res == invalid" + rep.TypeInfo.Camel + " {\n")
-		w.WriteString("\t\treturn res, fmt.Errorf(\"failed to parse invalid " + rep.TypeInfo.Camel + ": %v\", a)\n")
+		if rep.Suggest {
+			w.WriteString("\t\tif s, ok := a.(string); ok {\n")
+			w.WriteString("\t\t\tif c, d := Closest" + rep.TypeInfo.Camel + "(s); d <= " + strconv.Itoa(suggestDistance) + " {\n")
+			w.WriteString("\t\t\t\treturn res, fmt.Errorf(" + strconv.Quote(rep.errorMessage("parse")+": %v, did you mean %q?") + ", a, c.String())\n")
+			w.WriteString("\t\t\t}\n")
+			w.WriteString("\t\t}\n")
+		}
+		w.WriteString("\t\treturn res, fmt.Errorf(" + strconv.Quote(rep.errorMessage("parse")+": %v") + ", a)\n")
 		w.WriteString("\t}\n")
 	}
 	w.WriteString("\treturn res, nil\n")
 	w.WriteString("}\n\n")
 	setupStringToTypeMethod(w, rep)
 	setupIntToTypeMethod(w, rep)
+	writeFloatToTypeMethod(w, rep)
 }
 
+// unmarshalFunc returns the function the unmarshal and scan methods parse with,
+// which only differs from Parse in strict mode without failfast.
+func (rep EnumRepresentation) unmarshalFunc() string {
+	if rep.Strict && !rep.Failfast {
+		return "parseStrict" + rep.TypeInfo.Camel
+	}
+	return "Parse" + rep.TypeInfo.Camel
+}
+
+// writeStrictParseMethod writes the parse function failing on invalid values used by
+// the unmarshal and scan methods in strict mode, leaving Parse itself lenient.
+func writeStrictParseMethod(w io.StringWriter, rep EnumRepresentation) {
+	if !rep.Strict || rep.Failfast || !rep.hasHandlers() {
+		return
+	}
+	w.WriteString("func parseStrict" + rep.TypeInfo.Camel + "(a any) (" + rep.TypeInfo.Camel + ", error) {\n")
+	w.WriteString("\tres, err := Parse" + rep.TypeInfo.Camel + "(a)\n")
+	w.WriteString("\tif err == nil && res == invalid" + rep.TypeInfo.Camel + " {\n")
+	w.WriteString("\t\tif b, ok := a.([]byte); ok {\n")
+	w.WriteString("\t\t\ta = string(b)\n")
+	w.WriteString("\t\t}\n")
+	w.WriteString("\t\treturn res, fmt.Errorf(" + strconv.Quote(rep.errorMessage("parse")+": %v") + ", a)\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn res, err\n")
+	w.WriteString("}\n\n")
+}
+
+// setupIntToTypeMethod maps integers to the valid values by position, offset by
+// the iota index. With SQLInt, or values positions cannot reach, it maps the
+// underlying constant values back to the enum instead, so the values returned by
+// Value in numeric mode always scan back to the same enum.
 func setupIntToTypeMethod(w io.StringWriter, rep EnumRepresentation) {
+	if rep.Coverage {
+		writeIntToTypeMap(w, rep)
+		return
+	}
 	w.WriteString("func intTo" + rep.TypeInfo.Camel + "(i int) " + rep.TypeInfo.Camel + " {\n")
-	if rep.TypeInfo.Index != 0 {
-		w.WriteString("\ti = i - " + strconv.Itoa(rep.TypeInfo.Index) + "\n")
+	if !rep.valueLookup() {
+		if rep.TypeInfo.Index != 0 {
+			w.WriteString("\ti = i - " + strconv.Itoa(rep.TypeInfo.Index) + "\n")
+		}
+		w.WriteString("\tif i < 0 || i >= len(" + rep.TypeInfo.Container + ".All()) {\n")
+		w.WriteString("\t\treturn invalid" + rep.TypeInfo.Camel + "\n")
+		w.WriteString("\t}\n")
+		w.WriteString("\treturn " + rep.TypeInfo.Container + ".All()[i]\n")
+		w.WriteString("}\n\n")
+		return
+	}
+	w.WriteString("\tswitch i {\n")
+	for _, info := range rep.Enums {
+		if info.Info.Valid {
+			w.WriteString("\tcase int(" + info.Info.Name + "):\n")
+			w.WriteString("\t\treturn " + rep.TypeInfo.Container + "." + info.Info.Upper + "\n")
+		}
 	}
-	w.WriteString("\tif i < 0 || i >= len(" + rep.TypeInfo.PluralCamel + " .All()) {\n")
-	w.WriteString("\t\treturn invalid" + rep.TypeInfo.Camel + "\n")
 	w.WriteString("\t}\n")
-	w.WriteString("\treturn " + rep.TypeInfo.PluralCamel + " .All()[i]\n")
+	w.WriteString("\treturn invalid" + rep.TypeInfo.Camel + "\n")
 	w.WriteString("}\n\n")
 }
 
 func setupStringToTypeMethod(w io.StringWriter, rep EnumRepresentation) {
+	if rep.Coverage {
+		writeStringToTypeMap(w, rep)
+		return
+	}
+	switch rep.ParseLookup {
+	case ParseLookupLength:
+		writeStringToTypeLength(w, rep)
+		return
+	case ParseLookupHash:
+		writeStringToTypeHash(w, rep)
+		return
+	}
+	if rep.Insensitive {
+		w.WriteString("func stringTo" + rep.TypeInfo.Camel + "(s string) " + rep.TypeInfo.Camel + " {\n")
+		names := rep.parseNames()
+		w.WriteString("\tswitch {\n")
+		for i, info := range rep.Enums {
+			cases := make([]string, len(names[i]))
+			for j, name := range names[i] {
+				cases[j] = "strings.EqualFold(s, " + strconv.Quote(name) + ")"
+			}
+			w.WriteString("\tcase " + strings.Join(cases, ", ") + ":\n")
+			w.WriteString("\t\treturn " + rep.TypeInfo.Container + "." + info.Info.Upper + "\n")
+		}
+		w.WriteString("\t}\n")
+		w.WriteString("\treturn invalid" + rep.TypeInfo.Camel + "\n")
+		w.WriteString("}\n\n")
+		return
+	}
+	writeStringToTypeSwitch(w, rep)
+}
+
+// writeStringToTypeSwitch writes stringTo as a switch on the name.
+func writeStringToTypeSwitch(w io.StringWriter, rep EnumRepresentation) {
 	w.WriteString("func stringTo" + rep.TypeInfo.Camel + "(s string) " + rep.TypeInfo.Camel + " {\n")
-	// w.WriteString("\tlwr := strings.ToLower(s)\n")
+	names := rep.parseNames()
 	w.WriteString("\tswitch s {\n")
-	for _, info := range rep.Enums {
-		w.WriteString("\tcase \"" + info.Info.AlternateName + "\":\n")
-		w.WriteString("\t\treturn " + rep.TypeInfo.PluralCamel + "." + info.Info.Upper + "\n")
+	for i, info := range rep.Enums {
+		cases := make([]string, len(names[i]))
+		for j, name := range names[i] {
+			cases[j] = strconv.Quote(name)
+		}
+		w.WriteString("\tcase " + strings.Join(cases, ", ") + ":\n")
+		w.WriteString("\t\treturn " + rep.TypeInfo.Container + "." + info.Info.Upper + "\n")
 	}
 	w.WriteString("\t}\n")
 	w.WriteString("\treturn invalid" + rep.TypeInfo.Camel + "\n")
@@ -0,0 +1,131 @@
+package generator_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zarldev/goenums/pkg/generator"
+	"github.com/zarldev/goenums/pkg/generator/testdata/coverage"
+	"github.com/zarldev/goenums/pkg/generator/testdata/lookuphash"
+	"github.com/zarldev/goenums/pkg/generator/testdata/lookuplength"
+	"github.com/zarldev/goenums/pkg/generator/testdata/lookupswitch"
+)
+
+// syntheticEnum returns the source of an enum with n values, each with the
+// extra fields when fields is set.
+func syntheticEnum(n int, fields bool) string {
+	var b strings.Builder
+	b.WriteString("package synthetic\n\n")
+	b.WriteString("type code int")
+	if fields {
+		b.WriteString(" // Number[int],Label[string],Weight[float64],Active[bool],Region[string],Rank[int]")
+	}
+	b.WriteString("\n\nconst (\n")
+	b.WriteString("\tunknown code = iota // invalid\n")
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "\tvalue%04d", i)
+		if fields {
+			fmt.Fprintf(&b, " // Value%04d %d,\"Label %d\",%d.5,%t,\"Region %d\",%d", i, i, i, i, i%2 == 0, i%7, n-i)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+func BenchmarkParseAndGenerate(b *testing.B) {
+	benchmarks := []struct {
+		name   string
+		values int
+		fields bool
+		config generator.Config
+	}{
+		{name: "Values10", values: 10},
+		{name: "Values1000", values: 1000},
+		{name: "Values1000Fields", values: 1000, fields: true},
+		{name: "Values5000Fields", values: 5000, fields: true},
+		{name: "Values1000AllOptions", values: 1000, fields: true, config: generator.Config{
+			Failfast:    true,
+			YAML:        generator.YAMLv2,
+			Accessors:   true,
+			Insensitive: true,
+			Suggest:     true,
+		}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			filename := filepath.Join(b.TempDir(), "code.go")
+			src := syntheticEnum(bm.values, bm.fields)
+			if err := os.WriteFile(filename, []byte(src), 0o644); err != nil {
+				b.Fatalf("failed to write %s, got %v", filename, err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				err := generator.ParseAndGenerateWithConfig(context.Background(), filename, bm.config)
+				if err != nil {
+					b.Fatalf("failed to generate enums, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkWriteReleaseNotes(b *testing.B) {
+	previous := []byte(syntheticEnum(1000, true))
+	current := []byte(strings.Replace(syntheticEnum(1001, true), "value0500", "value0500renamed", 1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		err := generator.WriteReleaseNotes(io.Discard, "code.go", previous, current)
+		if err != nil {
+			b.Fatalf("failed to write release notes, got %v", err)
+		}
+	}
+}
+
+// BenchmarkParseLookup compares the lookup strategies of the generated Parse
+// over the same enum, parsing every spelling and an unknown name.
+func BenchmarkParseLookup(b *testing.B) {
+	inputs := []string{"unknown", "failed", "FAILED", "passed", "ok", "succeeded", "skipped", "scheduled", "running", "bogus"}
+	benchmarks := []struct {
+		name  string
+		parse func(string) error
+	}{
+		{name: "Switch", parse: func(s string) error { _, err := lookupswitch.ParseStatus(s); return err }},
+		{name: "Map", parse: func(s string) error { _, err := coverage.ParseStatus(s); return err }},
+		{name: "Length", parse: func(s string) error { _, err := lookuplength.ParseStatus(s); return err }},
+		{name: "Hash", parse: func(s string) error { _, err := lookuphash.ParseStatus(s); return err }},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := range b.N {
+				_ = bm.parse(inputs[i%len(inputs)])
+			}
+		})
+	}
+}
+
+// BenchmarkMarshalJSON compares MarshalJSON with appending to a reused buffer.
+func BenchmarkMarshalJSON(b *testing.B) {
+	p := lookupswitch.Statuses.SCHEDULED
+	b.Run("MarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			_, _ = p.MarshalJSON()
+		}
+	})
+	b.Run("AppendJSON", func(b *testing.B) {
+		buf := make([]byte, 0, 64)
+		b.ReportAllocs()
+		for range b.N {
+			buf = p.AppendJSON(buf[:0])
+		}
+	})
+}
@@ -0,0 +1,65 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+// Unlike the generated jsonv2 files, which only use jsontext, this test calls
+// json.Marshal and json.Unmarshal, which the go 1.22 module may only use in a
+// file whose build constraint raises its language version to go1.27.
+
+package generator_test
+
+import (
+	"encoding/json/v2"
+	"testing"
+
+	"github.com/zarldev/goenums/pkg/generator/testdata/emptyinvalid"
+	"github.com/zarldev/goenums/pkg/generator/testdata/jsonv2"
+)
+
+func TestGeneratedJSONv2(t *testing.T) {
+	b, err := json.Marshal(jsonv2.Statuses.PASSED)
+	if err != nil {
+		t.Fatalf("failed to marshal, got %v", err)
+	}
+	if string(b) != `"passed"` {
+		t.Errorf("expected \"passed\", got %s", b)
+	}
+	var s jsonv2.Status
+	err = json.Unmarshal([]byte(`"booked"`), &s)
+	if err != nil {
+		t.Fatalf("failed to unmarshal, got %v", err)
+	}
+	if s != jsonv2.Statuses.BOOKED {
+		t.Errorf("expected %v, got %v", jsonv2.Statuses.BOOKED, s)
+	}
+}
+
+func TestGeneratedJSONv2EmptyInvalid(t *testing.T) {
+	values := []any{emptyinvalid.Status{}}
+	for _, s := range emptyinvalid.Statuses.All() {
+		values = append(values, s)
+	}
+	for _, c := range emptyinvalid.Colors.All() {
+		values = append(values, c)
+	}
+	for _, v := range values {
+		want, err := v.(interface{ MarshalJSON() ([]byte, error) }).MarshalJSON()
+		if err != nil {
+			t.Fatalf("failed to marshal %v, got %v", v, err)
+		}
+		got, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("failed to marshal %v with json/v2, got %v", v, err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("expected json/v2 to marshal %v as %s, got %s", v, want, got)
+		}
+	}
+	for _, in := range []string{`""`, `null`} {
+		s := emptyinvalid.Statuses.PASSED
+		if err := json.Unmarshal([]byte(in), &s); err != nil {
+			t.Fatalf("failed to unmarshal %s, got %v", in, err)
+		}
+		if s.IsValid() {
+			t.Errorf("expected %s to unmarshal to the invalid status, got %v", in, s)
+		}
+	}
+}
@@ -1,102 +1,159 @@
 package generator_test
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/zarldev/goenums/examples/sale"
 	"github.com/zarldev/goenums/pkg/generator"
+	"github.com/zarldev/goenums/pkg/generator/testdata"
+	"github.com/zarldev/goenums/pkg/generator/testdata/accessors"
+	"github.com/zarldev/goenums/pkg/generator/testdata/affixes"
+	"github.com/zarldev/goenums/pkg/generator/testdata/basemethods"
+	"github.com/zarldev/goenums/pkg/generator/testdata/bitflags"
+	"github.com/zarldev/goenums/pkg/generator/testdata/bitflagswide"
+	"github.com/zarldev/goenums/pkg/generator/testdata/blankfirst"
+	"github.com/zarldev/goenums/pkg/generator/testdata/blankgap"
+	"github.com/zarldev/goenums/pkg/generator/testdata/coverage"
+	"github.com/zarldev/goenums/pkg/generator/testdata/defaults"
+	"github.com/zarldev/goenums/pkg/generator/testdata/descriptions"
+	descriptionsdirective "github.com/zarldev/goenums/pkg/generator/testdata/descriptions_directive"
+	"github.com/zarldev/goenums/pkg/generator/testdata/emptydefault"
+	"github.com/zarldev/goenums/pkg/generator/testdata/emptyinvalid"
+	"github.com/zarldev/goenums/pkg/generator/testdata/floatintegral"
+	"github.com/zarldev/goenums/pkg/generator/testdata/floatround"
+	"github.com/zarldev/goenums/pkg/generator/testdata/grouped"
+	"github.com/zarldev/goenums/pkg/generator/testdata/immutable"
+	"github.com/zarldev/goenums/pkg/generator/testdata/insensitive"
+	"github.com/zarldev/goenums/pkg/generator/testdata/lookuphash"
+	"github.com/zarldev/goenums/pkg/generator/testdata/lookuplength"
+	"github.com/zarldev/goenums/pkg/generator/testdata/marshalinvalid"
+	"github.com/zarldev/goenums/pkg/generator/testdata/names"
 	"github.com/zarldev/goenums/pkg/generator/testdata/orders"
+	"github.com/zarldev/goenums/pkg/generator/testdata/pgx"
+	"github.com/zarldev/goenums/pkg/generator/testdata/pgxint"
 	"github.com/zarldev/goenums/pkg/generator/testdata/planets"
 	planetsgravityonly "github.com/zarldev/goenums/pkg/generator/testdata/planets_gravity_only"
 	plannetssimple "github.com/zarldev/goenums/pkg/generator/testdata/planets_simple"
+	"github.com/zarldev/goenums/pkg/generator/testdata/sentinel"
+	"github.com/zarldev/goenums/pkg/generator/testdata/shared"
+	"github.com/zarldev/goenums/pkg/generator/testdata/sparse"
+	"github.com/zarldev/goenums/pkg/generator/testdata/sqlint"
+	"github.com/zarldev/goenums/pkg/generator/testdata/strict"
+	"github.com/zarldev/goenums/pkg/generator/testdata/suggest"
+	"github.com/zarldev/goenums/pkg/generator/testdata/tags"
+	"github.com/zarldev/goenums/pkg/generator/testdata/trimprefix"
+	unicodenames "github.com/zarldev/goenums/pkg/generator/testdata/unicode"
 	"github.com/zarldev/goenums/pkg/generator/testdata/validation"
-)
-
-var (
-	testCases = []struct {
-		name     string
-		filename string
-		failfast bool
-		expected string
-	}{
-		{
-			name:     "TestParseAndGenerate-Statuses-Strings",
-			filename: "testdata/validation-strings/status.go",
-			failfast: false,
-			expected: "testdata/validation-strings/statuses_enums.go",
-		},
-		{
-			name:     "TestParseAndGenerate-Statuses",
-			filename: "testdata/validation/status.go",
-			failfast: false,
-			expected: "testdata/validation/statuses_enums.go",
-		},
-
-		{
-			name:     "TestParseAndGenerate-Planets",
-			filename: "testdata/planets/planets.go",
-			failfast: false,
-			expected: "testdata/planets/planets_enums.go",
-		},
-		{
-			name:     "TestParseAndGenerate-PlanetsGravityOnly",
-			filename: "testdata/planets_gravity_only/planets.go",
-			failfast: false,
-			expected: "testdata/planets_gravity_only/planets_enums.go",
-		},
-		{
-			name:     "TestParseAndGenerate-PlanetsSimple",
-			filename: "testdata/planets_simple/planets.go",
-			failfast: false,
-			expected: "testdata/planets_simple/planets_enums.go",
-		},
-		{
-			name:     "TestParseAndGenerate-DiscountTypes",
-			filename: "testdata/sale/discount.go",
-			failfast: true,
-			expected: "testdata/sale/discounttypes_enums.go",
-		},
-		{
-			name:     "TestParseAndGenerate-Orders",
-			filename: "testdata/orders/orders.go",
-			failfast: false,
-			expected: "testdata/orders/orders_enums.go",
-		},
-	}
+	"github.com/zarldev/goenums/pkg/generator/testdata/yaml"
+	"github.com/zarldev/goenums/pkg/generator/testdata/yamlv3"
+	goyaml "gopkg.in/yaml.v3"
 )
 
 func TestGenerator(t *testing.T) {
 	// Setup
 	// Clean up all previously generated files
-	for _, tc := range testCases {
-		err := os.Remove(tc.expected)
+	for _, tc := range testdata.InputOutputTestCases {
+		err := os.Remove(tc.Expected)
 		if err != nil {
 			t.Errorf("failed to cleanup generated files, got %v", err)
 		}
 	}
 	// Run test cases
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			err := generator.ParseAndGenerate(tc.filename, tc.failfast)
+	for _, tc := range testdata.InputOutputTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := generator.ParseAndGenerateWithConfig(context.Background(), tc.Source, tc.Config)
 			if err != nil {
-				t.Errorf("failed to generate enums for %s, got %v", tc.filename, err)
+				t.Errorf("failed to generate enums for %s, got %v", tc.Source, err)
 			}
 		})
 	}
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
+	for _, tc := range testdata.InputOutputTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
 			// Check if the generated file exists
-			_, err := os.Stat(tc.expected)
+			_, err := os.Stat(tc.Expected)
 			if err != nil {
-				t.Errorf("failed to find generated file %s, got %v", tc.expected, err)
+				t.Errorf("failed to find generated file %s, got %v", tc.Expected, err)
 			}
 		})
 	}
 }
 
+func TestGenerateContract(t *testing.T) {
+	testdata.RunGenerateContract(t, generator.Generate)
+}
+
+func TestRepresentationJSONRoundTrip(t *testing.T) {
+	testdata.RunGenerateContract(t, func(ctx context.Context, filename string, src []byte, cfg generator.Config) ([]generator.File, error) {
+		rep, err := generator.Parse(ctx, filename, src, cfg)
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(rep)
+		if err != nil {
+			return nil, err
+		}
+		var decoded generator.EnumRepresentation
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			return nil, err
+		}
+		return generator.GenerateRepresentation(ctx, decoded)
+	})
+}
+
+func TestGenerateRepresentationValidates(t *testing.T) {
+	src, err := os.ReadFile("testdata/validation/status.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rep, err := generator.Parse(context.Background(), "status.go", src, generator.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Version != generator.RepresentationVersion {
+		t.Errorf("expected version %d, got %d", generator.RepresentationVersion, rep.Version)
+	}
+	for _, version := range []int{0, generator.RepresentationVersion + 1} {
+		other := rep
+		other.Version = version
+		if _, err := generator.GenerateRepresentation(context.Background(), other); !errors.Is(err, generator.ErrIncompatibleRepresentation) {
+			t.Errorf("expected ErrIncompatibleRepresentation for version %d, got %v", version, err)
+		}
+	}
+	var unversioned generator.EnumRepresentation
+	if err := json.Unmarshal([]byte(`{"package":"validation","values":[]}`), &unversioned); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := generator.GenerateRepresentation(context.Background(), unversioned); !errors.Is(err, generator.ErrIncompatibleRepresentation) {
+		t.Errorf("expected ErrIncompatibleRepresentation without a version, got %v", err)
+	}
+	rep.Lock = true
+	if _, err := generator.GenerateRepresentation(context.Background(), rep); !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig for lock, got %v", err)
+	}
+	empty := generator.EnumRepresentation{Version: generator.RepresentationVersion}
+	if _, err := generator.GenerateRepresentation(context.Background(), empty); !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig for an empty representation, got %v", err)
+	}
+}
+
 var (
 	testCasesWithInvalid = []struct {
 		name     string
@@ -267,3 +324,3513 @@ func TestGeneratedEnums(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAndGenerateContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := generator.ParseAndGenerateContext(ctx, "testdata/validation/status.go", false)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	_, err = os.Stat("testdata/validation/statuses_enums.go")
+	if err != nil {
+		t.Errorf("expected existing generated file to be left untouched, got %v", err)
+	}
+}
+
+func TestParseAndGenerateConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	var wg sync.WaitGroup
+	errs := make([]error, len(testdata.InputOutputTestCases))
+	for i, tc := range testdata.InputOutputTestCases {
+		src, err := os.ReadFile(tc.Source)
+		if err != nil {
+			t.Fatalf("failed to read %s, got %v", tc.Source, err)
+		}
+		filename := filepath.Join(dir, strconv.Itoa(i), filepath.Base(tc.Source))
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatalf("failed to create dir, got %v", err)
+		}
+		if err := os.WriteFile(filename, src, 0o644); err != nil {
+			t.Fatalf("failed to write %s, got %v", filename, err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = generator.ParseAndGenerateWithConfig(context.Background(), filename, tc.Config)
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("failed to generate enums for %s, got %v", testdata.InputOutputTestCases[i].Source, err)
+		}
+	}
+}
+
+func TestGeneratedYAML(t *testing.T) {
+	var s yaml.Status
+	err := s.UnmarshalYAML(func(v any) error {
+		*(v.(*string)) = "passed"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to unmarshal yaml, got %v", err)
+	}
+	if s != yaml.Statuses.PASSED {
+		t.Errorf("expected %v, got %v", yaml.Statuses.PASSED, s)
+	}
+	v, err := s.MarshalYAML()
+	if err != nil {
+		t.Fatalf("failed to marshal yaml, got %v", err)
+	}
+	if v != "passed" {
+		t.Errorf("expected passed, got %v", v)
+	}
+}
+
+func TestGeneratedYAMLv3(t *testing.T) {
+	var doc struct {
+		Status   yamlv3.Status   `yaml:"status"`
+		Statuses []yamlv3.Status `yaml:"statuses"`
+	}
+	err := goyaml.Unmarshal([]byte("status: passed\nstatuses: [failed, booked]\n"), &doc)
+	if err != nil {
+		t.Fatalf("failed to unmarshal yaml, got %v", err)
+	}
+	if doc.Status != yamlv3.Statuses.PASSED {
+		t.Errorf("expected %v, got %v", yamlv3.Statuses.PASSED, doc.Status)
+	}
+	if len(doc.Statuses) != 2 || doc.Statuses[0] != yamlv3.Statuses.FAILED || doc.Statuses[1] != yamlv3.Statuses.BOOKED {
+		t.Errorf("expected [failed booked], got %v", doc.Statuses)
+	}
+	b, err := goyaml.Marshal(doc)
+	if err != nil {
+		t.Fatalf("failed to marshal yaml, got %v", err)
+	}
+	expected := "status: passed\nstatuses:\n    - failed\n    - booked\n"
+	if string(b) != expected {
+		t.Errorf("expected %q, got %q", expected, b)
+	}
+	err = goyaml.Unmarshal([]byte("status: lost\n"), &doc)
+	if err == nil {
+		t.Errorf("expected an error unmarshaling an invalid status, got %v", doc.Status)
+	}
+}
+
+func TestInvalidYAMLConfig(t *testing.T) {
+	err := generator.ParseAndGenerateWithConfig(context.Background(), "testdata/yaml/status.go", generator.Config{YAML: "v1"})
+	if !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestGeneratedFormat(t *testing.T) {
+	tests := []struct {
+		format   string
+		value    any
+		expected string
+	}{
+		{format: "%s", value: planets.Planets.EARTH, expected: "Earth"},
+		{format: "%v", value: planets.Planets.EARTH, expected: "Earth"},
+		{format: "%d", value: planets.Planets.EARTH, expected: "3"},
+		{format: "%q", value: planets.Planets.EARTH, expected: `"Earth"`},
+		{format: "%-7s|", value: planets.Planets.MARS, expected: "Mars   |"},
+		{format: "%+v", value: planets.Planets.EARTH, expected: "Earth{Gravity: 1, RadiusKm: 6378.1, MassKg: 5.97e+24, OrbitKm: 1.496e+08, OrbitDays: 365, SurfacePressureBars: 1, Moons: 1, Rings: false}"},
+		{format: "%+v", value: validation.Statuses.PASSED, expected: "passed"},
+	}
+	for _, tc := range tests {
+		got := fmt.Sprintf(tc.format, tc.value)
+		if got != tc.expected {
+			t.Errorf("expected %s to format as %s, got %s", tc.format, tc.expected, got)
+		}
+	}
+}
+
+func TestGeneratedGoString(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{value: validation.Statuses.PASSED, expected: "Statuses.PASSED"},
+		{value: planets.Planets.NEPTUNE, expected: "Planets.NEPTUNE"},
+		{value: validation.Status{}, expected: "Status{status: 0}"},
+	}
+	for _, tc := range tests {
+		got := fmt.Sprintf("%#v", tc.value)
+		if got != tc.expected {
+			t.Errorf("expected %%#v to be %s, got %s", tc.expected, got)
+		}
+	}
+}
+
+func TestMultiLineFieldSchema(t *testing.T) {
+	values := "const (\n\tunknown planet = iota // invalid\n\tearth // Earth 1,6378.1,1,false\n\tmars // Mars 0.377,3389.5,2,false\n)\n"
+	single := "package planets\n\ntype planet int // Gravity[float64],RadiusKm[float64],Moons[int],Rings[bool]\n\n" + values
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{name: "Continuation", schema: "type planet int // Gravity[float64], RadiusKm[float64],\n// Moons[int],\n//   Rings[bool]\n\n"},
+		{name: "ContinuationBeforeConst", schema: "type planet int // Gravity[float64], RadiusKm[float64],\n// Moons[int], Rings[bool]\n"},
+		{name: "Block", schema: "type planet int /* Gravity[float64], RadiusKm[float64],\n\tMoons[int]\n\tRings[bool] */\n\n"},
+		{name: "BlockStars", schema: "type planet int /*\n * Gravity[float64],\n * RadiusKm[float64],\n * Moons[int],\n * Rings[bool]\n */\n\n"},
+	}
+	ctx := context.Background()
+	want, err := generator.Parse(ctx, "planets.go", []byte(single), generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to parse the single line schema, got %v", err)
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			src := "package planets\n\n" + tc.schema + values
+			got, err := generator.Parse(ctx, "planets.go", []byte(src), generator.Config{})
+			if err != nil {
+				t.Fatalf("failed to parse, got %v", err)
+			}
+			if !reflect.DeepEqual(got.TypeInfo.NameTypePairs, want.TypeInfo.NameTypePairs) {
+				t.Errorf("expected the fields %v, got %v", want.TypeInfo.NameTypePairs, got.TypeInfo.NameTypePairs)
+			}
+			for i := range want.Enums {
+				if !reflect.DeepEqual(got.Enums[i].TypeInfo.NameTypePairs, want.Enums[i].TypeInfo.NameTypePairs) {
+					t.Errorf("expected the values %v, got %v", want.Enums[i].TypeInfo.NameTypePairs, got.Enums[i].TypeInfo.NameTypePairs)
+				}
+			}
+		})
+	}
+	// a schema without a trailing comma ends on its line
+	src := "package planets\n\ntype planet int // Gravity[float64]\n// Moons[int]\n" + values
+	rep, err := generator.Parse(ctx, "planets.go", []byte(src), generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to parse, got %v", err)
+	}
+	if len(rep.TypeInfo.NameTypePairs) != 1 {
+		t.Errorf("expected the comment below to be left alone, got %v", rep.TypeInfo.NameTypePairs)
+	}
+}
+
+func TestStructFieldSchema(t *testing.T) {
+	values := "const (\n\tunknown planet = iota // invalid\n\tearth // Earth 1,6378.1,1,false\n\tmars // Mars 0.377,3389.5,2,false\n)\n"
+	ctx := context.Background()
+	want, err := generator.Parse(ctx, "planets.go", []byte("package planets\n\ntype planet int // Gravity[float64],RadiusKm[float64],Moons[int],Rings[bool]\n\n"+values), generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to parse the comment schema, got %v", err)
+	}
+	schema := "type planetData struct {\n\tGravity, RadiusKm float64\n\tMoons int\n\tRings bool\n}\n\n"
+	got, err := generator.Parse(ctx, "planets.go", []byte("package planets\n\n"+schema+"//goenums:fields planetData\ntype planet int\n\n"+values), generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to parse the struct schema, got %v", err)
+	}
+	if !reflect.DeepEqual(got.TypeInfo.NameTypePairs, want.TypeInfo.NameTypePairs) {
+		t.Errorf("expected the fields %v, got %v", want.TypeInfo.NameTypePairs, got.TypeInfo.NameTypePairs)
+	}
+	for i := range want.Enums {
+		if !reflect.DeepEqual(got.Enums[i].TypeInfo.NameTypePairs, want.Enums[i].TypeInfo.NameTypePairs) {
+			t.Errorf("expected the values %v, got %v", want.Enums[i].TypeInfo.NameTypePairs, got.Enums[i].TypeInfo.NameTypePairs)
+		}
+	}
+	invalid := map[string]string{
+		"Missing":  "//goenums:fields planetInfo\ntype planet int\n\n",
+		"Embedded": "type planetData struct {\n\ttime.Duration\n}\n\n//goenums:fields planetData\ntype planet int\n\n",
+	}
+	for name, decl := range invalid {
+		_, err := generator.Parse(ctx, "planets.go", []byte("package planets\n\n"+decl+values), generator.Config{})
+		if !errors.Is(err, generator.ErrInvalidDirective) {
+			t.Errorf("%s: expected ErrInvalidDirective, got %v", name, err)
+		}
+	}
+}
+
+func TestDataFile(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "planets.go")
+	values := "\n\nconst (\n\tunknown planet = iota // invalid\n\tearth // Earth %s\n\tmars // Mars 0.377,\"https://en.wikipedia.org/wiki/Mars\" desc=\"The red planet.\"\n)\n"
+	ctx := context.Background()
+	want, err := generator.Parse(ctx, filename, []byte("package planets\n\ntype planet int // Gravity[float64],Wiki[string]"+fmt.Sprintf(values, `1,"https://en.wikipedia.org/wiki/Earth" desc="Our home, the third planet from the Sun."`)), generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to parse the comment values, got %v", err)
+	}
+	src := []byte("package planets\n\n//goenums:data planets.yaml\ntype planet int // Gravity[float64],Wiki[string]" + fmt.Sprintf(values, `9,"x"`))
+	files := map[string]string{
+		"YAML": "earth:\n  Gravity: 1\n  Wiki: https://en.wikipedia.org/wiki/Earth\n  Description: Our home, the third planet from the Sun.\n",
+		"JSON": `{"earth": {"Gravity": 1, "Wiki": "https://en.wikipedia.org/wiki/Earth", "Description": "Our home, the third planet from the Sun."}}`,
+	}
+	for name, data := range files {
+		got, err := generator.Parse(ctx, filename, src, generator.Config{Data: map[string][]byte{"planets.yaml": []byte(data)}})
+		if err != nil {
+			t.Fatalf("%s: failed to parse, got %v", name, err)
+		}
+		if !reflect.DeepEqual(got.TypeInfo.NameTypePairs, want.TypeInfo.NameTypePairs) {
+			t.Errorf("%s: expected the fields %v, got %v", name, want.TypeInfo.NameTypePairs, got.TypeInfo.NameTypePairs)
+		}
+		for i := range want.Enums {
+			if !reflect.DeepEqual(got.Enums[i].TypeInfo.NameTypePairs, want.Enums[i].TypeInfo.NameTypePairs) {
+				t.Errorf("%s: expected the values %v, got %v", name, want.Enums[i].TypeInfo.NameTypePairs, got.Enums[i].TypeInfo.NameTypePairs)
+			}
+		}
+	}
+	invalid := map[string]string{
+		"Unknown constant": "venus:\n  Gravity: 0.9\n  Wiki: x\n",
+		"Unknown field":    "earth:\n  Gravity: 1\n  Wiki: x\n  Moons: 1\n",
+		"Missing field":    "earth:\n  Gravity: 1\n",
+		"Not a scalar":     "earth:\n  Gravity: [1]\n  Wiki: x\n",
+	}
+	for name, data := range invalid {
+		_, err := generator.Parse(ctx, filename, src, generator.Config{Data: map[string][]byte{"planets.yaml": []byte(data)}})
+		if !errors.Is(err, generator.ErrInvalidDirective) {
+			t.Errorf("%s: expected ErrInvalidDirective, got %v", name, err)
+		}
+	}
+	// generating next to the source reads the file, while Parse stays hermetic
+	if err := os.WriteFile(filename, src, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "planets.yaml"), []byte(files["YAML"]), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := generator.Parse(ctx, filename, src, generator.Config{}); !errors.Is(err, generator.ErrInvalidDirective) {
+		t.Errorf("Not in the config: expected ErrInvalidDirective, got %v", err)
+	}
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, generator.Config{}); err != nil {
+		t.Fatalf("failed to generate from the data file, got %v", err)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "planets_enums.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte("https://en.wikipedia.org/wiki/Earth")) {
+		t.Errorf("expected the generated file to hold the values of the data file, got\n%s", b)
+	}
+	if err := os.Remove(filepath.Join(dir, "planets.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, generator.Config{}); !errors.Is(err, generator.ErrInvalidDirective) {
+		t.Errorf("Missing file: expected ErrInvalidDirective, got %v", err)
+	}
+}
+
+func TestDerivedFields(t *testing.T) {
+	values := "type planet int // Gravity[float64],MassKg[float64],VolumeKm3[float64],Moons[int]\n\nconst (\n\tunknown planet = iota // invalid\n\tearth // Earth 1,5,2,1 desc=\"home\"\n\tjupiter // Jupiter 2.5,1e3,4,95\n)\n"
+	ctx := context.Background()
+	derived := "//goenums:derived Density = MassKg / VolumeKm3\n//goenums:derived Heavy = Gravity > 2\n//goenums:derived Doubled[float32] = Density * 2\n"
+	rep, err := generator.Parse(ctx, "planets.go", []byte("package planets\n\n"+derived+values), generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to parse, got %v", err)
+	}
+	var fields []string
+	for _, pair := range rep.TypeInfo.NameTypePairs {
+		fields = append(fields, pair.Name+" "+pair.Type)
+	}
+	if got := strings.Join(fields, ", "); !strings.HasSuffix(got, "Density float64, Heavy bool, Doubled float32, Description string") {
+		t.Errorf("expected the derived fields before the description, got %s", got)
+	}
+	want := map[string][]string{
+		"unknown": {"", "", ""},
+		"earth":   {"2.5", "false", "5"},
+		"jupiter": {"250", "true", "500"},
+	}
+	for _, e := range rep.Enums {
+		values := make(map[string]string)
+		for _, pair := range e.TypeInfo.NameTypePairs {
+			values[pair.Name] = pair.Value
+		}
+		got := []string{values["Density"], values["Heavy"], values["Doubled"]}
+		if !reflect.DeepEqual(got, want[e.Info.Name]) {
+			t.Errorf("expected %s to derive %v, got %v", e.Info.Name, want[e.Info.Name], got)
+		}
+	}
+	invalid := map[string]string{
+		"No expression":     "//goenums:derived Density\n",
+		"Existing field":    "//goenums:derived Moons = 2\n",
+		"Unknown field":     "//goenums:derived Density = MassKg / Radius\n",
+		"Mismatched type":   "//goenums:derived Total = MassKg + Moons\n",
+		"Division by zero":  "//goenums:derived Ratio = Moons / (Moons - Moons)\n",
+		"Not representable": "//goenums:derived Small[int8] = Moons * 2\n",
+	}
+	for name, directive := range invalid {
+		_, err := generator.Parse(ctx, "planets.go", []byte("package planets\n\n"+directive+values), generator.Config{})
+		if !errors.Is(err, generator.ErrInvalidDirective) {
+			t.Errorf("%s: expected ErrInvalidDirective, got %v", name, err)
+		}
+	}
+}
+
+func TestFieldAnnotations(t *testing.T) {
+	values := "\n\nconst (\n\tunknown planet = iota // invalid\n\tearth // Earth %s,1\n\tmars // Mars 3.7,2\n)\n"
+	ctx := context.Background()
+	schema := "type planet int // Gravity[float64,min=0,max=30,unit=m/s²],Moons(int, max=100)"
+	rep, err := generator.Parse(ctx, "planets.go", []byte("package planets\n\n"+schema+fmt.Sprintf(values, "9.8")), generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to parse, got %v", err)
+	}
+	gravity, moons := rep.TypeInfo.NameTypePairs[0], rep.TypeInfo.NameTypePairs[1]
+	if gravity.Type != "float64" || gravity.Unit != "m/s²" || gravity.Min != "0" || gravity.Max != "30" {
+		t.Errorf("expected Gravity to be a float64 in m/s² between 0 and 30, got %+v", gravity)
+	}
+	if moons.Type != "int" || moons.Max != "100" || len(rep.TypeInfo.NameTypePairs) != 2 {
+		t.Errorf("expected Moons to be an int of at most 100, got %+v", rep.TypeInfo.NameTypePairs)
+	}
+	if earth := rep.Enums[1].TypeInfo.NameTypePairs[0]; earth.Type != "float64" || earth.Value != "9.8" || earth.Unit != "m/s²" {
+		t.Errorf("expected the Gravity of earth to be 9.8 float64 in m/s², got %+v", earth)
+	}
+	files, err := generator.Generate(ctx, "planets.go", []byte("package planets\n\n"+schema+fmt.Sprintf(values, "9.8")), generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to generate, got %v", err)
+	}
+	if !bytes.Contains(files[0].Content, []byte("\t// Gravity is in m/s².\n\tGravity float64\n")) {
+		t.Errorf("expected the unit in the doc comment of Gravity, got\n%s", files[0].Content)
+	}
+	fields := "type planetData struct {\n\tGravity float64 `goenums:\"min=0,unit=m/s²\"`\n\tMoons int\n}\n\n//goenums:fields planetData\ntype planet int"
+	rep, err = generator.Parse(ctx, "planets.go", []byte("package planets\n\n"+fields+fmt.Sprintf(values, "9.8")), generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to parse the struct schema, got %v", err)
+	}
+	if gravity := rep.TypeInfo.NameTypePairs[0]; gravity.Unit != "m/s²" || gravity.Min != "0" {
+		t.Errorf("expected Gravity in m/s² of at least 0 from its tag, got %+v", gravity)
+	}
+	invalid := map[string]string{
+		"Below the minimum":  schema + fmt.Sprintf(values, "-1"),
+		"Above the maximum":  schema + fmt.Sprintf(values, "31"),
+		"Unknown annotation": "type planet int // Gravity[float64,step=1],Moons[int]" + fmt.Sprintf(values, "9.8"),
+		"Not a number":       "type planet int // Gravity[string,min=0],Moons[int]" + fmt.Sprintf(values, "\"high\""),
+		"Invalid bound":      "type planet int // Gravity[float64,min=low],Moons[int]" + fmt.Sprintf(values, "9.8"),
+		"Tag below minimum":  fields + fmt.Sprintf(values, "-9.8"),
+		"Derived":            "//goenums:derived Ratio[float64,max=2] = Gravity / 2\n" + schema + fmt.Sprintf(values, "9.8"),
+	}
+	for name, src := range invalid {
+		_, err := generator.Parse(ctx, "planets.go", []byte("package planets\n\n"+src), generator.Config{})
+		if !errors.Is(err, generator.ErrInvalidField) {
+			t.Errorf("%s: expected ErrInvalidField, got %v", name, err)
+		}
+	}
+}
+
+func TestDescriptionDirectiveValues(t *testing.T) {
+	// the desc= directive gives the Description field, the values the others
+	src := "package planets\n\ntype planet int // Gravity[float64],Description[string],Moons[int]\n\nconst (\n\tunknown planet = iota // invalid\n\tmars // Mars 0.377,2 desc=\"The red planet.\"\n\tearth // Earth 1,\"Home.\",1\n)\n"
+	rep, err := generator.Parse(context.Background(), "planets.go", []byte(src), generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to parse, got %v", err)
+	}
+	want := map[string][]string{
+		"mars":  {"0.377", `"The red planet."`, "2"},
+		"earth": {"1", `"Home."`, "1"},
+	}
+	for _, e := range rep.Enums[1:] {
+		var got []string
+		for _, pair := range e.TypeInfo.NameTypePairs {
+			got = append(got, pair.Value)
+		}
+		if !reflect.DeepEqual(got, want[e.Info.Name]) {
+			t.Errorf("expected %s to have the values %v, got %v", e.Info.Name, want[e.Info.Name], got)
+		}
+	}
+}
+
+func TestGeneratedDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{ Description() string }
+		expected string
+	}{
+		{name: "Mercury", value: descriptions.Planets.MERCURY, expected: "The smallest planet, and the closest to the Sun."},
+		{name: "Venus", value: descriptions.Planets.VENUS, expected: "Hottest planet; its clouds are (mostly) sulfuric acid!"},
+		{name: "Earth", value: descriptions.Planets.EARTH, expected: `Home, "sweet" home - not an invalid planet.`},
+		{name: "Mars", value: descriptions.Planets.MARS, expected: "The red planet: dusty, cold & thin-aired."},
+		{name: "Luna", value: descriptionsdirective.Moons.LUNA, expected: "Earth's only natural satellite."},
+		{name: "Phobos", value: descriptionsdirective.Moons.PHOBOS, expected: "The larger, inner moon of Mars."},
+		{name: "Deimos", value: descriptionsdirective.Moons.DEIMOS, expected: ""},
+	}
+	for _, tc := range tests {
+		if got := tc.value.(fmt.Stringer).String(); got != tc.name {
+			t.Errorf("expected name %s, got %s", tc.name, got)
+		}
+		if got := tc.value.Description(); got != tc.expected {
+			t.Errorf("expected %s description %q, got %q", tc.name, tc.expected, got)
+		}
+	}
+	if descriptions.Planets.EARTH.Gravity != 1 {
+		t.Errorf("expected Earth gravity 1, got %v", descriptions.Planets.EARTH.Gravity)
+	}
+}
+
+func TestGeneratedAccessors(t *testing.T) {
+	earth := accessors.Planets.EARTH
+	if earth.Gravity() != 1 {
+		t.Errorf("expected Earth gravity 1, got %v", earth.Gravity())
+	}
+	if earth.Moons() != 1 {
+		t.Errorf("expected Earth to have 1 moon, got %v", earth.Moons())
+	}
+	if !accessors.Planets.SATURN.Rings() {
+		t.Errorf("expected Saturn to have rings")
+	}
+	got := fmt.Sprintf("%+v", accessors.Planets.MARS)
+	expected := "Mars{Gravity: 0.377, RadiusKm: 3389.5, MassKg: 6.42e+23, OrbitKm: 2.279e+08, OrbitDays: 687, SurfacePressureBars: 0.01, Moons: 2, Rings: false}"
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestGeneratedImmutable(t *testing.T) {
+	statuses := immutable.Statuses()
+	statuses.PASSED = immutable.Status{}
+	if immutable.Statuses().PASSED.String() != "passed" {
+		t.Errorf("expected modifying a copy to leave the container untouched, got %v", immutable.Statuses().PASSED)
+	}
+	s, err := immutable.ParseStatus("booked")
+	if err != nil {
+		t.Fatalf("failed to parse, got %v", err)
+	}
+	if s != immutable.Statuses().BOOKED {
+		t.Errorf("expected %v, got %v", immutable.Statuses().BOOKED, s)
+	}
+	if got := fmt.Sprintf("%#v", s); got != "Statuses().BOOKED" {
+		t.Errorf("expected Statuses().BOOKED, got %s", got)
+	}
+}
+
+func TestGeneratedShared(t *testing.T) {
+	expected, err := shared.ParseStatus(2)
+	if err != nil {
+		t.Fatalf("failed to parse int, got %v", err)
+	}
+	for _, v := range []any{2, int8(2), int16(2), int32(2), int64(2), uint(2), uint8(2), uint16(2), uint32(2), uint64(2)} {
+		s, err := shared.ParseStatus(v)
+		if err != nil {
+			t.Fatalf("failed to parse %T, got %v", v, err)
+		}
+		if s != expected {
+			t.Errorf("expected %T(2) to parse as %v, got %v", v, expected, s)
+		}
+		o, err := shared.ParseOrder(v)
+		if err != nil {
+			t.Fatalf("failed to parse %T, got %v", v, err)
+		}
+		if o != shared.Orders.PROCESSING {
+			t.Errorf("expected %T(2) to parse as %v, got %v", v, shared.Orders.PROCESSING, o)
+		}
+	}
+}
+
+func TestGeneratedSentinel(t *testing.T) {
+	s, err := sentinel.ParseStatus("bogus")
+	if err != nil {
+		t.Fatalf("failed to parse, got %v", err)
+	}
+	if s != sentinel.StatusInvalid {
+		t.Errorf("expected %v, got %v", sentinel.StatusInvalid, s)
+	}
+	if !s.IsZero() || !sentinel.Statuses.UNKNOWN.IsZero() {
+		t.Errorf("expected the sentinel to be zero")
+	}
+	if sentinel.Statuses.UNKNOWN.IsValid() {
+		t.Errorf("expected the sentinel to be invalid")
+	}
+	for _, v := range []sentinel.Status{sentinel.Statuses.ACTIVE, sentinel.Statuses.INACTIVE, sentinel.Statuses.PENDING} {
+		if !v.IsValid() || v.IsZero() {
+			t.Errorf("expected %v to be valid and not zero", v)
+		}
+	}
+	if len(sentinel.Statuses.All()) != 3 {
+		t.Errorf("expected 3 valid statuses, got %d", len(sentinel.Statuses.All()))
+	}
+}
+
+func TestGeneratedAllWithInvalid(t *testing.T) {
+	all := validation.Statuses.AllWithInvalid()
+	if len(all) != 6 || all[0].IsValid() || !slices.Equal(all[1:], validation.Statuses.All()) {
+		t.Errorf("expected the invalid status followed by the valid ones, got %v", all)
+	}
+	var visited []sentinel.Status
+	sentinel.ExhaustiveStatussIncludingInvalid(func(s sentinel.Status) {
+		visited = append(visited, s)
+	})
+	expected := []sentinel.Status{sentinel.Statuses.ACTIVE, sentinel.Statuses.INACTIVE, sentinel.Statuses.UNKNOWN, sentinel.Statuses.PENDING}
+	if !slices.Equal(visited, expected) {
+		t.Errorf("expected %v, got %v", expected, visited)
+	}
+	// invalid constants the container leaves zero are built from the constant
+	src := "package states\n\ntype state int\n\nconst (\n\tactive state = iota\n\tretired // invalid\n\tpending\n)\n"
+	files, err := generator.Generate(context.Background(), "states.go", []byte(src), generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	if !strings.Contains(string(files[0].Content), "\t\tc.ACTIVE,\n\t\t{state: retired},\n\t\tc.PENDING,\n") {
+		t.Errorf("expected AllWithInvalid to hold retired")
+	}
+}
+
+func TestGeneratedCount(t *testing.T) {
+	all := validation.Statuses.All()
+	if got := validation.Statuses.Count(); got != len(all) {
+		t.Errorf("expected %d statuses, got %d", len(all), got)
+	}
+	if got := validation.Statuses.First(); got != validation.Statuses.PASSED {
+		t.Errorf("expected the first valid status %v, got %v", validation.Statuses.PASSED, got)
+	}
+	if got := validation.Statuses.Last(); got != validation.Statuses.BOOKED {
+		t.Errorf("expected the last status %v, got %v", validation.Statuses.BOOKED, got)
+	}
+	if got := immutable.Statuses().Count(); got != len(immutable.Statuses().All()) {
+		t.Errorf("expected the immutable container to count %d, got %d", len(immutable.Statuses().All()), got)
+	}
+}
+
+func TestGeneratedOrdinal(t *testing.T) {
+	for i, d := range sale.DiscountTypes.All() {
+		if got := d.Ordinal(); got != i {
+			t.Errorf("expected %v at %d, got %d", d, i, got)
+		}
+	}
+	if got := validation.Statuses.PASSED.Ordinal(); got != 1 {
+		t.Errorf("expected %v after the invalid value at 1, got %d", validation.Statuses.PASSED, got)
+	}
+	var undeclared sale.DiscountType
+	if got := undeclared.Ordinal(); got != -1 {
+		t.Errorf("expected an undeclared value at -1, got %d", got)
+	}
+}
+
+func TestGeneratedUnderlying(t *testing.T) {
+	if got := int(sale.DiscountTypes.SALE.Underlying()); got != 1 {
+		t.Errorf("expected the constant 1 under %v, got %d", sale.DiscountTypes.SALE, got)
+	}
+	if got := int(validation.Statuses.BOOKED.Underlying()); got != 5 {
+		t.Errorf("expected the constant 5 under %v, got %d", validation.Statuses.BOOKED, got)
+	}
+}
+
+func TestGeneratedWrap(t *testing.T) {
+	for _, p := range planets.Planets.All() {
+		got, err := planets.WrapPlanet(p.Underlying())
+		if err != nil {
+			t.Fatalf("unexpected error wrapping %v: %v", p, err)
+		}
+		if got != p {
+			t.Errorf("expected %v, got %v", p, got)
+		}
+	}
+	invalid := planets.Planets.AllWithInvalid()[0]
+	if _, err := planets.WrapPlanet(invalid.Underlying()); err == nil {
+		t.Errorf("expected an error wrapping %v", invalid)
+	}
+	var undeclared validation.Status
+	if _, err := validation.WrapStatus(undeclared.Underlying() + 10); err == nil {
+		t.Errorf("expected an error wrapping an undeclared constant")
+	}
+}
+
+func TestGeneratedAs(t *testing.T) {
+	for _, p := range planets.Planets.All() {
+		if got := planets.AsPlanet(p.Base()); got != p {
+			t.Errorf("expected %v, got %v", p, got)
+		}
+	}
+	var undeclared validation.Status
+	if got := validation.AsStatus(undeclared.Base() + 10); got.IsValid() {
+		t.Errorf("expected the invalid Status for an undeclared constant, got %v", got)
+	}
+}
+
+func TestFindBaseUsage(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":                "module example.com/app\n\ngo 1.22\n",
+		"order/order.go":        "package order\n\ntype order int\n\nconst (\n\tcreated order = iota\n\tshipped\n)\n",
+		"order/orders_enums.go": "// Code generated by goenums. DO NOT EDIT.\n\npackage order\n\nvar _ = created\n",
+		"order/ship.go":         "package order\n\nfunc ship(o order) bool {\n\treturn o == shipped\n}\n\nfunc local() {\n\tshipped := 1\n\t_ = shipped\n}\n",
+		"order/order_test.go":   "package order\n\nvar first = Orders.CREATED\n\nvar raw = created\n",
+		"order/extern_test.go":  "package order_test\n\nvar created = 1\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create %s, got %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatalf("failed to write %s, got %v", path, err)
+		}
+	}
+	candidates, err := generator.FindEnums(root)
+	if err != nil {
+		t.Fatalf("failed to find enums, got %v", err)
+	}
+	usages, err := generator.FindBaseUsage(context.Background(), candidates, func(generator.Candidate) (generator.Config, error) {
+		return generator.Config{}, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to find base usage, got %v", err)
+	}
+	// the source, generated and external test files and shadowed names are not reported
+	expected := []string{
+		filepath.Join(root, "order/order_test.go") + ":5:11: use Orders.CREATED instead of created",
+		filepath.Join(root, "order/ship.go") + ":3:13: use Order instead of order",
+		filepath.Join(root, "order/ship.go") + ":4:14: use Orders.SHIPPED instead of shipped",
+	}
+	if len(usages) != len(expected) {
+		t.Fatalf("expected %d usages, got %v", len(expected), usages)
+	}
+	for i, u := range usages {
+		if got := u.String(); got != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], got)
+		}
+	}
+}
+
+func TestGeneratedZeroHelpers(t *testing.T) {
+	var unset validation.Status
+	if !unset.IsZero() || unset.IsSet() {
+		t.Errorf("expected the zero value to be zero and unset")
+	}
+	if validation.Statuses.PASSED.IsZero() || !validation.Statuses.PASSED.IsSet() {
+		t.Errorf("expected %v to be set", validation.Statuses.PASSED)
+	}
+	if orders.Orders.CREATED.IsZero() || !orders.Orders.CREATED.IsValid() {
+		t.Errorf("expected %v, a valid zero value, to be set", orders.Orders.CREATED)
+	}
+	p := validation.Statuses.BOOKED.Ptr()
+	if p == nil || *p != validation.Statuses.BOOKED {
+		t.Errorf("expected pointer to %v, got %v", validation.Statuses.BOOKED, p)
+	}
+	*p = validation.Statuses.RUNNING
+	if validation.Statuses.BOOKED.String() != "booked" {
+		t.Errorf("expected Ptr to point to a copy")
+	}
+}
+
+func TestGeneratedEmptyInvalid(t *testing.T) {
+	type request struct {
+		Status   emptyinvalid.Status `json:"status"`
+		Optional emptyinvalid.Status `json:"optional"`
+	}
+	b, err := json.Marshal(request{})
+	if err != nil {
+		t.Fatalf("failed to marshal, got %v", err)
+	}
+	if string(b) != `{"status":"","optional":""}` {
+		t.Errorf("expected the invalid statuses to marshal empty, got %s", b)
+	}
+	b, err = json.Marshal(request{Status: emptyinvalid.Statuses.PASSED, Optional: emptyinvalid.Statuses.BOOKED})
+	if err != nil {
+		t.Fatalf("failed to marshal, got %v", err)
+	}
+	if string(b) != `{"status":"passed","optional":"booked"}` {
+		t.Errorf("unexpected json, got %s", b)
+	}
+	b, err = json.Marshal(emptyinvalid.Colors.RED)
+	if err != nil || string(b) != `"red"` {
+		t.Errorf("expected the valid zero value to marshal its name, got %s, %v", b, err)
+	}
+	for _, in := range []string{`{"status":""}`, `{"status":null}`, `{}`} {
+		r := request{Status: emptyinvalid.Statuses.PASSED}
+		if in == `{}` {
+			r.Status = emptyinvalid.Status{}
+		}
+		err = json.Unmarshal([]byte(in), &r)
+		if err != nil {
+			t.Fatalf("failed to unmarshal %s, got %v", in, err)
+		}
+		if !r.Status.IsZero() {
+			t.Errorf("expected %s to unmarshal to the invalid status, got %v", in, r.Status)
+		}
+	}
+}
+
+func TestGeneratedSQLInt(t *testing.T) {
+	for _, s := range sqlint.Statuses.All() {
+		v, err := s.Value()
+		if err != nil {
+			t.Fatalf("failed to get value, got %v", err)
+		}
+		if _, ok := v.(int64); !ok {
+			t.Fatalf("expected an int64 value, got %T", v)
+		}
+		for _, scanned := range []any{v, []byte(fmt.Sprint(v)), fmt.Sprint(v), s.String()} {
+			var got sqlint.Status
+			if err := got.Scan(scanned); err != nil {
+				t.Fatalf("failed to scan %v, got %v", scanned, err)
+			}
+			if got != s {
+				t.Errorf("expected %T %v to scan as %v, got %v", scanned, scanned, s, got)
+			}
+		}
+	}
+	var got sqlint.Status
+	if err := got.Scan(int64(0)); err != nil || got.IsValid() {
+		t.Errorf("expected the invalid value to scan as invalid, got %v, %v", got, err)
+	}
+	// without -sqlint integers index the valid values
+	if p, err := validation.ParseStatus(0); err != nil || p != validation.Statuses.PASSED {
+		t.Errorf("expected 0 to parse as the first valid value, got %v, %v", p, err)
+	}
+}
+
+func TestGeneratedSQLConstraint(t *testing.T) {
+	expected := "CHECK (status IN ('passed', 'skipped', 'scheduled', 'running', 'booked'))"
+	if got := validation.StatusCheckConstraint("status"); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	expected = "CHECK (status IN (1, 2, 3, 4, 5, 6))"
+	if got := sqlint.StatusCheckConstraint("status"); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	if orders.OrderSQLValues != "'CREATED', 'APPROVED', 'PROCESSING', 'READY_TO_SHIP', 'SHIPPED', 'DELIVERED', 'CANCELLED'" {
+		t.Errorf("unexpected order values %s", orders.OrderSQLValues)
+	}
+}
+
+func TestGeneratedMarshalInvalid(t *testing.T) {
+	for _, p := range []marshalinvalid.Status{marshalinvalid.Statuses.UNKNOWN, {}} {
+		if _, err := json.Marshal(p); err == nil {
+			t.Errorf("expected json error marshaling %v", p)
+		}
+		if _, err := p.MarshalYAML(); err == nil {
+			t.Errorf("expected yaml error marshaling %v", p)
+		}
+		if _, err := p.Value(); err == nil {
+			t.Errorf("expected value error marshaling %v", p)
+		}
+	}
+	b, err := json.Marshal(marshalinvalid.Statuses.PASSED)
+	if err != nil || string(b) != `"passed"` {
+		t.Errorf("expected \"passed\", got %s, %v", b, err)
+	}
+}
+
+func TestGeneratedSparse(t *testing.T) {
+	tests := []struct {
+		input    any
+		expected sparse.Status
+		wantErr  bool
+	}{
+		{input: "active", expected: sparse.Statuses.ACTIVE},
+		{input: 0, expected: sparse.Statuses.ACTIVE},
+		{input: 1, expected: sparse.Statuses.PAUSED},
+		{input: "archived", expected: sparse.Statuses.ARCHIVED},
+		{input: 4, expected: sparse.Statuses.ARCHIVED},
+		{input: 2, wantErr: true},
+		{input: 3, wantErr: true},
+		{input: 5, wantErr: true},
+		{input: "bogus", wantErr: true},
+	}
+	for _, tc := range tests {
+		got, err := sparse.ParseStatus(tc.input)
+		if (err != nil) != tc.wantErr || !tc.wantErr && got != tc.expected {
+			t.Errorf("expected %v for %v, got %v, %v", tc.expected, tc.input, got, err)
+		}
+		if tc.wantErr && (got.IsValid() || got == sparse.Statuses.ACTIVE) {
+			t.Errorf("expected an invalid value for %v, got %v", tc.input, got)
+		}
+	}
+	for _, p := range sparse.Statuses.All() {
+		b, err := json.Marshal(p)
+		if err != nil || string(b) != strconv.Quote(p.String()) {
+			t.Errorf("expected %q marshaling %v, got %s, %v", p.String(), p, b, err)
+		}
+	}
+	if got := sparse.Statuses.ARCHIVED.String(); got != "archived" {
+		t.Errorf("expected archived, got %s", got)
+	}
+}
+
+func TestGeneratedBlankFirst(t *testing.T) {
+	// a blank first constant leaves the first value of the name index empty
+	type status interface {
+		fmt.Stringer
+		IsValid() bool
+	}
+	parsers := map[string]func(any) (status, error){
+		"blankfirst": func(a any) (status, error) { return blankfirst.ParseStatus(a) },
+		"blankgap":   func(a any) (status, error) { return blankgap.ParseStatus(a) },
+	}
+	values := map[string]map[string]int{
+		"blankfirst": {"active": 1, "archived": 2},
+		"blankgap":   {"active": 2, "archived": 4},
+	}
+	for pkg, parse := range parsers {
+		for name, value := range values[pkg] {
+			for _, input := range []any{name, value} {
+				got, err := parse(input)
+				if err != nil || !got.IsValid() || got.String() != name {
+					t.Errorf("%s: expected %s for %v, got %v, %v", pkg, name, input, got, err)
+				}
+				b, err := json.Marshal(got)
+				if err != nil || string(b) != strconv.Quote(name) {
+					t.Errorf("%s: expected %q marshaling %v, got %s, %v", pkg, name, input, b, err)
+				}
+			}
+		}
+		for _, input := range []any{0, 3, "_"} {
+			if got, err := parse(input); err == nil && got.IsValid() {
+				t.Errorf("%s: expected no value for %v, got %v", pkg, input, got)
+			}
+		}
+	}
+	if got := blankfirst.Statuses.ACTIVE.String(); got != "active" {
+		t.Errorf("expected active, got %s", got)
+	}
+	if got := blankgap.Statuses.ARCHIVED.String(); got != "archived" {
+		t.Errorf("expected archived, got %s", got)
+	}
+}
+
+func TestGeneratedBitFlags(t *testing.T) {
+	flags := map[int]bitflags.Flag{1: bitflags.Flags.READ, 2: bitflags.Flags.WRITE, 4: bitflags.Flags.EXEC}
+	for value, flag := range flags {
+		got, err := bitflags.ParseFlag(value)
+		if err != nil || got != flag {
+			t.Errorf("expected %v for %d, got %v, %v", flag, value, got, err)
+		}
+		if got, err := bitflags.ParseFlag(flag.String()); err != nil || got != flag {
+			t.Errorf("expected %v for %q, got %v, %v", flag, flag.String(), got, err)
+		}
+	}
+	if got := bitflags.Flags.EXEC.String(); got != "exec" {
+		t.Errorf("expected exec, got %s", got)
+	}
+	// the wide flags are named from a switch rather than tables up to the last value
+	for i, p := range bitflagswide.Perms.All() {
+		if got := int(p.Underlying()); got != 1<<i {
+			t.Errorf("expected %v to be %d, got %d", p, 1<<i, got)
+		}
+		got, err := bitflagswide.ParsePerm(1 << i)
+		if err != nil || got != p {
+			t.Errorf("expected %v for %d, got %v, %v", p, 1<<i, got, err)
+		}
+		b, err := json.Marshal(p)
+		if err != nil || string(b) != strconv.Quote(p.String()) {
+			t.Errorf("expected %q marshaling %v, got %s, %v", p.String(), p, b, err)
+		}
+	}
+	if got := bitflagswide.Perms.OWNER.String(); got != "owner" {
+		t.Errorf("expected owner, got %s", got)
+	}
+	if got, _ := bitflagswide.ParsePerm(3); got.IsValid() {
+		t.Errorf("expected no valid value for 3, got %v", got)
+	}
+	var names []string
+	for i := range 30 {
+		names = append(names, fmt.Sprintf("flag%d", i))
+	}
+	src := "package perms\n\ntype perm int\n\nconst (\n\t" + names[0] + " perm = 1 << iota\n\t" + strings.Join(names[1:], "\n\t") + "\n)\n"
+	files, err := generator.Generate(context.Background(), "perms.go", []byte(src), generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to generate 30 flags, got %v", err)
+	}
+	if size := len(files[0].Content); size > 64<<10 {
+		t.Errorf("expected 30 flags to generate less than 64KiB, got %d bytes", size)
+	}
+}
+
+func TestGeneratedGrouped(t *testing.T) {
+	// constants declared several to a spec each take the value of their own expression
+	expected := map[int]grouped.Status{
+		0: grouped.Statuses.PENDING,
+		1: grouped.Statuses.ACTIVE,
+		2: grouped.Statuses.PAUSED,
+		3: grouped.Statuses.CLOSED,
+		5: grouped.Statuses.ARCHIVED,
+	}
+	for input, want := range expected {
+		got, err := grouped.ParseStatus(input)
+		if err != nil || got != want {
+			t.Errorf("expected %v for %d, got %v, %v", want, input, got, err)
+		}
+	}
+	if _, err := grouped.ParseStatus(4); err == nil {
+		t.Errorf("expected an error parsing the blank value 4")
+	}
+	if got := len(grouped.Statuses.All()); got != 5 {
+		t.Errorf("expected 5 values, got %d", got)
+	}
+	src := "package order\n\ntype order int\n\nconst (\n\tcreated, shipped, lost order = iota, iota, iota\n)\n"
+	if _, err := generator.Parse(context.Background(), "order.go", []byte(src), generator.Config{}); !errors.Is(err, generator.ErrDuplicateValue) {
+		t.Errorf("expected ErrDuplicateValue for names given the same iota, got %v", err)
+	}
+}
+
+func TestGeneratedFloatInput(t *testing.T) {
+	tests := []struct {
+		input    any
+		integral string
+		round    string
+		rejected string
+	}{
+		{input: 1, integral: "skipped", round: "skipped", rejected: "skipped"},
+		{input: 1.0, integral: "skipped", round: "skipped"},
+		{input: float32(2), integral: "scheduled", round: "scheduled"},
+		{input: 1.4, round: "skipped"},
+		{input: 1.5, round: "scheduled"},
+		{input: math.NaN()},
+		{input: math.Inf(1)},
+		{input: 1e300},
+	}
+	for _, tc := range tests {
+		integral, err := floatintegral.ParseStatus(tc.input)
+		if (err == nil) != (tc.integral != "") || err == nil && integral.String() != tc.integral {
+			t.Errorf("expected integral %q for %v, got %v, %v", tc.integral, tc.input, integral, err)
+		}
+		round, err := floatround.ParseStatus(tc.input)
+		if (err == nil) != (tc.round != "") || err == nil && round.String() != tc.round {
+			t.Errorf("expected round %q for %v, got %v, %v", tc.round, tc.input, round, err)
+		}
+		// validation parses leniently, returning the invalid value for floats
+		rejected, err := validation.ParseStatus(tc.input)
+		if err != nil || rejected.IsValid() != (tc.rejected != "") || rejected.IsValid() && rejected.String() != tc.rejected {
+			t.Errorf("expected rejected %q for %v, got %v, %v", tc.rejected, tc.input, rejected, err)
+		}
+	}
+}
+
+func TestGeneratedBaseMethods(t *testing.T) {
+	tests := []struct {
+		input    any
+		expected basemethods.Status
+		wantErr  bool
+	}{
+		{input: "Active", expected: basemethods.Active},
+		{input: "enabled", expected: basemethods.Active},
+		{input: []byte("Archived"), expected: basemethods.Archived},
+		{input: 2, expected: basemethods.Suspended},
+		{input: basemethods.Archived, expected: basemethods.Archived},
+		{input: "Unknown", wantErr: true},
+		{input: 0, wantErr: true},
+		{input: 257, wantErr: true},
+		{input: "bogus", wantErr: true},
+		{input: 2.0, wantErr: true},
+	}
+	for _, tc := range tests {
+		got, err := basemethods.ParseStatus(tc.input)
+		if (err != nil) != tc.wantErr || !tc.wantErr && got != tc.expected {
+			t.Errorf("expected %v for %v, got %v, %v", tc.expected, tc.input, got, err)
+		}
+	}
+	var v struct {
+		Status basemethods.Status `json:"status"`
+	}
+	if err := json.Unmarshal([]byte(`{"status":"enabled"}`), &v); err != nil || v.Status != basemethods.Active {
+		t.Errorf("expected Active unmarshaling, got %v, %v", v.Status, err)
+	}
+	b, err := json.Marshal(v)
+	if err != nil || string(b) != `{"status":"Active"}` {
+		t.Errorf("expected Active marshaling, got %s, %v", b, err)
+	}
+	if !basemethods.Suspended.IsValid() || basemethods.Unknown.IsValid() || basemethods.Status(9).IsValid() {
+		t.Error("expected only the valid constants to be valid")
+	}
+	if value, err := basemethods.Archived.Value(); err != nil || value != "Archived" {
+		t.Errorf("expected Archived stored, got %v, %v", value, err)
+	}
+}
+
+func TestGenerateStringer(t *testing.T) {
+	src := []byte("package sale\n\ntype discountType int\n\nconst (\n\tsale discountType = iota + 1\n\t_\n\tgiveaway\n)\n")
+	files, err := generator.Generate(context.Background(), "discount.go", src, generator.Config{Stringer: true})
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	if len(files) != 1 || files[0].Name != "discounttype_string.go" {
+		t.Fatalf("expected only discounttype_string.go, got %v", files)
+	}
+	for _, e := range []string{
+		"const _discountType_name = \"salegiveaway\"\n",
+		"var _discountType_index = [...]uint8{0, 4, 4, 12}\n",
+		"\ti -= 1\n",
+		"|| _discountType_index[i] == _discountType_index[i+1] {\n",
+		"return \"discountType(\" + strconv.FormatInt(int64(i+1), 10) + \")\"\n",
+	} {
+		if !strings.Contains(string(files[0].Content), e) {
+			t.Errorf("expected generated file to contain %q", e)
+		}
+	}
+	fields, err := os.ReadFile("testdata/planets/planets.go")
+	if err != nil {
+		t.Fatalf("failed to read source, got %v", err)
+	}
+	if _, err := generator.Generate(context.Background(), "planets.go", fields, generator.Config{Stringer: true}); err == nil {
+		t.Error("expected an error generating an enum with extra values in stringer mode")
+	}
+}
+
+func TestGeneratedAppendJSON(t *testing.T) {
+	type appender interface {
+		json.Marshaler
+		AppendJSON([]byte) []byte
+	}
+	var values []appender
+	tags.ExhaustiveStatussIncludingInvalid(func(p tags.Status) { values = append(values, p) })
+	emptyinvalid.ExhaustiveStatussIncludingInvalid(func(p emptyinvalid.Status) { values = append(values, p) })
+	sale.ExhaustiveDiscountTypesIncludingInvalid(func(p sale.DiscountType) { values = append(values, p) })
+	buf := make([]byte, 0, 64)
+	for _, p := range values {
+		expected, err := p.MarshalJSON()
+		if err != nil {
+			t.Fatalf("failed to marshal %v, got %v", p, err)
+		}
+		if got := p.AppendJSON([]byte("x")); string(got) != "x"+string(expected) {
+			t.Errorf("expected x%s appending %v, got %s", expected, p, got)
+		}
+		allocs := testing.AllocsPerRun(10, func() {
+			buf = p.AppendJSON(buf[:0])
+		})
+		if allocs != 0 {
+			t.Errorf("expected appending %s not to allocate, got %v allocations", expected, allocs)
+		}
+	}
+}
+
+func TestGenerateMarshalInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   generator.Config
+		expected []string
+	}{
+		{
+			name:   "Number",
+			config: generator.Config{MarshalInvalid: generator.MarshalInvalidNumber},
+			expected: []string{
+				"\treturn strconv.Itoa(int(p.status)), nil\n",
+				"\tname, err := p.marshalName(p.String())\n",
+				"\t\tdst = strconv.AppendInt(append(dst, '\"'), int64(p.status), 10)\n",
+			},
+		},
+		{
+			name:   "Placeholder",
+			config: generator.Config{InvalidPlaceholder: "n/a", Pgx: true},
+			expected: []string{
+				"\treturn \"n/a\", nil\n",
+				"\t\treturn pgtype.Text{}, err\n",
+				"\t\treturn append(dst, `\"n/a\"`...)\n",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filename := copyToTempDir(t, "testdata/validation/status.go")
+			err := generator.ParseAndGenerateWithConfig(context.Background(), filename, tc.config)
+			if err != nil {
+				t.Fatalf("failed to generate enums, got %v", err)
+			}
+			b, err := os.ReadFile(filepath.Join(filepath.Dir(filename), "statuses_enums.go"))
+			if err != nil {
+				t.Fatalf("failed to read generated file, got %v", err)
+			}
+			for _, e := range tc.expected {
+				if !strings.Contains(string(b), e) {
+					t.Errorf("expected generated file to contain %q", e)
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidMarshalInvalidConfig(t *testing.T) {
+	configs := []generator.Config{
+		{MarshalInvalid: "panic"},
+		{MarshalInvalid: generator.MarshalInvalidError, InvalidPlaceholder: "n/a"},
+		{InvalidPlaceholder: `say "what"`},
+	}
+	for _, cfg := range configs {
+		err := generator.ParseAndGenerateWithConfig(context.Background(), "testdata/validation/status.go", cfg)
+		if !errors.Is(err, generator.ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig for %+v, got %v", cfg, err)
+		}
+	}
+}
+
+func TestRoundTripCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		config  generator.Config
+		wantErr bool
+	}{
+		{name: "Valid", value: `passed // json:"PASSED" parse:"ok"`, config: generator.Config{Insensitive: true}},
+		{name: "Escaped", value: `passed // json:"say \"passed\""`},
+		{name: "Trimmed", value: `passed // db:" passed"`, wantErr: true},
+		{name: "Empty", value: `passed // json:""`, config: generator.Config{EmptyInvalid: true}, wantErr: true},
+		{name: "Placeholder", value: "passed", config: generator.Config{InvalidPlaceholder: "skipped"}, wantErr: true},
+		{name: "InvalidPlaceholder", value: "passed", config: generator.Config{InvalidPlaceholder: "failed"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			src, err := os.ReadFile("testdata/validation/status.go")
+			if err != nil {
+				t.Fatalf("failed to read source, got %v", err)
+			}
+			src = []byte(strings.Replace(string(src), "\tpassed\n", "\t"+tc.value+"\n", 1))
+			filename := filepath.Join(t.TempDir(), "status.go")
+			if err := os.WriteFile(filename, src, 0o644); err != nil {
+				t.Fatalf("failed to write %s, got %v", filename, err)
+			}
+			tc.config.RoundTripCheck = true
+			err = generator.ParseAndGenerateWithConfig(context.Background(), filename, tc.config)
+			if tc.wantErr && !errors.Is(err, generator.ErrRoundTrip) {
+				t.Errorf("expected ErrRoundTrip, got %v", err)
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGenerateTestFile(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/testonly/fixture_test.go")
+	dir := filepath.Dir(filename)
+	err := generator.ParseAndGenerateWithConfig(context.Background(), filename, generator.Config{Shared: true, JSONv2: true, Lock: true})
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read %s, got %v", dir, err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	expected := []string{"fixture_test.enums.lock", "fixture_test.go", "fixtures_enums_jsonv2_test.go", "fixtures_enums_test.go"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected files %v, got %v", expected, got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/validation/status.go")
+	ctx := context.Background()
+	if err := generator.Check(ctx, filename, generator.Config{}); !errors.Is(err, generator.ErrStale) {
+		t.Errorf("expected ErrStale before generating, got %v", err)
+	}
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, generator.Config{}); err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	if err := generator.Check(ctx, filename, generator.Config{}); err != nil {
+		t.Errorf("expected generated enums to be up to date, got %v", err)
+	}
+	if err := generator.Check(ctx, filename, generator.Config{Failfast: true}); !errors.Is(err, generator.ErrStale) {
+		t.Errorf("expected ErrStale with other options, got %v", err)
+	}
+	quoted := generator.Config{InvalidPlaceholder: "not set -jsonv2"}
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, quoted); err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	generated, err := os.ReadFile(filepath.Join(filepath.Dir(filename), "statuses_enums.go"))
+	if err != nil || !strings.Contains(string(generated), "goenums -invalid-placeholder 'not set -jsonv2' ") {
+		t.Errorf("expected the placeholder quoted in the command, got %v\n%s", err, generated)
+	}
+	if err := generator.Check(ctx, filename, quoted); err != nil {
+		t.Errorf("expected generated enums to be up to date, got %v", err)
+	}
+	split := generator.Config{InvalidPlaceholder: "not set", JSONv2: true}
+	if err := generator.Check(ctx, filename, split); !errors.Is(err, generator.ErrStale) {
+		t.Errorf("expected ErrStale with -jsonv2 split from the placeholder, got %v", err)
+	}
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, generator.Config{}); err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read source, got %v", err)
+	}
+	reformatted := strings.Replace(string(src), "failed status = iota", "failed   status   =   iota", 1) + "\nfunc helper() {}\n"
+	if err := os.WriteFile(filename, []byte(reformatted), 0o644); err != nil {
+		t.Fatalf("failed to write source, got %v", err)
+	}
+	if err := generator.Check(ctx, filename, generator.Config{}); err != nil {
+		t.Errorf("expected reformatting and other declarations to be ignored, got %v", err)
+	}
+	changed := strings.Replace(reformatted, "\tbooked\n", "\tbooked\n\tcancelled\n", 1)
+	if err := os.WriteFile(filename, []byte(changed), 0o644); err != nil {
+		t.Fatalf("failed to write source, got %v", err)
+	}
+	if err := generator.Check(ctx, filename, generator.Config{}); !errors.Is(err, generator.ErrStale) {
+		t.Errorf("expected ErrStale after adding a value, got %v", err)
+	}
+}
+
+func TestCheckFieldsStruct(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "planets.go")
+	src := "package planets\n\ntype planetData struct {\n\tGravity float64\n}\n\n//goenums:fields planetData\ntype planet int\n\nconst (\n\tunknown planet = iota // invalid\n\tearth // Earth 1\n)\n"
+	if err := os.WriteFile(filename, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, generator.Config{}); err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	if err := generator.Check(ctx, filename, generator.Config{}); err != nil {
+		t.Errorf("expected generated enums to be up to date, got %v", err)
+	}
+	changed := strings.Replace(src, "Gravity float64", "Gravity int", 1)
+	if err := os.WriteFile(filename, []byte(changed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := generator.Check(ctx, filename, generator.Config{}); !errors.Is(err, generator.ErrStale) {
+		t.Errorf("expected ErrStale after changing the fields struct, got %v", err)
+	}
+}
+
+func TestGenerateManifest(t *testing.T) {
+	statuses := copyToTempDir(t, "testdata/validation/status.go")
+	dir := filepath.Dir(statuses)
+	orders := filepath.Join(dir, "orders.go")
+	src, err := os.ReadFile("testdata/orders/orders.go")
+	if err != nil {
+		t.Fatalf("failed to read source, got %v", err)
+	}
+	src = []byte(strings.Replace(string(src), "package orders", "package validation", 1))
+	if err := os.WriteFile(orders, src, 0o644); err != nil {
+		t.Fatalf("failed to write %s, got %v", orders, err)
+	}
+	ctx := context.Background()
+	for _, run := range []struct {
+		filename string
+		config   generator.Config
+	}{
+		{statuses, generator.Config{Manifest: true}},
+		{orders, generator.Config{Manifest: true}},
+		{statuses, generator.Config{Manifest: true, Failfast: true, Lock: true}},
+	} {
+		if err := generator.ParseAndGenerateWithConfig(ctx, run.filename, run.config); err != nil {
+			t.Fatalf("failed to generate enums for %s, got %v", run.filename, err)
+		}
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "goenums-manifest.json"))
+	if err != nil {
+		t.Fatalf("failed to read manifest, got %v", err)
+	}
+	var m struct {
+		GoVersion string `json:"goVersion"`
+		Enums     []struct {
+			Input    string   `json:"input"`
+			Type     string   `json:"type"`
+			Args     []string `json:"args"`
+			Checksum string   `json:"checksum"`
+			Outputs  []string `json:"outputs"`
+		} `json:"enums"`
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("failed to unmarshal manifest, got %v", err)
+	}
+	if m.GoVersion == "" {
+		t.Error("expected the go version to be recorded")
+	}
+	if len(m.Enums) != 2 {
+		t.Fatalf("expected an entry per input, got %+v", m.Enums)
+	}
+	order, status := m.Enums[0], m.Enums[1]
+	if order.Input != "orders.go" || order.Type != "Order" || !slices.Equal(order.Outputs, []string{"orders_enums.go"}) {
+		t.Errorf("unexpected order entry %+v", order)
+	}
+	if status.Input != "status.go" || status.Type != "Status" || status.Checksum == "" {
+		t.Errorf("unexpected status entry %+v", status)
+	}
+	if !slices.Equal(status.Args, []string{"-f", "-lock", "-manifest"}) {
+		t.Errorf("expected the args of the latest run, got %v", status.Args)
+	}
+	if !slices.Equal(status.Outputs, []string{"statuses_enums.go", "status.enums.lock"}) {
+		t.Errorf("expected the generated files and lockfile, got %v", status.Outputs)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/validation/status.go")
+	ctx := context.Background()
+	cfg := generator.Config{Failfast: true, JSONv2: true}
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, cfg); err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read source, got %v", err)
+	}
+	files, err := generator.Generate(ctx, filename, src, cfg)
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name)
+		written, err := os.ReadFile(filepath.Join(filepath.Dir(filename), f.Name))
+		if err != nil {
+			t.Fatalf("failed to read generated file, got %v", err)
+		}
+		if !bytes.Equal(f.Content, written) {
+			t.Errorf("expected %s to match the written file", f.Name)
+		}
+	}
+	if !slices.Equal(names, []string{"statuses_enums.go", "statuses_enums_jsonv2.go"}) {
+		t.Errorf("unexpected generated files %v", names)
+	}
+	_, err = generator.Generate(ctx, filename, src, generator.Config{Lock: true})
+	if !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig for lock, got %v", err)
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	src, err := os.ReadFile("testdata/defaults/status.go")
+	if err != nil {
+		t.Fatalf("failed to read source, got %v", err)
+	}
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		src      string
+		config   generator.Config
+		expected []generator.Diagnostic
+	}{
+		{name: "Valid", src: string(src)},
+		{name: "NotEnum", src: "package status\n\nconst limit = 10\n"},
+		{
+			name: "Syntax",
+			src:  "package status\n\ntype status int\n\nconst (\n\ta status = iota\n\tb status = 1 2\n)\n",
+			expected: []generator.Diagnostic{
+				{Line: 7, Column: 15, Message: "expected ';', found 2"},
+				{Line: 8, Column: 3, Message: "expected ')', found 'EOF'"},
+			},
+		},
+		{
+			name:     "Directive",
+			src:      strings.Replace(string(src), "defaultStatus = active", "defaultStatus = maxRetries", 1),
+			expected: []generator.Diagnostic{{Line: 3, Column: 6, Message: "not a constant of the enum"}},
+		},
+		{
+			name:     "Config",
+			src:      string(src),
+			config:   generator.Config{YAML: "v4"},
+			expected: []generator.Diagnostic{{Line: 3, Column: 6, Message: "unknown yaml library"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diags, err := generator.Diagnose(ctx, "status.go", []byte(tc.src), tc.config)
+			if err != nil {
+				t.Fatalf("failed to diagnose, got %v", err)
+			}
+			if len(diags) != len(tc.expected) {
+				t.Fatalf("expected %d diagnostics, got %+v", len(tc.expected), diags)
+			}
+			for i, d := range diags {
+				e := tc.expected[i]
+				if d.Line != e.Line || d.Column != e.Column || !strings.Contains(d.Message, e.Message) {
+					t.Errorf("expected %d:%d %q, got %d:%d %q", e.Line, e.Column, e.Message, d.Line, d.Column, d.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestDirectiveArgs(t *testing.T) {
+	src := "package order\n\n//go:generate goenums -f -sqlint order.go\n\ntype order int\n"
+	args := generator.DirectiveArgs("/src/order/order.go", []byte(src))
+	if !slices.Equal(args, []string{"-f", "-sqlint"}) {
+		t.Errorf("expected the directive flags, got %v", args)
+	}
+	if args := generator.DirectiveArgs("other.go", []byte(src)); args != nil {
+		t.Errorf("expected no flags for another file, got %v", args)
+	}
+	if args := generator.DirectiveArgs("/src/order/order.go", []byte(src+"\nconst (\n\tunknown order = \n")); !slices.Equal(args, []string{"-f", "-sqlint"}) {
+		t.Errorf("expected the directive flags of a file with syntax errors, got %v", args)
+	}
+}
+
+func TestGenerateType(t *testing.T) {
+	src := "package states\n\ntype status int\n\nconst (\n\tunknown status = iota // invalid\n\tactive\n)\n\n" +
+		"type priority int\n\nconst (\n\tlow priority = iota\n\t//goenums:default\n\tmedium\n\thigh\n)\n"
+	ctx := context.Background()
+	tests := []struct {
+		typ      string
+		expected string
+		names    []string
+	}{
+		{typ: "status", expected: "statuses_enums.go", names: []string{"UNKNOWN", "ACTIVE"}},
+		{typ: "priority", expected: "priorities_enums.go", names: []string{"LOW", "MEDIUM", "HIGH"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.typ, func(t *testing.T) {
+			files, err := generator.Generate(ctx, "states.go", []byte(src), generator.Config{Type: tc.typ})
+			if err != nil {
+				t.Fatalf("failed to generate enums, got %v", err)
+			}
+			if len(files) != 1 || files[0].Name != tc.expected {
+				t.Fatalf("expected %s to be generated, got %v", tc.expected, files)
+			}
+			content := string(files[0].Content)
+			if !strings.Contains(content, "goenums -type "+tc.typ+" states.go") {
+				t.Errorf("expected the command to record -type %s", tc.typ)
+			}
+			for _, name := range []string{"UNKNOWN", "ACTIVE", "LOW", "MEDIUM", "HIGH"} {
+				want := slices.Contains(tc.names, name)
+				if got := strings.Contains(content, "\t"+name+" "); got != want {
+					t.Errorf("expected %s generated %v, got %v", name, want, got)
+				}
+			}
+		})
+	}
+	_, err := generator.Generate(ctx, "states.go", []byte(src), generator.Config{Type: "kind"})
+	if !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig for a missing type, got %v", err)
+	}
+	_, err = generator.Generate(ctx, "states.go", []byte(src), generator.Config{})
+	if !errors.Is(err, generator.ErrInvalidConfig) || !strings.Contains(err.Error(), "-type") {
+		t.Errorf("expected ErrInvalidConfig asking for -type with several enums, got %v", err)
+	}
+	sizes := "package states\n\nconst (\n\t_ = iota\n\tKB = 1 << (10 * iota)\n\tMB\n)\n\n" +
+		"type status int\n\nconst (\n\tunknown status = iota // invalid\n\tactive\n)\n"
+	files, err := generator.Generate(ctx, "states.go", []byte(sizes), generator.Config{})
+	if err != nil {
+		t.Fatalf("expected untyped iota constants to be skipped, got %v", err)
+	}
+	if content := string(files[0].Content); !strings.Contains(content, "\tACTIVE ") || strings.Contains(content, "\tKB ") {
+		t.Errorf("expected only the status constants generated, got\n%s", content)
+	}
+}
+
+func TestGeneratePgx(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   generator.Config
+		expected []string
+	}{
+		{
+			name:   "Text",
+			config: generator.Config{Pgx: true},
+			expected: []string{
+				`"github.com/jackc/pgx/v5/pgtype"`,
+				"func (p *Status) ScanText(v pgtype.Text) error {",
+				"func (p Status) TextValue() (pgtype.Text, error) {",
+			},
+		},
+		{
+			name:   "Int64",
+			config: generator.Config{Pgx: true, SQLInt: true},
+			expected: []string{
+				`"github.com/jackc/pgx/v5/pgtype"`,
+				"func (p *Status) ScanInt64(v pgtype.Int8) error {",
+				"func (p Status) Int64Value() (pgtype.Int8, error) {",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filename := copyToTempDir(t, "testdata/validation/status.go")
+			err := generator.ParseAndGenerateWithConfig(context.Background(), filename, tc.config)
+			if err != nil {
+				t.Fatalf("failed to generate enums, got %v", err)
+			}
+			b, err := os.ReadFile(filepath.Join(filepath.Dir(filename), "statuses_enums.go"))
+			if err != nil {
+				t.Fatalf("failed to read generated file, got %v", err)
+			}
+			for _, e := range tc.expected {
+				if !strings.Contains(string(b), e) {
+					t.Errorf("expected generated file to contain %s", e)
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratedPgx(t *testing.T) {
+	m := pgtype.NewMap()
+	b, err := m.Encode(pgtype.TextOID, pgtype.TextFormatCode, pgx.Statuses.PASSED, nil)
+	if err != nil {
+		t.Fatalf("failed to encode, got %v", err)
+	}
+	if string(b) != "passed" {
+		t.Errorf("expected passed, got %s", b)
+	}
+	var s pgx.Status
+	err = m.Scan(pgtype.TextOID, pgtype.TextFormatCode, []byte("booked"), &s)
+	if err != nil {
+		t.Fatalf("failed to scan, got %v", err)
+	}
+	if s != pgx.Statuses.BOOKED {
+		t.Errorf("expected %v, got %v", pgx.Statuses.BOOKED, s)
+	}
+	err = m.Scan(pgtype.TextOID, pgtype.TextFormatCode, nil, &s)
+	if err != nil {
+		t.Fatalf("failed to scan NULL, got %v", err)
+	}
+	if s.IsValid() {
+		t.Errorf("expected NULL to scan to the invalid status, got %v", s)
+	}
+
+	b, err = m.Encode(pgtype.Int8OID, pgtype.BinaryFormatCode, pgxint.Statuses.PASSED, nil)
+	if err != nil {
+		t.Fatalf("failed to encode, got %v", err)
+	}
+	var n pgxint.Status
+	err = m.Scan(pgtype.Int8OID, pgtype.BinaryFormatCode, b, &n)
+	if err != nil {
+		t.Fatalf("failed to scan, got %v", err)
+	}
+	if n != pgxint.Statuses.PASSED {
+		t.Errorf("expected %v, got %v", pgxint.Statuses.PASSED, n)
+	}
+}
+
+// copyToTempDir copies the source file into a new temporary directory, for
+// generating code that cannot be compiled as part of the test packages.
+func copyToTempDir(t *testing.T, filename string) string {
+	t.Helper()
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read %s, got %v", filename, err)
+	}
+	dst := filepath.Join(t.TempDir(), filepath.Base(filename))
+	if err := os.WriteFile(dst, src, 0o644); err != nil {
+		t.Fatalf("failed to write %s, got %v", dst, err)
+	}
+	return dst
+}
+
+func TestGenerateSQLC(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/validation/status.go")
+	dir := filepath.Dir(filename)
+	err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\ngo 1.22\n"), 0o644)
+	if err != nil {
+		t.Fatalf("failed to write go.mod, got %v", err)
+	}
+	err = generator.ParseAndGenerateWithConfig(context.Background(), filename, generator.Config{Outputs: []string{generator.OutputSQLC}})
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "statuses_enums.go")); !os.IsNotExist(err) {
+		t.Errorf("expected no Go output, got %v", err)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "statuses_sqlc.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read generated file, got %v", err)
+	}
+	for _, e := range []string{
+		`- db_type: "status"`,
+		`import: "example.com/app"`,
+		`package: "validation"`,
+		`type: "Status"`,
+		`pointer: true`,
+	} {
+		if !strings.Contains(string(b), e) {
+			t.Errorf("expected generated file to contain %s", e)
+		}
+	}
+}
+
+func TestMappingOutput(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/sale/discount.go")
+	dir := filepath.Dir(filename)
+	err := generator.ParseAndGenerateWithConfig(context.Background(), filename, generator.Config{Outputs: []string{generator.OutputCSV, generator.OutputJSON}})
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	f, err := os.Open(filepath.Join(dir, "discounttypes_mapping.csv"))
+	if err != nil {
+		t.Fatalf("failed to open generated file, got %v", err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read generated csv, got %v", err)
+	}
+	expectedRows := [][]string{
+		{"value", "name", "valid", "aliases"},
+		{"1", "sale", "true", ""},
+		{"2", "percentage", "true", ""},
+		{"3", "amount", "true", ""},
+		{"4", "giveaway", "true", ""},
+	}
+	if !slices.EqualFunc(rows, expectedRows, slices.Equal[[]string]) {
+		t.Errorf("expected rows %v, got %v", expectedRows, rows)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "discounttypes_mapping.json"))
+	if err != nil {
+		t.Fatalf("failed to read generated file, got %v", err)
+	}
+	var doc struct {
+		Type   string
+		Values []struct {
+			Value   int
+			Name    string
+			Valid   bool
+			Aliases []string
+		}
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatalf("failed to decode generated json, got %v", err)
+	}
+	if doc.Type != "DiscountType" || len(doc.Values) != 4 {
+		t.Fatalf("expected the 4 DiscountType values, got %s with %d", doc.Type, len(doc.Values))
+	}
+	if v := doc.Values[3]; v.Value != 4 || v.Name != "giveaway" || !v.Valid || len(v.Aliases) != 0 {
+		t.Errorf("expected giveaway = 4, got %+v", v)
+	}
+}
+
+func TestAvroOutput(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/validation/status.go")
+	err := generator.ParseAndGenerateWithConfig(context.Background(), filename, generator.Config{Outputs: []string{generator.OutputAvro}})
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	b, err := os.ReadFile(filepath.Join(filepath.Dir(filename), "statuses.avsc"))
+	if err != nil {
+		t.Fatalf("failed to read generated file, got %v", err)
+	}
+	var schema struct {
+		Type      string
+		Name      string
+		Namespace string
+		Symbols   []string
+		Default   string
+	}
+	if err := json.Unmarshal(b, &schema); err != nil {
+		t.Fatalf("failed to decode generated schema, got %v", err)
+	}
+	expected := []string{"failed", "passed", "skipped", "scheduled", "running", "booked"}
+	if schema.Type != "enum" || schema.Name != "Status" || schema.Namespace != "validation" {
+		t.Errorf("expected the enum Status in validation, got %s %s in %s", schema.Type, schema.Name, schema.Namespace)
+	}
+	if !slices.Equal(schema.Symbols, expected) {
+		t.Errorf("expected symbols %v, got %v", expected, schema.Symbols)
+	}
+	if schema.Default != "failed" {
+		t.Errorf("expected the invalid value as default, got %q", schema.Default)
+	}
+}
+
+func TestAvroOutputInvalidSymbol(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/validation/status.go")
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read source, got %v", err)
+	}
+	src = []byte(strings.Replace(string(src), "\tpassed\n", "\tpassed // passed-ok\n", 1))
+	if err := os.WriteFile(filename, src, 0o644); err != nil {
+		t.Fatalf("failed to write source, got %v", err)
+	}
+	err = generator.ParseAndGenerateWithConfig(context.Background(), filename, generator.Config{Outputs: []string{generator.OutputAvro}})
+	if !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestJVMOutputs(t *testing.T) {
+	tests := []struct {
+		output   string
+		filename string
+		expected []string
+	}{
+		{
+			output:   generator.OutputJava,
+			filename: "Planet.java",
+			expected: []string{
+				"package descriptions;",
+				"public enum Planet {",
+				`    UNKNOWN(0, "unknown", false, 0.0, ""),`,
+				`    EARTH(3, "Earth", true, 1.0, "Home, \"sweet\" home - not an invalid planet."),`,
+				"    public double gravity() {",
+				`            case "Mars":`,
+			},
+		},
+		{
+			output:   generator.OutputKotlin,
+			filename: "Planet.kt",
+			expected: []string{
+				"package descriptions",
+				"enum class Planet(",
+				"    val gravity: Double,",
+				`    MARS(4, "Mars", true, 0.377, "The red planet: dusty, cold & thin-aired.");`,
+				`            "Mars" -> MARS`,
+				`            else -> throw IllegalArgumentException("invalid Planet: $s")`,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.output, func(t *testing.T) {
+			filename := copyToTempDir(t, "testdata/descriptions/planets.go")
+			err := generator.ParseAndGenerateWithConfig(context.Background(), filename, generator.Config{Outputs: []string{tc.output}})
+			if err != nil {
+				t.Fatalf("failed to generate enums, got %v", err)
+			}
+			b, err := os.ReadFile(filepath.Join(filepath.Dir(filename), tc.filename))
+			if err != nil {
+				t.Fatalf("failed to read generated file, got %v", err)
+			}
+			for _, e := range tc.expected {
+				if !strings.Contains(string(b), e+"\n") {
+					t.Errorf("expected generated file to contain %s", e)
+				}
+			}
+		})
+	}
+}
+
+func TestPythonOutput(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/descriptions/planets.go")
+	err := generator.ParseAndGenerateWithConfig(context.Background(), filename, generator.Config{Outputs: []string{generator.OutputPython}})
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	b, err := os.ReadFile(filepath.Join(filepath.Dir(filename), "planets_enums.py"))
+	if err != nil {
+		t.Fatalf("failed to read generated file, got %v", err)
+	}
+	for _, e := range []string{
+		"class Planet(enum.Enum):",
+		"    EARTH = 3",
+		`    Planet.EARTH: "Earth",`,
+		`    Planet.UNKNOWN: {"gravity": 0.0, "description": ""},`,
+		`    Planet.MERCURY: {"gravity": 0.378, "description": "The smallest planet, and the closest to the Sun."},`,
+		`    "Mars": Planet.MARS,`,
+	} {
+		if !strings.Contains(string(b), e+"\n") {
+			t.Errorf("expected generated file to contain %s", e)
+		}
+	}
+	if strings.Contains(string(b), "    Planet.UNKNOWN,\n") {
+		t.Errorf("expected the invalid value to be left out of _VALID")
+	}
+}
+
+func TestRustOutput(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/coverage/status.go")
+	err := generator.ParseAndGenerateWithConfig(context.Background(), filename, generator.Config{Outputs: []string{generator.OutputRust}, Strict: true})
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	b, err := os.ReadFile(filepath.Join(filepath.Dir(filename), "statuses_enums.rs"))
+	if err != nil {
+		t.Fatalf("failed to read generated file, got %v", err)
+	}
+	for _, e := range []string{
+		"pub enum Status {",
+		`    #[serde(rename = "unknown", skip_deserializing)]`,
+		`    #[serde(rename = "passed", alias = "ok", alias = "succeeded")]`,
+		"    Passed = 2,",
+		"        matches!(self, Status::Failed | Status::Passed | Status::Skipped | Status::Scheduled | Status::Running)",
+		`            "passed" | "ok" | "succeeded" => Ok(Status::Passed),`,
+		"impl fmt::Display for Status {",
+	} {
+		if !strings.Contains(string(b), e+"\n") {
+			t.Errorf("expected generated file to contain %s", e)
+		}
+	}
+}
+
+func TestCSharpOutput(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/descriptions/planets.go")
+	err := generator.ParseAndGenerateWithConfig(context.Background(), filename, generator.Config{Outputs: []string{generator.OutputCSharp}})
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	b, err := os.ReadFile(filepath.Join(filepath.Dir(filename), "Planet.cs"))
+	if err != nil {
+		t.Fatalf("failed to read generated file, got %v", err)
+	}
+	for _, e := range []string{
+		"namespace Descriptions",
+		"    public enum Planet : long",
+		"        Earth = 3,",
+		"    public static class PlanetExtensions",
+		"        public static string DisplayName(this Planet p) => p switch",
+		"            Planet.Unknown => false,",
+		"            Planet.Mercury => 0.378,",
+		"        public static Planet Parse(string s) =>",
+		`                case "Mars":`,
+	} {
+		if !strings.Contains(string(b), e+"\n") {
+			t.Errorf("expected generated file to contain %s", e)
+		}
+	}
+}
+
+func TestForeignOutputUnsupportedField(t *testing.T) {
+	err := generator.ParseAndGenerateWithConfig(context.Background(), copyToTempDir(t, "testdata/sale/discount.go"), generator.Config{Outputs: []string{generator.OutputJava}})
+	if !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig for the time.Duration field, got %v", err)
+	}
+}
+
+func TestInvalidOutputConfig(t *testing.T) {
+	err := generator.ParseAndGenerateWithConfig(context.Background(), "testdata/validation/status.go", generator.Config{Outputs: []string{"cobol"}})
+	if !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestOutputDirective(t *testing.T) {
+	tests := []struct {
+		name      string
+		directive string
+		config    generator.Config
+		expected  []string
+		skipped   []string
+		wantErr   error
+	}{
+		{
+			name:      "OverridesDefault",
+			directive: "//goenums:output go,sqlc",
+			expected:  []string{"statuses_enums.go", "statuses_sqlc.yaml"},
+		},
+		{
+			name:      "OverridesConfig",
+			directive: "//goenums:output=sqlc",
+			config:    generator.Config{Outputs: []string{generator.OutputGo}},
+			expected:  []string{"statuses_sqlc.yaml"},
+			skipped:   []string{"statuses_enums.go"},
+		},
+		{
+			name:     "Config",
+			config:   generator.Config{Outputs: []string{generator.OutputSQLC}},
+			expected: []string{"statuses_sqlc.yaml"},
+			skipped:  []string{"statuses_enums.go"},
+		},
+		{
+			name:      "Unknown",
+			directive: "//goenums:output go,cobol",
+			wantErr:   generator.ErrInvalidDirective,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filename := copyToTempDir(t, "testdata/validation/status.go")
+			dir := filepath.Dir(filename)
+			err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\ngo 1.22\n"), 0o644)
+			if err != nil {
+				t.Fatalf("failed to write go.mod, got %v", err)
+			}
+			src, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatalf("failed to read %s, got %v", filename, err)
+			}
+			src = []byte(strings.Replace(string(src), "type status int", tc.directive+"\ntype status int", 1))
+			if err := os.WriteFile(filename, src, 0o644); err != nil {
+				t.Fatalf("failed to write %s, got %v", filename, err)
+			}
+			err = generator.ParseAndGenerateWithConfig(context.Background(), filename, tc.config)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			for _, name := range tc.expected {
+				if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+					t.Errorf("expected %s to be generated, got %v", name, err)
+				}
+			}
+			for _, name := range tc.skipped {
+				if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+					t.Errorf("expected %s not to be generated, got %v", name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlersDirective(t *testing.T) {
+	src, err := os.ReadFile("testdata/validation/status.go")
+	if err != nil {
+		t.Fatalf("failed to read source, got %v", err)
+	}
+	tests := []struct {
+		name      string
+		directive string
+		config    generator.Config
+		expected  []string
+		skipped   []string
+		wantErr   error
+	}{
+		{
+			name:     "Config",
+			config:   generator.Config{YAML: generator.YAMLv2},
+			expected: []string{") MarshalJSON(", ") Scan(", "UnmarshalYAML(unmarshal func(any) error)"},
+		},
+		{
+			name:      "OverridesConfig",
+			directive: "//goenums:handlers json, sql",
+			config:    generator.Config{YAML: generator.YAMLv2, Pgx: true},
+			expected:  []string{") MarshalJSON(", ") UnmarshalJSON(", ") Scan(", ") Value(", "ScanText("},
+			skipped:   []string{"YAML"},
+		},
+		{
+			name:      "JSONOnly",
+			directive: "//goenums:handlers json",
+			expected:  []string{") MarshalJSON(", `"bytes"`},
+			skipped:   []string{") Scan(", ") Value(", "CheckConstraint", `"database/sql/driver"`},
+		},
+		{
+			name:      "YAMLWithoutLibrary",
+			directive: "//goenums:handlers=yaml",
+			expected:  []string{"UnmarshalYAML(node *yaml.Node)", `"gopkg.in/yaml.v3"`},
+			skipped:   []string{"JSON", ") Scan(", `"bytes"`},
+		},
+		{
+			name:      "Unknown",
+			directive: "//goenums:handlers json,binary",
+			wantErr:   generator.ErrInvalidDirective,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			src := strings.Replace(string(src), "type status int", tc.directive+"\ntype status int", 1)
+			files, err := generator.Generate(context.Background(), "status.go", []byte(src), tc.config)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			content := string(files[0].Content)
+			for _, s := range tc.expected {
+				if !strings.Contains(content, s) {
+					t.Errorf("expected %s in the generated file", s)
+				}
+			}
+			for _, s := range tc.skipped {
+				if strings.Contains(content, s) {
+					t.Errorf("expected no %s in the generated file", s)
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratedCacheKey(t *testing.T) {
+	if got := validation.Statuses.PASSED.CacheKey("status"); got != "status:passed" {
+		t.Errorf("expected status:passed, got %s", got)
+	}
+}
+
+func TestFreezeNames(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/validation/status.go")
+	cfg := generator.Config{FreezeNames: true}
+	err := generator.ParseAndGenerateWithConfig(context.Background(), filename, cfg)
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read %s, got %v", filename, err)
+	}
+	if !strings.Contains(string(src), "booked") {
+		t.Fatalf("expected source to contain booked")
+	}
+	err = os.WriteFile(filename, []byte(strings.Replace(string(src), "booked", "reserved", 1)), 0o644)
+	if err != nil {
+		t.Fatalf("failed to write %s, got %v", filename, err)
+	}
+	err = generator.ParseAndGenerateWithConfig(context.Background(), filename, cfg)
+	if !errors.Is(err, generator.ErrFrozenName) {
+		t.Errorf("expected ErrFrozenName, got %v", err)
+	}
+	err = generator.ParseAndGenerateWithConfig(context.Background(), filename, generator.Config{})
+	if err != nil {
+		t.Errorf("expected rename to be allowed without freeze, got %v", err)
+	}
+}
+
+func TestLock(t *testing.T) {
+	tests := []struct {
+		name    string
+		old     string
+		new     string
+		wantErr bool
+	}{
+		{name: "Added", old: "booked\n)", new: "booked\n\tcancelled\n)"},
+		{name: "Renamed", old: "booked", new: "reserved", wantErr: true},
+		{name: "Renumbered", old: "failed status = iota", new: "failed status = iota + 1", wantErr: true},
+		{name: "Removed", old: "\tbooked\n", new: "", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filename := copyToTempDir(t, "testdata/validation/status.go")
+			cfg := generator.Config{Lock: true}
+			err := generator.ParseAndGenerateWithConfig(context.Background(), filename, cfg)
+			if err != nil {
+				t.Fatalf("failed to generate enums, got %v", err)
+			}
+			lock, err := os.ReadFile(filepath.Join(filepath.Dir(filename), "status.enums.lock"))
+			if err != nil {
+				t.Fatalf("failed to read lockfile, got %v", err)
+			}
+			if !strings.Contains(string(lock), "\"booked\" 5\n") {
+				t.Errorf("expected lockfile to contain booked, got %s", lock)
+			}
+			src, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatalf("failed to read %s, got %v", filename, err)
+			}
+			if !strings.Contains(string(src), tc.old) {
+				t.Fatalf("expected source to contain %q", tc.old)
+			}
+			err = os.WriteFile(filename, []byte(strings.Replace(string(src), tc.old, tc.new, 1)), 0o644)
+			if err != nil {
+				t.Fatalf("failed to write %s, got %v", filename, err)
+			}
+			err = generator.ParseAndGenerateWithConfig(context.Background(), filename, cfg)
+			if tc.wantErr != errors.Is(err, generator.ErrLockMismatch) {
+				t.Errorf("expected lock mismatch %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDuplicateNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		config  generator.Config
+		wantErr bool
+	}{
+		{name: "Unique", comment: "reserved"},
+		{name: "OwnTag", comment: `json:"booked" db:"booked"`},
+		{name: "Alias", comment: "running", wantErr: true},
+		{name: "Tag", comment: `json:"passed"`, wantErr: true},
+		{name: "ParseOnly", comment: `parse:"reserved, skipped"`, wantErr: true},
+		{name: "CaseSensitive", comment: "Running"},
+		{name: "Insensitive", comment: "Running", config: generator.Config{Insensitive: true}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filename := copyToTempDir(t, "testdata/validation/status.go")
+			src, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatalf("failed to read %s, got %v", filename, err)
+			}
+			src = []byte(strings.Replace(string(src), "\tbooked\n", "\tbooked // "+tc.comment+"\n", 1))
+			if err := os.WriteFile(filename, src, 0o644); err != nil {
+				t.Fatalf("failed to write %s, got %v", filename, err)
+			}
+			err = generator.ParseAndGenerateWithConfig(context.Background(), filename, tc.config)
+			if tc.wantErr != errors.Is(err, generator.ErrDuplicateName) {
+				t.Errorf("expected duplicate name %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestUniqueNames(t *testing.T) {
+	statuses := copyToTempDir(t, "testdata/validation/status.go")
+	err := generator.ParseAndGenerate(statuses, false)
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	src, err := os.ReadFile("testdata/orders/orders.go")
+	if err != nil {
+		t.Fatalf("failed to read orders, got %v", err)
+	}
+	src = []byte(strings.NewReplacer("package orders", "package validation", "// CANCELLED", "// running").Replace(string(src)))
+	orders := filepath.Join(filepath.Dir(statuses), "orders.go")
+	if err := os.WriteFile(orders, src, 0o644); err != nil {
+		t.Fatalf("failed to write %s, got %v", orders, err)
+	}
+	err = generator.ParseAndGenerate(orders, false)
+	if err != nil {
+		t.Errorf("expected names shared between enums to be allowed by default, got %v", err)
+	}
+	err = generator.ParseAndGenerateWithConfig(context.Background(), orders, generator.Config{UniqueNames: true})
+	if !errors.Is(err, generator.ErrDuplicateName) {
+		t.Errorf("expected duplicate name, got %v", err)
+	}
+	err = generator.ParseAndGenerateWithConfig(context.Background(), statuses, generator.Config{UniqueNames: true})
+	if !errors.Is(err, generator.ErrDuplicateName) {
+		t.Errorf("expected duplicate name when regenerating the other enum, got %v", err)
+	}
+	err = generator.ParseAndGenerateWithConfig(context.Background(), statuses, generator.Config{Coverage: true})
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	err = generator.ParseAndGenerateWithConfig(context.Background(), orders, generator.Config{UniqueNames: true})
+	if !errors.Is(err, generator.ErrDuplicateName) {
+		t.Errorf("expected duplicate name with the lookup map of -coverage, got %v", err)
+	}
+}
+
+func TestReport(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/validation/status.go")
+	var report bytes.Buffer
+	cfg := generator.Config{Report: &report}
+	err := generator.ParseAndGenerateWithConfig(context.Background(), filename, cfg)
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	if err := generator.Check(context.Background(), filename, generator.Config{}); err != nil {
+		t.Errorf("expected enums generated with a report to be up to date, got %v", err)
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read %s, got %v", filename, err)
+	}
+	changed := strings.Replace(string(src), "booked\n", "reserved\n\tcancelled\n", 1)
+	err = os.WriteFile(filename, []byte(changed), 0o644)
+	if err != nil {
+		t.Fatalf("failed to write %s, got %v", filename, err)
+	}
+	report.Reset()
+	err = generator.ParseAndGenerateWithConfig(context.Background(), filename, cfg)
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	for _, e := range []string{
+		"previous: goenums " + filename,
+		`renamed "booked" to "reserved" = 5`,
+		`added "cancelled" = 6`,
+	} {
+		if !strings.Contains(report.String(), e) {
+			t.Errorf("expected report to contain %s, got\n%s", e, report.String())
+		}
+	}
+}
+
+func TestWriteReleaseNotes(t *testing.T) {
+	previous, err := os.ReadFile("testdata/validation/status.go")
+	if err != nil {
+		t.Fatalf("failed to read source, got %v", err)
+	}
+	current := strings.Replace(string(previous), "booked\n", "reserved\n\tcancelled\n", 1)
+	var notes bytes.Buffer
+	err = generator.WriteReleaseNotes(&notes, "testdata/validation/status.go", previous, []byte(current))
+	if err != nil {
+		t.Fatalf("failed to write release notes, got %v", err)
+	}
+	expected := "### Status\n\n" +
+		"- **Breaking:** Renamed `booked` to `reserved` (5)\n" +
+		"- Added `cancelled` (6)\n"
+	if notes.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, notes.String())
+	}
+}
+
+func TestGeneratedClosest(t *testing.T) {
+	got, d := suggest.ClosestStatus("Pased")
+	if got != suggest.Statuses.PASSED || d != 1 {
+		t.Errorf("expected %v at distance 1, got %v at %d", suggest.Statuses.PASSED, got, d)
+	}
+	_, err := suggest.ParseStatus("runing")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "running"?`) {
+		t.Errorf("expected suggestion in error, got %v", err)
+	}
+	_, err = suggest.ParseStatus("elephant")
+	if err == nil || strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("expected error without suggestion, got %v", err)
+	}
+}
+
+func TestGeneratedInsensitive(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected insensitive.Status
+	}{
+		{input: "passed", expected: insensitive.Statuses.PASSED},
+		{input: "PASSED", expected: insensitive.Statuses.PASSED},
+		{input: "pAsSeD", expected: insensitive.Statuses.PASSED},
+		{input: "Running", expected: insensitive.Statuses.RUNNING},
+		// Kelvin sign folds to k
+		{input: "boo\u212Aed", expected: insensitive.Statuses.BOOKED},
+		// Turkish dotted capital I and dotless i do not fold to i
+		{input: "FA\u0130LED", expected: insensitive.Statuses.UNKNOWN},
+		{input: "fa\u0131led", expected: insensitive.Statuses.UNKNOWN},
+		{input: "passedx", expected: insensitive.Statuses.UNKNOWN},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := insensitive.ParseStatus(tc.input)
+			if err != nil {
+				t.Fatalf("failed to parse %s, got %v", tc.input, err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGeneratedCoverage(t *testing.T) {
+	tests := []struct {
+		input    any
+		expected coverage.Status
+		wantErr  bool
+	}{
+		{input: "passed", expected: coverage.Statuses.PASSED},
+		{input: "succeeded", expected: coverage.Statuses.PASSED},
+		{input: "FAILED", expected: coverage.Statuses.FAILED},
+		{input: []byte("running"), expected: coverage.Statuses.RUNNING},
+		{input: 2, expected: coverage.Statuses.SKIPPED},
+		{input: int64(4), expected: coverage.Statuses.RUNNING},
+		{input: "unknown", expected: coverage.Statuses.UNKNOWN, wantErr: true},
+		{input: 5, expected: coverage.Statuses.UNKNOWN, wantErr: true},
+		{input: "bogus", expected: coverage.Statuses.UNKNOWN, wantErr: true},
+	}
+	for _, tc := range tests {
+		got, err := coverage.ParseStatus(tc.input)
+		if got != tc.expected || (err != nil) != tc.wantErr {
+			t.Errorf("expected %v for %v, got %v, %v", tc.expected, tc.input, got, err)
+		}
+	}
+	src, err := os.ReadFile("testdata/coverage/statuses_enums.go")
+	if err != nil {
+		t.Fatalf("failed to read generated file, got %v", err)
+	}
+	if !strings.Contains(string(src), "\n//coverage:ignore\n") {
+		t.Error("expected the coverage:ignore hint in the header")
+	}
+	if strings.Contains(string(src), "switch s {") || strings.Contains(string(src), "switch i {") {
+		t.Error("expected lookups instead of switches")
+	}
+}
+
+func TestParseLookup(t *testing.T) {
+	inputs := []string{"unknown", "failed", "FAILED", "passed", "ok", "succeeded", "skipped", "scheduled", "running", "", "o", "Failed", "runnin", "bogus"}
+	for _, input := range inputs {
+		want, wantErr := coverage.ParseStatus(input)
+		length, lengthErr := lookuplength.ParseStatus(input)
+		if length.String() != want.String() || (lengthErr != nil) != (wantErr != nil) {
+			t.Errorf("expected length lookup of %q to give %v, %v, got %v, %v", input, want, wantErr, length, lengthErr)
+		}
+		hash, hashErr := lookuphash.ParseStatus(input)
+		if hash.String() != want.String() || (hashErr != nil) != (wantErr != nil) {
+			t.Errorf("expected hash lookup of %q to give %v, %v, got %v, %v", input, want, wantErr, hash, hashErr)
+		}
+	}
+}
+
+func TestParseLookupManyValues(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "code.go")
+	if err := os.WriteFile(filename, []byte(syntheticEnum(1000, false)), 0o644); err != nil {
+		t.Fatalf("failed to write %s, got %v", filename, err)
+	}
+	for _, lookup := range []string{generator.ParseLookupLength, generator.ParseLookupHash} {
+		cfg := generator.Config{ParseLookup: lookup}
+		if err := generator.ParseAndGenerateWithConfig(context.Background(), filename, cfg); err != nil {
+			t.Errorf("failed to generate enums with %s lookup, got %v", lookup, err)
+		}
+	}
+}
+
+func TestParseLookupConfig(t *testing.T) {
+	tests := []generator.Config{
+		{ParseLookup: "tree"},
+		{ParseLookup: generator.ParseLookupHash, Insensitive: true},
+		{ParseLookup: generator.ParseLookupLength, Coverage: true},
+	}
+	for _, cfg := range tests {
+		err := generator.ParseAndGenerateWithConfig(context.Background(), "testdata/validation/status.go", cfg)
+		if !errors.Is(err, generator.ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig for %+v, got %v", cfg, err)
+		}
+	}
+}
+
+func TestCoverageFreezeNames(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/coverage/status.go")
+	cfg := generator.Config{Coverage: true, FreezeNames: true}
+	if err := generator.ParseAndGenerateWithConfig(context.Background(), filename, cfg); err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read %s, got %v", filename, err)
+	}
+	// removing a parse alias keeps the name of the value
+	src = []byte(strings.Replace(string(src), `parse:"ok, succeeded"`, "", 1))
+	if err := os.WriteFile(filename, src, 0o644); err != nil {
+		t.Fatalf("failed to write %s, got %v", filename, err)
+	}
+	if err := generator.ParseAndGenerateWithConfig(context.Background(), filename, cfg); err != nil {
+		t.Errorf("expected removing an alias to be allowed, got %v", err)
+	}
+	src = []byte(strings.Replace(string(src), "running", "active", 1))
+	if err := os.WriteFile(filename, src, 0o644); err != nil {
+		t.Fatalf("failed to write %s, got %v", filename, err)
+	}
+	err = generator.ParseAndGenerateWithConfig(context.Background(), filename, cfg)
+	if !errors.Is(err, generator.ErrFrozenName) {
+		t.Errorf("expected ErrFrozenName, got %v", err)
+	}
+}
+
+func TestGeneratedTags(t *testing.T) {
+	tests := []struct {
+		value   tags.Status
+		json    string
+		db      string
+		display string
+	}{
+		{value: tags.Statuses.PASSED, json: "passed", db: "PASSED", display: "Passed all checks"},
+		{value: tags.Statuses.SKIPPED, json: "skipped", db: "skipped", display: "Skipped"},
+		{value: tags.Statuses.INPROGRESS, json: "in_progress", db: "IN_PROGRESS", display: "In progress"},
+		{value: tags.Statuses.QUOTED, json: `"quoted"`, db: "quoted", display: "quoted"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.value.String(), func(t *testing.T) {
+			if got := tc.value.JSONName(); got != tc.json {
+				t.Errorf("expected json name %s, got %s", tc.json, got)
+			}
+			if got := tc.value.DBName(); got != tc.db {
+				t.Errorf("expected db name %s, got %s", tc.db, got)
+			}
+			if got := tc.value.DisplayName(); got != tc.display {
+				t.Errorf("expected display name %s, got %s", tc.display, got)
+			}
+			b, err := json.Marshal(tc.value)
+			if err != nil {
+				t.Fatalf("failed to marshal %v, got %v", tc.value, err)
+			}
+			if string(b) != strconv.Quote(tc.json) {
+				t.Errorf("expected %q, got %s", tc.json, b)
+			}
+			var got tags.Status
+			if err := json.Unmarshal(b, &got); err != nil || got != tc.value {
+				t.Errorf("expected %v to round trip through JSON, got %v, %v", tc.value, got, err)
+			}
+			v, err := tc.value.Value()
+			if err != nil || v != tc.db {
+				t.Errorf("expected value %s, got %v, %v", tc.db, v, err)
+			}
+			got = tags.Status{}
+			if err := got.Scan(tc.db); err != nil || got != tc.value {
+				t.Errorf("expected %v to round trip through Scan, got %v, %v", tc.value, got, err)
+			}
+		})
+	}
+	if _, err := tags.ParseStatus("Passed all checks"); err == nil {
+		t.Error("expected display names not to parse")
+	}
+}
+
+func TestGeneratedNames(t *testing.T) {
+	names := []string{"failed", "passed", "skipped", "scheduled", "InProgress", "quoted"}
+	if got := tags.StatusNames(); !slices.Equal(got, names) {
+		t.Errorf("expected names %v, got %v", names, got)
+	}
+	for _, s := range tags.StatusStrings() {
+		if v, err := tags.ParseStatus(s); err != nil || !v.IsValid() {
+			t.Errorf("expected %q to parse to a valid status, got %v, %v", s, v, err)
+		}
+	}
+	if got := tags.StatusStrings(); !slices.Contains(got, "bypassed") || slices.Contains(got, "unknown") {
+		t.Errorf("expected the aliases of the valid statuses, got %v", got)
+	}
+	for i, s := range validation.Statuses.All() {
+		if got := validation.StatusNames()[i]; got != s.String() {
+			t.Errorf("expected name %s, got %s", s, got)
+		}
+	}
+}
+
+func TestGeneratedParseOnlyAliases(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected tags.Status
+		wire     string
+	}{
+		{input: "ignored", expected: tags.Statuses.SKIPPED, wire: "skipped"},
+		{input: "bypassed", expected: tags.Statuses.SKIPPED, wire: "skipped"},
+		{input: "running", expected: tags.Statuses.INPROGRESS, wire: "in_progress"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			var got tags.Status
+			if err := json.Unmarshal([]byte(strconv.Quote(tc.input)), &got); err != nil {
+				t.Fatalf("failed to unmarshal %s, got %v", tc.input, err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("failed to marshal %v, got %v", got, err)
+			}
+			if string(b) != strconv.Quote(tc.wire) {
+				t.Errorf("expected %q, got %s", tc.wire, b)
+			}
+		})
+	}
+}
+
+func TestGeneratedDisplayName(t *testing.T) {
+	tests := []struct {
+		value   fmt.Stringer
+		display string
+		wire    string
+	}{
+		{value: tags.Statuses.PASSED, display: "Passed all checks", wire: "passed"},
+		{value: tags.Statuses.SCHEDULED, display: "scheduled", wire: "scheduled"},
+		{value: validation.Statuses.PASSED, display: "passed", wire: "passed"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.display, func(t *testing.T) {
+			got := tc.value.(interface{ DisplayName() string }).DisplayName()
+			if got != tc.display {
+				t.Errorf("expected display name %s, got %s", tc.display, got)
+			}
+			b, err := json.Marshal(tc.value)
+			if err != nil {
+				t.Fatalf("failed to marshal %v, got %v", tc.value, err)
+			}
+			if string(b) != strconv.Quote(tc.wire) {
+				t.Errorf("expected wire name %q, got %s", tc.wire, b)
+			}
+		})
+	}
+}
+
+func TestGeneratedTrimPrefix(t *testing.T) {
+	tests := []struct {
+		value    trimprefix.Status
+		expected string
+	}{
+		{value: trimprefix.Statuses.ACTIVE, expected: "active"},
+		{value: trimprefix.Statuses.PENDINGREVIEW, expected: "pending_review"},
+		{value: trimprefix.Statuses.ARCHIVED, expected: "ARCHIVED"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expected, func(t *testing.T) {
+			if got := tc.value.String(); got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+			got, err := trimprefix.ParseStatus(tc.expected)
+			if err != nil || got != tc.value {
+				t.Errorf("expected %v, got %v, %v", tc.value, got, err)
+			}
+		})
+	}
+	if got := trimprefix.Statuses.ACTIVE.GoString(); got != "Statuses.ACTIVE" {
+		t.Errorf("expected Statuses.ACTIVE, got %s", got)
+	}
+}
+
+func TestGeneratedAffixes(t *testing.T) {
+	var legacy affixes.Status = "passed"
+	got, err := affixes.ParseStatusEnum(string(legacy))
+	if err != nil {
+		t.Fatalf("failed to parse %s, got %v", legacy, err)
+	}
+	if got != affixes.StatusesEnum.PASSED {
+		t.Errorf("expected %v, got %v", affixes.StatusesEnum.PASSED, got)
+	}
+	if got := affixes.StatusesEnum.PASSED.GoString(); got != "StatusesEnum.PASSED" {
+		t.Errorf("expected StatusesEnum.PASSED, got %s", got)
+	}
+}
+
+func TestInvalidAffixConfig(t *testing.T) {
+	for _, cfg := range []generator.Config{{Prefix: "gen"}, {Prefix: "1X"}, {Suffix: "-enum"}} {
+		err := generator.ParseAndGenerateWithConfig(context.Background(), "testdata/validation/status.go", cfg)
+		if !errors.Is(err, generator.ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig for %+v, got %v", cfg, err)
+		}
+	}
+}
+
+func TestGeneratedDefault(t *testing.T) {
+	if got := defaults.DefaultStatus(); got != defaults.Statuses.ACTIVE {
+		t.Errorf("expected %v, got %v", defaults.Statuses.ACTIVE, got)
+	}
+	expected := []defaults.Status{defaults.Statuses.ACTIVE, defaults.Statuses.INACTIVE, defaults.Statuses.SUSPENDED}
+	var got []defaults.Status
+	defaults.ExhaustiveStatuss(func(s defaults.Status) {
+		got = append(got, s)
+	})
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected aliases to be skipped, got %v", got)
+	}
+}
+
+func TestGeneratedStrict(t *testing.T) {
+	got, err := strict.ParseStatus("bogus")
+	if err != nil || got != strict.Statuses.UNKNOWN {
+		t.Errorf("expected Parse to stay lenient, got %v, %v", got, err)
+	}
+	var s strict.Status
+	if err := json.Unmarshal([]byte(`"bogus"`), &s); err == nil || !strings.Contains(err.Error(), "bogus") {
+		t.Errorf("expected unmarshal error naming the input, got %v", err)
+	}
+	if err := s.Scan("bogus"); err == nil {
+		t.Error("expected scan error")
+	}
+	if err := s.UnmarshalYAML(func(v any) error {
+		*v.(*string) = "bogus"
+		return nil
+	}); err == nil {
+		t.Error("expected yaml unmarshal error")
+	}
+	if err := json.Unmarshal([]byte(`"passed"`), &s); err != nil || s != strict.Statuses.PASSED {
+		t.Errorf("expected passed, got %v, %v", s, err)
+	}
+}
+
+func TestGeneratedEmptyDefault(t *testing.T) {
+	if got := emptydefault.DefaultStatus(); got != emptydefault.Statuses.PENDING {
+		t.Errorf("expected %v, got %v", emptydefault.Statuses.PENDING, got)
+	}
+	for _, input := range []string{`{"status":""}`, `{"status":null}`} {
+		var v struct {
+			Status emptydefault.Status `json:"status"`
+		}
+		if err := json.Unmarshal([]byte(input), &v); err != nil {
+			t.Fatalf("failed to unmarshal %s, got %v", input, err)
+		}
+		if v.Status != emptydefault.Statuses.PENDING {
+			t.Errorf("expected %s to unmarshal to %v, got %v", input, emptydefault.Statuses.PENDING, v.Status)
+		}
+	}
+	for _, value := range []any{nil, "", []byte{}} {
+		s := emptydefault.Statuses.CLOSED
+		if err := s.Scan(value); err != nil {
+			t.Fatalf("failed to scan %#v, got %v", value, err)
+		}
+		if s != emptydefault.Statuses.PENDING {
+			t.Errorf("expected %#v to scan to %v, got %v", value, emptydefault.Statuses.PENDING, s)
+		}
+	}
+	var s emptydefault.Status
+	if err := s.Scan("bogus"); err == nil {
+		t.Error("expected invalid values to still fail in failfast mode")
+	}
+}
+
+func TestInvalidEmptyDefault(t *testing.T) {
+	err := generator.ParseAndGenerateWithConfig(context.Background(), "testdata/validation/status.go", generator.Config{EmptyDefault: true})
+	if !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig without a default, got %v", err)
+	}
+	err = generator.ParseAndGenerateWithConfig(context.Background(), "testdata/emptydefault/status.go", generator.Config{EmptyDefault: true, EmptyInvalid: true})
+	if !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig with emptyinvalid, got %v", err)
+	}
+}
+
+func TestInvalidDefault(t *testing.T) {
+	src, err := os.ReadFile("testdata/defaults/status.go")
+	if err != nil {
+		t.Fatalf("failed to read source, got %v", err)
+	}
+	src = []byte(strings.Replace(string(src), "defaultStatus = active", "defaultStatus = maxRetries", 1))
+	filename := filepath.Join(t.TempDir(), "status.go")
+	if err := os.WriteFile(filename, src, 0o644); err != nil {
+		t.Fatalf("failed to write %s, got %v", filename, err)
+	}
+	err = generator.ParseAndGenerate(filename, false)
+	if !errors.Is(err, generator.ErrInvalidDirective) {
+		t.Errorf("expected ErrInvalidDirective, got %v", err)
+	}
+}
+
+func TestGeneratedUnicode(t *testing.T) {
+	tests := []struct {
+		value    unicodenames.État
+		expected string
+	}{
+		{value: unicodenames.États.PRÊT, expected: "prêt"},
+		{value: unicodenames.États.TERMINÉ, expected: "terminé"},
+		{value: unicodenames.États.ÉCHOUÉ, expected: "Échoué"},
+		{value: unicodenames.États.X完了, expected: "完了"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expected, func(t *testing.T) {
+			if got := tc.value.String(); got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+			got, err := unicodenames.ParseÉtat(tc.expected)
+			if err != nil {
+				t.Fatalf("failed to parse %s, got %v", tc.expected, err)
+			}
+			if got != tc.value {
+				t.Errorf("expected %v, got %v", tc.value, got)
+			}
+		})
+	}
+}
+
+func TestGeneratedNameStyle(t *testing.T) {
+	tests := []struct {
+		value    names.OrderStatus
+		expected string
+	}{
+		{value: names.OrderStatuses.READYTOSHIP, expected: "Ready To Ship"},
+		{value: names.OrderStatuses.INTRANSIT, expected: "In Transit"},
+		{value: names.OrderStatuses.DELIVERED, expected: "Delivered"},
+		{value: names.OrderStatuses.RETURNEDTOSENDER, expected: "RTS"},
+	}
+	for _, tc := range tests {
+		if got := tc.value.String(); got != tc.expected {
+			t.Errorf("expected %s, got %s", tc.expected, got)
+		}
+	}
+}
+
+func TestGenerateNameStyles(t *testing.T) {
+	tests := []struct {
+		style    string
+		expected []string
+		err      error
+	}{
+		{style: "snake", expected: []string{`"ready_to_ship"`, `"in_transit"`, `"RTS"`}},
+		{style: "screaming", expected: []string{`"READY_TO_SHIP"`, `"IN_TRANSIT"`, `"RTS"`}},
+		{style: "kebab", expected: []string{`"ready-to-ship"`, `"in-transit"`, `"RTS"`}},
+		{style: "pascal", err: generator.ErrInvalidDirective},
+	}
+	for _, tc := range tests {
+		t.Run(tc.style, func(t *testing.T) {
+			filename := copyToTempDir(t, "testdata/names/orderstatus.go")
+			src, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatalf("failed to read %s, got %v", filename, err)
+			}
+			src = bytes.Replace(src, []byte("names=title"), []byte("names="+tc.style), 1)
+			if err := os.WriteFile(filename, src, 0o644); err != nil {
+				t.Fatalf("failed to write %s, got %v", filename, err)
+			}
+			err = generator.ParseAndGenerateWithConfig(context.Background(), filename, generator.Config{})
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if tc.err != nil {
+				return
+			}
+			b, err := os.ReadFile(filepath.Join(filepath.Dir(filename), "orderstatuses_enums.go"))
+			if err != nil {
+				t.Fatalf("failed to read generated file, got %v", err)
+			}
+			for _, e := range tc.expected {
+				if !strings.Contains(string(b), "case "+e+":") {
+					t.Errorf("expected generated file to parse %s", e)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateImports(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "timeout.go")
+	src := `package timeouts
+
+type timeout int // Connect[time.Duration],Read[time.Duration],Since[time.Time]
+
+const (
+	unset timeout = iota // invalid
+	short                // 1,2,time.Time{}
+	long                 // 10,20,time.Time{}
+)
+`
+	if err := os.WriteFile(filename, []byte(src), 0o644); err != nil {
+		t.Fatalf("failed to write %s, got %v", filename, err)
+	}
+	expected := "import (\n" +
+		"\t\"bytes\"\n" +
+		"\t\"database/sql/driver\"\n" +
+		"\t\"fmt\"\n" +
+		"\t\"strconv\"\n" +
+		"\t\"time\"\n" +
+		"\n" +
+		"\t\"github.com/jackc/pgx/v5/pgtype\"\n" +
+		"\t\"gopkg.in/yaml.v3\"\n" +
+		")\n"
+	var first []byte
+	for range 3 {
+		err := generator.ParseAndGenerateWithConfig(context.Background(), filename, generator.Config{YAML: generator.YAMLv3, Pgx: true})
+		if err != nil {
+			t.Fatalf("failed to generate enums, got %v", err)
+		}
+		b, err := os.ReadFile(filepath.Join(dir, "timeouts_enums.go"))
+		if err != nil {
+			t.Fatalf("failed to read generated file, got %v", err)
+		}
+		if !strings.Contains(string(b), expected) {
+			t.Fatalf("expected imports\n%s\ngot\n%s", expected, b)
+		}
+		if first != nil && !bytes.Equal(first, b) {
+			t.Fatalf("expected repeated generation to be identical")
+		}
+		first = b
+	}
+}
+
+func TestGenerateLargeEnum(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "code.go")
+	if err := os.WriteFile(filename, []byte(syntheticEnum(8000, true)), 0o644); err != nil {
+		t.Fatalf("failed to write %s, got %v", filename, err)
+	}
+	err := generator.ParseAndGenerateWithConfig(context.Background(), filename, generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	b, err := os.ReadFile(filepath.Join(filepath.Dir(filename), "codes_enums.go"))
+	if err != nil {
+		t.Fatalf("failed to read generated file, got %v", err)
+	}
+	for _, e := range []string{
+		// the names run is longer than a uint16 can index
+		"_codes_index = [...]uint32{0, 7, 16, 25,",
+		"\tVALUE0001: Code{code: value0001, Number: 1, Label: \"Label 1\",",
+	} {
+		if !strings.Contains(string(b), e) {
+			t.Errorf("expected generated file to contain %s", e)
+		}
+	}
+}
+
+func TestFindEnums(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":                  "module example.com/app\n\ngo 1.22\n",
+		"order/order.go":          "package order\n\n//go:generate goenums -f -sqlint order.go\n//go:generate stringer -type order\n\ntype order int\n\nconst (\n\tcreated order = iota\n\tshipped\n)\n",
+		"order/order_enums.go":    "// Code generated by goenums. DO NOT EDIT.\n\npackage order\n\ntype kind int\n\nconst one kind = iota\n",
+		"order/order_test.go":     "package order\n\ntype fixture int\n\nconst a fixture = iota\n",
+		"internal/kind/kind.go":   "package kind\n\ntype kind uint8\n\nconst (\n\t_ kind = iota + 1\n\tsmall\n)\n",
+		"internal/kind/public.go": "package kind\n\ntype Public int\n\nconst A Public = iota\n",
+		"internal/kind/plain.go":  "package kind\n\nconst limit = 10\n",
+		"testdata/skipped.go":     "package testdata\n\ntype skipped int\n\nconst a skipped = iota\n",
+		"nested/go.mod":           "module example.com/nested\n\ngo 1.22\n",
+		"nested/nested.go":        "package nested\n\ntype nested int\n\nconst a nested = iota\n",
+		".hidden/hidden.go":       "package hidden\n\ntype hidden int\n\nconst a hidden = iota\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create %s, got %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatalf("failed to write %s, got %v", path, err)
+		}
+	}
+	// searching from a package finds every enum in the module
+	candidates, err := generator.FindEnums(filepath.Join(root, "order"))
+	if err != nil {
+		t.Fatalf("failed to find enums, got %v", err)
+	}
+	expected := []generator.Candidate{
+		{Filename: filepath.Join(root, "internal/kind/kind.go"), ImportPath: "example.com/app/internal/kind", Type: "kind"},
+		{Filename: filepath.Join(root, "order/order.go"), ImportPath: "example.com/app/order", Type: "order", Args: []string{"-f", "-sqlint"}},
+	}
+	if len(candidates) != len(expected) {
+		t.Fatalf("expected %d candidates, got %+v", len(expected), candidates)
+	}
+	for i, c := range candidates {
+		e := expected[i]
+		if c.Filename != e.Filename || c.ImportPath != e.ImportPath || c.Type != e.Type || strings.Join(c.Args, " ") != strings.Join(e.Args, " ") || (c.Args == nil) != (e.Args == nil) {
+			t.Errorf("expected %+v, got %+v", e, c)
+		}
+	}
+}
+
+func TestWriteSite(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":           "module example.com/app\n\ngo 1.22\n",
+		"order/order.go":   "package order\n\ntype order int // Size[Size],Weight[float64,unit=kg]\n\nconst (\n\tunknown order = iota // invalid\n\tcreated // Created Sizes.SMALL,2.5 desc=\"A new <b>order</b>.\"\n)\n",
+		"order/size.go":    "package order\n\ntype size int\n\nconst (\n\tsmall size = iota // parse:\"tiny\"\n\tlarge // display:\"Large size\"\n)\n",
+		"colour/colour.go": "package colour\n\ntype colour int\n\nconst (\n\tred colour = iota\n\tgreen\n)\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create %s, got %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatalf("failed to write %s, got %v", path, err)
+		}
+	}
+	candidates, err := generator.FindEnums(root)
+	if err != nil {
+		t.Fatalf("failed to find enums, got %v", err)
+	}
+	out := filepath.Join(t.TempDir(), "site")
+	err = generator.WriteSite(context.Background(), out, candidates, func(generator.Candidate) (generator.Config, error) {
+		return generator.Config{Header: generator.Header{Owner: "payments"}}, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to write site, got %v", err)
+	}
+	read := func(name string) string {
+		b, err := os.ReadFile(filepath.Join(out, name))
+		if err != nil {
+			t.Fatalf("failed to read %s, got %v", name, err)
+		}
+		return string(b)
+	}
+	index := read("index.html")
+	for _, want := range []string{
+		`<a href="example.com_app_colour.Colour.html">Colour</a>`,
+		`<a href="example.com_app_order.Order.html">Order</a>`,
+		`<a href="example.com_app_order.Size.html">Size</a>`,
+		`<td>payments</td>`,
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("expected the index to contain %s, got\n%s", want, index)
+		}
+	}
+	order := read("example.com_app_order.Order.html")
+	for _, want := range []string{
+		// the other enum of the package, also linked from the field of its type
+		`<a href="example.com_app_order.Size.html">Size</a></nav>`,
+		`<th><a href="example.com_app_order.Size.html">Size</a> <code>Size</code></th>`,
+		`<tr class="invalid"><td><code>Orders.UNKNOWN</code> (invalid)</td>`,
+		`<th>Weight <code>float64</code> in kg</th>`,
+		`<td>Created</td><td>Created</td><td>1</td><td></td><td>A new &lt;b&gt;order&lt;/b&gt;.</td><td>Sizes.SMALL</td><td>2.5</td>`,
+	} {
+		if !strings.Contains(order, want) {
+			t.Errorf("expected the Order page to contain %s, got\n%s", want, order)
+		}
+	}
+	size := read("example.com_app_order.Size.html")
+	if !strings.Contains(size, "<td>small</td><td>small</td><td>0</td><td>tiny</td>") {
+		t.Errorf("expected the Size page to list the alias of small, got\n%s", size)
+	}
+	if !strings.Contains(size, "<td>large</td><td>Large size</td><td>1</td>") {
+		t.Errorf("expected the Size page to list the display name of large, got\n%s", size)
+	}
+}
+
+func TestWriteExport(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":         "module example.com/app\n\ngo 1.22\n",
+		"order/order.go": "package order\n\ntype order int // Weight[int,unit=kg]\n\nconst (\n\tunknown order = iota // invalid\n\tcreated // Created 3 desc=\"A new order, not yet paid.\"\n\tshipped // parse:\"sent\" display:\"Shipped out\" 5\n)\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create %s, got %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatalf("failed to write %s, got %v", path, err)
+		}
+	}
+	candidates, err := generator.FindEnums(root)
+	if err != nil {
+		t.Fatalf("failed to find enums, got %v", err)
+	}
+	config := func(generator.Candidate) (generator.Config, error) {
+		return generator.Config{Header: generator.Header{Owner: "orders & billing"}}, nil
+	}
+	var b bytes.Buffer
+	if err := generator.WriteExport(context.Background(), &b, generator.ExportCSV, candidates, config); err != nil {
+		t.Fatalf("failed to export, got %v", err)
+	}
+	expected := "Package,Type,Constant,Name,Display name,Value,Valid,Aliases,Description,Extra values,Owner\n" +
+		"example.com/app/order,Order,Orders.UNKNOWN,unknown,unknown,0,false,,,,orders & billing\n" +
+		"example.com/app/order,Order,Orders.CREATED,Created,Created,1,true,,\"A new order, not yet paid.\",Weight=3 kg,orders & billing\n" +
+		"example.com/app/order,Order,Orders.SHIPPED,shipped,Shipped out,2,true,sent,,Weight=5 kg,orders & billing\n"
+	if got := b.String(); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+	b.Reset()
+	if err := generator.WriteExport(context.Background(), &b, generator.ExportXLSX, candidates, config); err != nil {
+		t.Fatalf("failed to export, got %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatalf("failed to open the workbook, got %v", err)
+	}
+	var sheet []byte
+	for _, f := range zr.File {
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open the sheet, got %v", err)
+		}
+		sheet, _ = io.ReadAll(r)
+		r.Close()
+	}
+	for _, want := range []string{
+		`<dimension ref="A1:K4"/>`,
+		`<c r="C3" t="inlineStr"><is><t xml:space="preserve">Orders.CREATED</t></is></c><c r="D3" t="inlineStr"><is><t xml:space="preserve">Created</t></is></c><c r="E3" t="inlineStr"><is><t xml:space="preserve">Created</t></is></c><c r="F3"><v>1</v></c><c r="G3" t="b"><v>1</v></c>`,
+		`<c r="E4" t="inlineStr"><is><t xml:space="preserve">Shipped out</t></is></c>`,
+		`<t xml:space="preserve">orders &amp; billing</t>`,
+		`<autoFilter ref="A1:K4"/>`,
+	} {
+		if !bytes.Contains(sheet, []byte(want)) {
+			t.Errorf("expected the sheet to contain %s, got\n%s", want, sheet)
+		}
+	}
+	if err := generator.WriteExport(context.Background(), &b, "pdf", candidates, config); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}
+
+func TestFindUsage(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":              "module example.com/app\n\ngo 1.22\n",
+		"order/order.go":      "package order\n\ntype order int\n\nconst (\n\tcreated order = iota\n\tpaid\n\tshipped\n\tlost\n)\n",
+		"order/paid.go":       "package order\n\nfunc isPaid(o order) bool {\n\treturn o == paid\n}\n",
+		"order/order_test.go": "package order\n\nvar l = Orders.LOST\n",
+		"ship/ship.go":        "package ship\n\nimport o \"example.com/app/order\"\n\nfunc ship(v o.Order) o.Order {\n\tswitch v {\n\tcase o.Orders.CREATED, o.Orders.PAID:\n\t\treturn o.Orders.SHIPPED\n\t}\n\tif v != o.Orders.SHIPPED {\n\t\treturn v\n\t}\n\tOrders := 1\n\t_ = Orders\n\treturn v\n}\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create %s, got %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatalf("failed to write %s, got %v", path, err)
+		}
+	}
+	candidates, err := generator.FindEnums(root)
+	if err != nil {
+		t.Fatalf("failed to find enums, got %v", err)
+	}
+	usages, err := generator.FindUsage(context.Background(), root, candidates, func(generator.Candidate) (generator.Config, error) {
+		return generator.Config{}, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to find usage, got %v", err)
+	}
+	if len(usages) != 1 {
+		t.Fatalf("expected the usage of one enum, got %+v", usages)
+	}
+	// references from tests do not count
+	expected := []generator.ValueUsage{
+		{Constant: "Orders.CREATED", Cases: 1},
+		{Constant: "Orders.PAID", Cases: 1, Comparisons: 1},
+		{Constant: "Orders.SHIPPED", Comparisons: 1, Other: 1},
+		{Constant: "Orders.LOST"},
+	}
+	if !reflect.DeepEqual(usages[0].Values, expected) {
+		t.Errorf("expected %+v, got %+v", expected, usages[0].Values)
+	}
+	if usages[0].Values[3].Referenced() {
+		t.Errorf("expected %s to be unreferenced", usages[0].Values[3].Constant)
+	}
+}
+
+func TestRename(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":             "module example.com/app\n\ngo 1.22\n",
+		"order/order.go":     "package order\n\ntype order int\n\nconst (\n\tcreated order = iota\n\tshipped // parse:\"sent\"\n\tlost\n)\n\nconst last = shipped\n",
+		"order/ship.go":      "package order\n\nfunc ship(o order) bool {\n\treturn o == shipped\n}\n",
+		"order/ship_test.go": "package order\n\nvar s = Orders.SHIPPED\n",
+		"use/use.go":         "package use\n\nimport o \"example.com/app/order\"\n\nvar S, L = o.Orders.SHIPPED, o.Orders.LOST\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create %s, got %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatalf("failed to write %s, got %v", path, err)
+		}
+	}
+	filename := filepath.Join(root, "order/order.go")
+	ctx := context.Background()
+	if _, err := generator.Rename(ctx, filename, generator.Config{}, "shipped", "lost", false); err == nil {
+		t.Errorf("expected an error renaming to a declared constant")
+	}
+	if _, err := generator.Rename(ctx, filename, generator.Config{}, "missing", "found", false); err == nil {
+		t.Errorf("expected an error renaming a constant that is not declared")
+	}
+	changed, err := generator.Rename(ctx, filename, generator.Config{}, "shipped", "dispatched", true)
+	if err != nil {
+		t.Fatalf("failed to rename, got %v", err)
+	}
+	if len(changed) != 4 {
+		t.Errorf("expected 4 files changed, got %v", changed)
+	}
+	expected := map[string]string{
+		// the old name is added to the parse aliases
+		"order/order.go":     "package order\n\ntype order int\n\nconst (\n\tcreated    order = iota\n\tdispatched       // parse:\"sent,shipped\"\n\tlost\n)\n\nconst last = dispatched\n",
+		"order/ship.go":      "package order\n\nfunc ship(o order) bool {\n\treturn o == dispatched\n}\n",
+		"order/ship_test.go": "package order\n\nvar s = Orders.DISPATCHED\n",
+		"use/use.go":         "package use\n\nimport o \"example.com/app/order\"\n\nvar S, L = o.Orders.DISPATCHED, o.Orders.LOST\n",
+	}
+	for name, want := range expected {
+		got, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("failed to read %s, got %v", name, err)
+		}
+		if string(got) != want {
+			t.Errorf("expected %s to be\n%s\ngot\n%s", name, want, got)
+		}
+	}
+	generated, err := os.ReadFile(filepath.Join(root, "order/orders_enums.go"))
+	if err != nil {
+		t.Fatalf("failed to read the generated file, got %v", err)
+	}
+	if !strings.Contains(string(generated), `case "dispatched", "sent", "shipped":`) {
+		t.Errorf("expected the old name to still parse, got\n%s", generated)
+	}
+}
+
+func TestFindRenumbered(t *testing.T) {
+	root := t.TempDir()
+	filename := filepath.Join(root, "order.go")
+	original := "package order\n\ntype order int\n\nconst (\n\tcreated order = iota\n\tshipped\n\t_\n\tlost\n)\n"
+	if err := os.WriteFile(filename, []byte(original), 0o644); err != nil {
+		t.Fatalf("failed to write %s, got %v", filename, err)
+	}
+	ctx := context.Background()
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, generator.Config{}); err != nil {
+		t.Fatalf("failed to generate, got %v", err)
+	}
+	// paid is inserted before shipped, moving it and lost up by one
+	reordered := "package order\n\ntype order int\n\nconst (\n\tcreated order = iota\n\tpaid\n\tshipped\n\t_\n\tlost\n)\n"
+	if err := os.WriteFile(filename, []byte(reordered), 0o644); err != nil {
+		t.Fatalf("failed to write %s, got %v", filename, err)
+	}
+	expected := []generator.Renumbering{
+		{Constant: "shipped", Name: "shipped", Previous: 1, Current: 2},
+		{Constant: "lost", Name: "lost", Previous: 3, Current: 4},
+	}
+	for _, previous := range [][]byte{nil, []byte(original)} {
+		renumbered, err := generator.FindRenumbered(ctx, filename, previous, generator.Config{})
+		if err != nil {
+			t.Fatalf("failed to find renumbered values, got %v", err)
+		}
+		if !reflect.DeepEqual(renumbered, expected) {
+			t.Errorf("expected %v, got %v", expected, renumbered)
+		}
+	}
+	if err := generator.PinValues(ctx, filename, generator.Config{}, map[string]int{"shipped": 1, "lost": 3}); err != nil {
+		t.Fatalf("failed to pin values, got %v", err)
+	}
+	got, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read %s, got %v", filename, err)
+	}
+	// paid takes a value after every other as its own is pinned to shipped
+	want := "package order\n\ntype order int\n\n//goenums:pinned\nconst (\n\tcreated order = 0\n\tpaid    order = 5\n\tshipped order = 1\n\tlost    order = 3\n)\n"
+	if string(got) != want {
+		t.Errorf("expected the pinned source\n%s\ngot\n%s", want, got)
+	}
+	renumbered, err := generator.FindRenumbered(ctx, filename, []byte(original), generator.Config{})
+	if err != nil {
+		t.Fatalf("failed to find renumbered values, got %v", err)
+	}
+	if len(renumbered) != 0 {
+		t.Errorf("expected no renumbered values once pinned, got %v", renumbered)
+	}
+	candidates, err := generator.FindEnums(root)
+	if err != nil || len(candidates) != 1 || candidates[0].Type != "order" {
+		t.Errorf("expected the pinned enum to be found, got %v, %v", candidates, err)
+	}
+	duplicate := "package order\n\ntype order int\n\n//goenums:pinned\nconst (\n\tcreated order = 0\n\tshipped order = 0\n)\n"
+	if _, err := generator.Parse(ctx, filename, []byte(duplicate), generator.Config{}); !errors.Is(err, generator.ErrDuplicateValue) {
+		t.Errorf("expected ErrDuplicateValue for a pinned value declared twice, got %v", err)
+	}
+}
+
+func TestPinValues(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "order.go")
+	src := "package order\n\ntype order int\n\n// orders\nconst (\n\tcreated order = iota + 1 // invalid\n\t// shipped is sent\n\tshipped\n\t_ // retired\n\tlost\n)\n"
+	if err := os.WriteFile(filename, []byte(src), 0o644); err != nil {
+		t.Fatalf("failed to write %s, got %v", filename, err)
+	}
+	ctx := context.Background()
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, generator.Config{}); err != nil {
+		t.Fatalf("failed to generate, got %v", err)
+	}
+	generated := filepath.Join(filepath.Dir(filename), "orders_enums.go")
+	before, err := os.ReadFile(generated)
+	if err != nil {
+		t.Fatalf("failed to read the generated file, got %v", err)
+	}
+	if err := generator.PinValues(ctx, filename, generator.Config{}, nil); err != nil {
+		t.Fatalf("failed to pin values, got %v", err)
+	}
+	got, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read %s, got %v", filename, err)
+	}
+	want := "package order\n\ntype order int\n\n// orders\n//\n//goenums:pinned\nconst (\n\tcreated order = 1 // invalid\n\t// shipped is sent\n\tshipped order = 2\n\tlost    order = 4\n)\n"
+	if string(got) != want {
+		t.Errorf("expected the pinned source\n%s\ngot\n%s", want, got)
+	}
+	after, err := os.ReadFile(generated)
+	if err != nil {
+		t.Fatalf("failed to read the generated file, got %v", err)
+	}
+	// only the checksum of the source in the header changes
+	_, beforeBody, _ := strings.Cut(string(before), "\npackage ")
+	_, afterBody, _ := strings.Cut(string(after), "\npackage ")
+	if beforeBody != afterBody {
+		t.Errorf("expected pinning to generate the same enum, got\n%s\nbefore\n%s", after, before)
+	}
+}
+
+func TestPinnedBlocks(t *testing.T) {
+	src := "package states\n\ntype status int\n\n//goenums:pinned\nconst (\n\tunknown status = 1 // invalid\n\tactive  status = 4\n)\n\n" +
+		"type priority int\n\n//goenums:pinned\nconst (\n\tlow  priority = 10\n\thigh priority = 20\n)\n"
+	ctx := context.Background()
+	tests := []struct {
+		typ    string
+		index  int
+		values map[string]int
+	}{
+		{typ: "status", index: 1, values: map[string]int{"unknown": 0, "active": 3}},
+		{typ: "priority", index: 10, values: map[string]int{"low": 0, "high": 10}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.typ, func(t *testing.T) {
+			rep, err := generator.Parse(ctx, "states.go", []byte(src), generator.Config{Type: tc.typ})
+			if err != nil {
+				t.Fatalf("failed to parse, got %v", err)
+			}
+			if rep.TypeInfo.Index != tc.index {
+				t.Errorf("expected index %d, got %d", tc.index, rep.TypeInfo.Index)
+			}
+			values := map[string]int{}
+			for _, e := range rep.Enums {
+				values[e.Info.Name] = e.Info.Value
+			}
+			if !reflect.DeepEqual(values, tc.values) {
+				t.Errorf("expected only the constants of %s %v, got %v", tc.typ, tc.values, values)
+			}
+		})
+	}
+	if _, err := generator.Parse(ctx, "states.go", []byte(src), generator.Config{}); !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig without -type, got %v", err)
+	}
+	filename := filepath.Join(t.TempDir(), "states.go")
+	unpinned := strings.Replace(src, "//goenums:pinned\nconst (\n\tunknown status = 1 // invalid\n\tactive  status = 4\n)", "const (\n\tunknown status = iota // invalid\n\tactive\n)", 1)
+	if err := os.WriteFile(filename, []byte(unpinned), 0o644); err != nil {
+		t.Fatalf("failed to write %s, got %v", filename, err)
+	}
+	if err := generator.PinValues(ctx, filename, generator.Config{Type: "status"}, nil); err != nil {
+		t.Fatalf("failed to pin values, got %v", err)
+	}
+	got, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read %s, got %v", filename, err)
+	}
+	want := strings.Replace(src, "status = 4", "status = 1", 1)
+	want = strings.Replace(want, "status = 1 // invalid", "status = 0 // invalid", 1)
+	if string(got) != want {
+		t.Errorf("expected only the status block pinned\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestGenerateBatch(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":                "module example.com/app\n\ngo 1.22\n",
+		"order/order.go":        "package order\n\n//go:generate goenums -f order.go\n\ntype order int\n\nconst (\n\tcreated order = iota\n\tshipped\n)\n",
+		"order/kind.go":         "package order\n\ntype kind int\n\nconst (\n\tsmall kind = iota\n\tlarge\n)\n",
+		"colour/colour.go":      "package colour\n\ntype colour int\n\nconst (\n\tred colour = iota\n\tgreen\n)\n",
+		"broken/broken.go":      "package broken\n\ntype broken int\n\nconst (\n\ta broken = iota\n\tb\n)\n",
+		"internal/size/size.go": "package size\n\ntype size int\n\nconst (\n\tsmall size = iota\n\tlarge\n)\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create %s, got %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatalf("failed to write %s, got %v", path, err)
+		}
+	}
+	candidates, err := generator.FindEnums(root)
+	if err != nil {
+		t.Fatalf("failed to find enums, got %v", err)
+	}
+	var metrics []generator.BatchMetrics
+	errConfig := errors.New("bad config")
+	errs := generator.GenerateBatch(context.Background(), candidates, generator.BatchOptions{
+		Jobs:    2,
+		Retries: 1,
+		Config: func(c generator.Candidate) (generator.Config, error) {
+			if c.Type == "broken" {
+				return generator.Config{}, errConfig
+			}
+			return generator.Config{Failfast: c.Args != nil}, nil
+		},
+		Metrics: func(m generator.BatchMetrics) {
+			metrics = append(metrics, m)
+		},
+	})
+	if len(errs) != len(candidates) {
+		t.Fatalf("expected %d errors, got %d", len(candidates), len(errs))
+	}
+	for i, c := range candidates {
+		if c.Type == "broken" {
+			if !errors.Is(errs[i], errConfig) || !strings.Contains(errs[i].Error(), c.Filename) {
+				t.Errorf("expected the config error for %s, got %v", c.Filename, errs[i])
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Errorf("failed to generate %s, got %v", c.Filename, errs[i])
+		}
+	}
+	if len(metrics) != 1 {
+		t.Fatalf("expected metrics once, got %+v", metrics)
+	}
+	if m := metrics[0]; m.Enums != 5 || m.Packages != 4 || m.Failed != 1 || m.Retries != 0 {
+		t.Errorf("expected 5 enums in 4 packages with 1 failure, got %+v", m)
+	}
+	// generated from the package directory like go generate
+	b, err := os.ReadFile(filepath.Join(root, "order/orders_enums.go"))
+	if err != nil {
+		t.Fatalf("failed to read generated file, got %v", err)
+	}
+	if !strings.Contains(string(b), "// goenums -f order.go\n") {
+		t.Errorf("expected the command relative to the package, got\n%s", b)
+	}
+	for _, name := range []string{"order/kinds_enums.go", "colour/colours_enums.go", "internal/size/sizes_enums.go"} {
+		if _, err := os.Stat(filepath.Join(root, name)); err != nil {
+			t.Errorf("expected %s to be generated, got %v", name, err)
+		}
+	}
+}
+
+func TestGenerateDataset(t *testing.T) {
+	tests := []struct {
+		dataset  string
+		expected []string
+	}{
+		{dataset: "countries", expected: []string{
+			"type country int // Name[string]",
+			"\tgb ",
+			"// GB \"Britain (UK)\"\n",
+		}},
+		{dataset: "currencies", expected: []string{
+			"type currency int // Numeric[int],MinorUnits[int],Name[string]",
+			"\tjpy ",
+			"// JPY 392,0,\"Yen\"\n",
+		}},
+		{dataset: "httpstatuses", expected: []string{
+			"type httpStatus int // Code[int],Message[string]",
+			"\tstatus_not_found ",
+			"// not_found 404,\"Not Found\"\n",
+			"// im_a_teapot 418,\"I'm a teapot\"\n",
+		}},
+		{dataset: "mimetypes", expected: []string{
+			"type mimeType int // Extension[string]",
+			"\tapplication_json ",
+			"// application/json \".json\"\n",
+		}},
+		{dataset: "timezones", expected: []string{
+			"type timezone int",
+			"\teurope_london ",
+			"// Europe/London\n",
+			"\tutc ",
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.dataset, func(t *testing.T) {
+			dir := t.TempDir()
+			filename, err := generator.GenerateDataset(context.Background(), dir, "ref", tc.dataset, generator.Config{})
+			if err != nil {
+				t.Fatalf("failed to generate dataset, got %v", err)
+			}
+			src, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatalf("failed to read %s, got %v", filename, err)
+			}
+			for _, e := range tc.expected {
+				if !strings.Contains(string(src), e) {
+					t.Errorf("expected source to contain %s", e)
+				}
+			}
+			if _, err := os.Stat(filepath.Join(dir, tc.dataset+"_enums.go")); err != nil {
+				t.Errorf("expected generated enums, got %v", err)
+			}
+		})
+	}
+	_, err := generator.GenerateDataset(context.Background(), t.TempDir(), "ref", "planets", generator.Config{})
+	if !errors.Is(err, generator.ErrUnknownDataset) {
+		t.Errorf("expected ErrUnknownDataset, got %v", err)
+	}
+}
+
+func TestGeneratePostProcess(t *testing.T) {
+	src := []byte("package status\n\ntype status int\n\nconst (\n\tunknown status = iota // invalid\n\tactive\n)\n")
+	generator.RegisterPostProcessor("marker", func(filename string, src []byte) ([]byte, error) {
+		return append(src, "\n// post-processed "+filename+"\n"...), nil
+	})
+	files, err := generator.Generate(context.Background(), "status.go", src, generator.Config{PostProcess: []string{"compact", "group-imports", "marker"}})
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	b := files[0].Content
+	if strings.Contains(string(b), "{\n\n") || strings.Contains(string(b), "\n\n}") {
+		t.Errorf("expected no empty lines at the start or end of blocks, got\n%s", b)
+	}
+	if !strings.HasSuffix(string(b), "// post-processed statuses_enums.go\n") {
+		t.Errorf("expected the registered post-processor to run last, got\n%s", b)
+	}
+	if !strings.Contains(string(b), "// goenums -postprocess compact,group-imports,marker status.go\n") {
+		t.Errorf("expected the command to record the post-processors, got\n%s", b)
+	}
+	_, err = generator.Generate(context.Background(), "status.go", src, generator.Config{PostProcess: []string{"unknown"}})
+	if !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected %v for an unknown post-processor, got %v", generator.ErrInvalidConfig, err)
+	}
+}
+
+func TestGenerateStyle(t *testing.T) {
+	src := []byte("package status\n\n// status is the state of an order\ntype status int\n\nconst (\n\tunknown status = iota // invalid\n\tactive\n)\n")
+	style := generator.Style{
+		Receiver:    "s",
+		ErrorFormat: "{type}: cannot {action} 100% invalid value",
+		Comments:    generator.CommentsNone,
+		Order:       []string{"string", "types"},
+	}
+	files, err := generator.Generate(context.Background(), "status.go", src, generator.Config{Failfast: true, Style: style})
+	if err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	b := string(files[0].Content)
+	if !strings.Contains(b, "func (s Status) IsValid() bool {\n\treturn validStatuses[s]\n}") {
+		t.Errorf("expected the receivers to be renamed, got\n%s", b)
+	}
+	if strings.Contains(b, "func (p ") {
+		t.Errorf("expected no receiver named p, got\n%s", b)
+	}
+	if !strings.Contains(b, `fmt.Errorf("Status: cannot parse 100%% invalid value: %v", a)`) {
+		t.Errorf("expected the configured error format, got\n%s", b)
+	}
+	if strings.Contains(b, "// Count returns") || !strings.Contains(b, "// Code generated by goenums. DO NOT EDIT.") {
+		t.Errorf("expected only the header comments, got\n%s", b)
+	}
+	if str, wrapper := strings.Index(b, ") String() string"), strings.Index(b, "type Status struct"); str < 0 || wrapper < 0 || str > wrapper {
+		t.Errorf("expected the string section before the types, got\n%s", b)
+	}
+	if !strings.Contains(b, "-style-receiver s") {
+		t.Errorf("expected the command to record the style, got\n%s", b)
+	}
+	for _, style := range []generator.Style{{Receiver: "fmt"}, {Receiver: "1p"}, {Comments: "some"}, {Order: []string{"types", "types"}}, {Order: []string{"unknown"}}} {
+		_, err = generator.Generate(context.Background(), "status.go", src, generator.Config{Style: style})
+		if !errors.Is(err, generator.ErrInvalidConfig) {
+			t.Errorf("expected %v for style %+v, got %v", generator.ErrInvalidConfig, style, err)
+		}
+	}
+}
+
+func TestGenerateHeader(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/validation/status.go")
+	ctx := context.Background()
+	cfg := generator.Config{Header: generator.Header{
+		Owner:       "team-payments",
+		Docs:        []string{"https://wiki.example.com/status", "https://example.com/runbook"},
+		Description: "goenums generates this status of an order from the source, which is reported by the fulfilment service once the order has been booked.",
+	}}
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, cfg); err != nil {
+		t.Fatalf("failed to generate enums, got %v", err)
+	}
+	generated, err := os.ReadFile(filepath.Join(filepath.Dir(filename), "statuses_enums.go"))
+	if err != nil {
+		t.Fatalf("failed to read generated file, got %v", err)
+	}
+	expected := "// Owner: team-payments\n" +
+		"// Docs: https://wiki.example.com/status\n" +
+		"// Docs: https://example.com/runbook\n" +
+		"//\n" +
+		"// goenums generates this status of an order from the source, which is reported\n" +
+		"// by the fulfilment service once the order has been booked.\n"
+	if !strings.Contains(string(generated), expected) {
+		t.Errorf("expected the header details\n%s\ngot\n%s", expected, generated)
+	}
+	if err := generator.Check(ctx, filename, cfg); err != nil {
+		t.Errorf("expected generated enums to be up to date, got %v", err)
+	}
+	cfg.Header.Owner = "team-orders"
+	if err := generator.Check(ctx, filename, cfg); !errors.Is(err, generator.ErrStale) {
+		t.Errorf("expected ErrStale with another owner, got %v", err)
+	}
+	cfg.Header.Owner = "team-payments\nteam-orders"
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, cfg); !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected %v for a multi-line owner, got %v", generator.ErrInvalidConfig, err)
+	}
+}
+
+func TestCollectStats(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":           "module example.com/app\n\ngo 1.22\n",
+		"order/order.go":   "package order\n\n//go:generate goenums -f -yaml v3 order.go\n\ntype order int\n\nconst (\n\tunknown order = iota // invalid\n\tcreated\n\tshipped\n)\n",
+		"planet/planet.go": "package planet\n\ntype planet int // Gravity[float64],Moons[int]\n\nconst (\n\tmercury planet = iota // Mercury 0.378,0\n\tearth // Earth 1.0,1\n)\n",
+		"broken/broken.go": "package broken\n\ntype broken int\n\nconst (\n\ta broken = iota\n\tb\n)\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create %s, got %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatalf("failed to write %s, got %v", path, err)
+		}
+	}
+	candidates, err := generator.FindEnums(root)
+	if err != nil {
+		t.Fatalf("failed to find enums, got %v", err)
+	}
+	stats := generator.CollectStats(candidates, func(c generator.Candidate) (generator.Config, error) {
+		switch {
+		case c.Type == "broken":
+			return generator.Config{}, errors.New("bad config")
+		case c.Args != nil:
+			return generator.Config{Failfast: true, YAML: generator.YAMLv3}, nil
+		}
+		return generator.Config{}, nil
+	})
+	expected := generator.Stats{
+		Enums:           3,
+		Packages:        3,
+		Failed:          1,
+		Values:          5,
+		Invalid:         1,
+		EnumsWithFields: 1,
+		Fields:          map[string]int{"float64": 1, "int": 1},
+		Handlers:        map[string]int{"json": 2, "sql": 2, "yaml": 1},
+		Outputs:         map[string]int{"go": 2},
+		Options:         map[string]int{"f": 1, "yaml": 1},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("expected stats %+v, got %+v", expected, stats)
+	}
+	for _, name := range []string{"order/orders_enums.go", "planet/planets_enums.go"} {
+		if _, err := os.Stat(filepath.Join(root, name)); !os.IsNotExist(err) {
+			t.Errorf("expected %s not to be generated, got %v", name, err)
+		}
+	}
+}
+
+func TestMaxDiffLines(t *testing.T) {
+	filename := copyToTempDir(t, "testdata/validation/status.go")
+	ctx := context.Background()
+	guarded := generator.Config{MaxDiffLines: 10}
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, guarded); err != nil {
+		t.Fatalf("expected the first generation not to be limited, got %v", err)
+	}
+	generated := filepath.Join(filepath.Dir(filename), "statuses_enums.go")
+	before, err := os.ReadFile(generated)
+	if err != nil {
+		t.Fatalf("failed to read generated file, got %v", err)
+	}
+	guarded.Failfast = true
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, guarded); err != nil {
+		t.Errorf("expected a small change to be written, got %v", err)
+	}
+	guarded.Failfast = false
+	guarded.Accessors = true
+	guarded.YAML = generator.YAMLv3
+	err = generator.ParseAndGenerateWithConfig(ctx, filename, guarded)
+	if !errors.Is(err, generator.ErrDiffTooLarge) {
+		t.Fatalf("expected %v for a large change, got %v", generator.ErrDiffTooLarge, err)
+	}
+	after, err := os.ReadFile(generated)
+	if err != nil {
+		t.Fatalf("failed to read generated file, got %v", err)
+	}
+	if bytes.Equal(before, after) || bytes.Contains(after, []byte("UnmarshalYAML")) {
+		t.Errorf("expected only the small change to be written, got\n%s", after)
+	}
+	guarded.Force = true
+	if err := generator.ParseAndGenerateWithConfig(ctx, filename, guarded); err != nil {
+		t.Errorf("expected -force to write a large change, got %v", err)
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read source, got %v", err)
+	}
+	if _, err := generator.Generate(ctx, "status.go", src, generator.Config{MaxDiffLines: 10}); !errors.Is(err, generator.ErrInvalidConfig) {
+		t.Errorf("expected %v generating hermetically, got %v", generator.ErrInvalidConfig, err)
+	}
+}
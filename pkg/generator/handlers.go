@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Handlers are the groups of encoding methods that can be selected for one enum
+// with the handlers directive.
+const (
+	// HandlerJSON is MarshalJSON and UnmarshalJSON, and the encoding/json/v2
+	// methods with JSONv2.
+	HandlerJSON = "json"
+	// HandlerSQL is Scan, Value and the check constraint, and the pgtype methods with Pgx.
+	HandlerSQL = "sql"
+	// HandlerYAML is MarshalYAML and UnmarshalYAML for the library selected by YAML,
+	// gopkg.in/yaml.v3 when none is.
+	HandlerYAML = "yaml"
+)
+
+// handlers are the known handlers.
+var handlers = []string{HandlerJSON, HandlerSQL, HandlerYAML}
+
+// handlersDirective selects the handlers generated for one enum, overriding the
+// config, e.g. //goenums:handlers json,sql in the doc comment of the enum type.
+const handlersDirective = "handlers"
+
+// parseHandlers splits the comma separated handlers of the directive and checks
+// each one is known. An empty list generates no handlers.
+func parseHandlers(s string) ([]string, error) {
+	selected := []string{}
+	for _, h := range strings.Split(s, ",") {
+		h = strings.TrimSpace(h)
+		if h == "" {
+			continue
+		}
+		if !slices.Contains(handlers, h) {
+			return nil, fmt.Errorf("%w: %s: unknown handler %q, expected one of %s", ErrInvalidDirective, handlersDirective, h, strings.Join(handlers, ", "))
+		}
+		selected = append(selected, h)
+	}
+	return selected, nil
+}
+
+// hasHandler reports whether the methods of the handler are generated for the
+// enum, with a handlers directive on the type taking precedence over the config.
+func (rep EnumRepresentation) hasHandler(handler string) bool {
+	if rep.TypeInfo.Handlers != nil {
+		return slices.Contains(rep.TypeInfo.Handlers, handler)
+	}
+	return handler != HandlerYAML || rep.YAML != ""
+}
+
+// hasHandlers reports whether any handler is generated, as the helpers they
+// share are otherwise left out.
+func (rep EnumRepresentation) hasHandlers() bool {
+	return slices.ContainsFunc(handlers, rep.hasHandler)
+}
+
+// yamlLibrary returns the yaml library the YAML methods are generated for, or
+// an empty string when they are not generated.
+func (rep EnumRepresentation) yamlLibrary() string {
+	switch {
+	case !rep.hasHandler(HandlerYAML):
+		return ""
+	case rep.YAML == "":
+		return YAMLv3
+	}
+	return rep.YAML
+}
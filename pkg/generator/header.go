@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Header holds the ownership and documentation details written into the header
+// of the generated Go files, for code ownership tooling and readers looking for
+// the meaning of the enum.
+type Header struct {
+	// Owner is the team or person owning the enum.
+	Owner string `json:"owner,omitempty"`
+	// Docs are links to the documentation of the enum.
+	Docs []string `json:"docs,omitempty"`
+	// Description is a longer description of the enum, wrapped over several lines.
+	Description string `json:"description,omitempty"`
+}
+
+// Prefixes of the header lines recording the details.
+const (
+	ownerPrefix = "// Owner: "
+	docsPrefix  = "// Docs: "
+)
+
+// headerWidth is the width the description is wrapped to, including the //.
+const headerWidth = 80
+
+// validate checks the details fit on their header lines.
+func (h Header) validate() error {
+	for _, v := range append([]string{h.Owner, h.Description}, h.Docs...) {
+		if strings.ContainsAny(v, "\r\n") {
+			return fmt.Errorf("header details must not contain line breaks, got %q", v)
+		}
+	}
+	return nil
+}
+
+// writeHeaderDetails writes the owner, documentation links and description
+// of the enum after the generated comment.
+func writeHeaderDetails(w io.StringWriter, h Header) {
+	if h.Owner != "" {
+		w.WriteString(ownerPrefix + h.Owner + "\n")
+	}
+	for _, doc := range h.Docs {
+		w.WriteString(docsPrefix + doc + "\n")
+	}
+	if h.Description == "" {
+		return
+	}
+	w.WriteString("//\n")
+	line := "//"
+	for _, word := range strings.Fields(h.Description) {
+		if len(line) > len("//") && len(line)+1+len(word) > headerWidth {
+			w.WriteString(line + "\n")
+			line = "//"
+		}
+		line += " " + word
+	}
+	w.WriteString(line + "\n")
+}
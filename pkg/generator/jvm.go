@@ -0,0 +1,217 @@
+package generator
+
+import (
+	"go/constant"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// javaSections are the writers for the Java enum.
+var javaSections = []func(io.StringWriter, EnumRepresentation){
+	writeJVMGeneratedComment,
+	writeJavaEnum,
+}
+
+// kotlinSections are the writers for the Kotlin enum class.
+var kotlinSections = []func(io.StringWriter, EnumRepresentation){
+	writeJVMGeneratedComment,
+	writeKotlinEnum,
+}
+
+// javaReserved are the members of the generated Java enum and the keywords of
+// the language, which the accessors of the extra values cannot be named.
+var javaReserved = []string{
+	"value", "label", "valid", "isValid", "parse", "toString", "name", "ordinal", "values", "valueOf",
+	"compareTo", "equals", "hashCode", "getDeclaringClass",
+	"abstract", "assert", "boolean", "break", "byte", "case", "catch", "char", "class", "const",
+	"continue", "default", "do", "double", "else", "enum", "extends", "final", "finally", "float",
+	"for", "goto", "if", "implements", "import", "instanceof", "int", "interface", "long", "native",
+	"new", "package", "private", "protected", "public", "return", "short", "static", "strictfp",
+	"super", "switch", "synchronized", "this", "throw", "throws", "transient", "try", "void",
+	"volatile", "while", "true", "false", "null",
+}
+
+// kotlinReserved are the members of the generated Kotlin enum class and the hard
+// keywords of the language.
+var kotlinReserved = []string{
+	"value", "label", "isValid", "parse", "toString", "name", "ordinal", "entries", "values", "valueOf",
+	"compareTo", "equals", "hashCode",
+	"as", "break", "class", "continue", "do", "else", "false", "for", "fun", "if", "in", "interface",
+	"is", "null", "object", "package", "return", "super", "this", "throw", "true", "try", "typealias",
+	"typeof", "val", "var", "when", "while",
+}
+
+// checkJava rejects the enums the Java output cannot represent.
+func checkJava(rep EnumRepresentation) error {
+	if _, err := rep.foreignValues(OutputJava); err != nil {
+		return err
+	}
+	return checkForeignMembers(rep, OutputJava, javaReserved, lowerFirst)
+}
+
+// checkKotlin rejects the enums the Kotlin output cannot represent.
+func checkKotlin(rep EnumRepresentation) error {
+	if _, err := rep.foreignValues(OutputKotlin); err != nil {
+		return err
+	}
+	return checkForeignMembers(rep, OutputKotlin, kotlinReserved, lowerFirst)
+}
+
+// jvmTypes are the Java and Kotlin types of the kinds of extra values.
+var jvmTypes = map[constant.Kind][2]string{
+	constant.Bool:   {"boolean", "Boolean"},
+	constant.String: {"String", "String"},
+	constant.Int:    {"long", "Long"},
+	constant.Float:  {"double", "Double"},
+}
+
+// jvmLiteral formats the value as a Java or Kotlin literal, escaping the
+// characters in escape in strings on top of the ones both languages escape.
+func jvmLiteral(v constant.Value, escape string) string {
+	switch v.Kind() {
+	case constant.Bool:
+		return strconv.FormatBool(constant.BoolVal(v))
+	case constant.String:
+		return cQuote(constant.StringVal(v), escape)
+	case constant.Int:
+		return foreignNumber(v) + "L"
+	}
+	return foreignNumber(v)
+}
+
+func writeJVMGeneratedComment(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("// Code generated by goenums. DO NOT EDIT.\n\n")
+}
+
+// jvmArgs returns the constructor arguments of each constant: its value, name,
+// validity and extra values.
+func (rep EnumRepresentation) jvmArgs(escape string) []string {
+	// checked before generating
+	values, _ := rep.foreignValues("")
+	args := make([]string, len(rep.Enums))
+	for i, e := range rep.Enums {
+		a := []string{
+			strconv.Itoa(e.Info.Value + rep.TypeInfo.Index),
+			cQuote(e.Info.AlternateName, escape),
+			strconv.FormatBool(e.Info.Valid),
+		}
+		for _, v := range values[i] {
+			a = append(a, jvmLiteral(v, escape))
+		}
+		args[i] = strings.Join(a, ", ")
+	}
+	return args
+}
+
+// writeJavaEnum writes the enum as a Java enum whose constants carry the value,
+// name, validity and extra values of the Go constants as constructor parameters.
+func writeJavaEnum(w io.StringWriter, rep EnumRepresentation) {
+	camel := rep.TypeInfo.Camel
+	w.WriteString("package " + rep.PackageName + ";\n\n")
+	w.WriteString("/**\n")
+	w.WriteString(" * " + camel + " mirrors the " + rep.TypeInfo.Name + " constants of the Go package " + rep.PackageName + ".\n")
+	w.WriteString(" */\n")
+	w.WriteString("public enum " + camel + " {\n")
+	args := rep.jvmArgs("")
+	for i, e := range rep.Enums {
+		sep := ","
+		if i == len(rep.Enums)-1 {
+			sep = ";"
+		}
+		w.WriteString("    " + e.Info.Upper + "(" + args[i] + ")" + sep + "\n")
+	}
+	w.WriteString("\n")
+	params := []string{"int value", "String label", "boolean valid"}
+	w.WriteString("    private final int value;\n")
+	w.WriteString("    private final String label;\n")
+	w.WriteString("    private final boolean valid;\n")
+	for _, field := range rep.TypeInfo.NameTypePairs {
+		typ := jvmTypes[foreignKinds[field.Type]][0]
+		params = append(params, typ+" "+lowerFirst(field.Name))
+		w.WriteString("    private final " + typ + " " + lowerFirst(field.Name) + ";\n")
+	}
+	w.WriteString("\n")
+	w.WriteString("    " + camel + "(" + strings.Join(params, ", ") + ") {\n")
+	for _, p := range params {
+		name := p[strings.LastIndexByte(p, ' ')+1:]
+		w.WriteString("        this." + name + " = " + name + ";\n")
+	}
+	w.WriteString("    }\n\n")
+	w.WriteString("    /** Returns the value of the Go constant. */\n")
+	w.WriteString("    public int value() {\n        return value;\n    }\n\n")
+	w.WriteString("    /** Returns the name the Go code writes for the value. */\n")
+	w.WriteString("    public String label() {\n        return label;\n    }\n\n")
+	w.WriteString("    /** Reports whether the value is valid, false for the values marked invalid. */\n")
+	w.WriteString("    public boolean isValid() {\n        return valid;\n    }\n\n")
+	for _, field := range rep.TypeInfo.NameTypePairs {
+		typ := jvmTypes[foreignKinds[field.Type]][0]
+		w.WriteString("    public " + typ + " " + lowerFirst(field.Name) + "() {\n        return " + lowerFirst(field.Name) + ";\n    }\n\n")
+	}
+	w.WriteString("    @Override\n")
+	w.WriteString("    public String toString() {\n        return label;\n    }\n\n")
+	w.WriteString("    /**\n")
+	w.WriteString("     * Returns the " + camel + " named s, accepting every spelling the Go code parses.\n")
+	w.WriteString("     *\n")
+	w.WriteString("     * @throws IllegalArgumentException if s is not the name of a " + camel + "\n")
+	w.WriteString("     */\n")
+	w.WriteString("    public static " + camel + " parse(String s) {\n")
+	w.WriteString("        switch (s) {\n")
+	for i, names := range rep.parseNames() {
+		for _, name := range names {
+			w.WriteString("            case " + cQuote(name, "") + ":\n")
+		}
+		w.WriteString("                return " + rep.Enums[i].Info.Upper + ";\n")
+	}
+	w.WriteString("            default:\n")
+	w.WriteString("                throw new IllegalArgumentException(\"invalid " + camel + ": \" + s);\n")
+	w.WriteString("        }\n")
+	w.WriteString("    }\n")
+	w.WriteString("}\n")
+}
+
+// writeKotlinEnum writes the enum as a Kotlin enum class whose properties are the
+// value, name, validity and extra values of the Go constants.
+func writeKotlinEnum(w io.StringWriter, rep EnumRepresentation) {
+	camel := rep.TypeInfo.Camel
+	w.WriteString("package " + rep.PackageName + "\n\n")
+	w.WriteString("/**\n")
+	w.WriteString(" * " + camel + " mirrors the " + rep.TypeInfo.Name + " constants of the Go package " + rep.PackageName + ".\n")
+	w.WriteString(" */\n")
+	w.WriteString("enum class " + camel + "(\n")
+	w.WriteString("    val value: Int,\n")
+	w.WriteString("    val label: String,\n")
+	w.WriteString("    val isValid: Boolean,\n")
+	for _, field := range rep.TypeInfo.NameTypePairs {
+		w.WriteString("    val " + lowerFirst(field.Name) + ": " + jvmTypes[foreignKinds[field.Type]][1] + ",\n")
+	}
+	w.WriteString(") {\n")
+	args := rep.jvmArgs("$")
+	for i, e := range rep.Enums {
+		sep := ","
+		if i == len(rep.Enums)-1 {
+			sep = ";"
+		}
+		w.WriteString("    " + e.Info.Upper + "(" + args[i] + ")" + sep + "\n")
+	}
+	w.WriteString("\n")
+	w.WriteString("    override fun toString(): String = label\n\n")
+	w.WriteString("    companion object {\n")
+	w.WriteString("        /**\n")
+	w.WriteString("         * Returns the " + camel + " named [s], accepting every spelling the Go code parses.\n")
+	w.WriteString("         *\n")
+	w.WriteString("         * @throws IllegalArgumentException if [s] is not the name of a " + camel + "\n")
+	w.WriteString("         */\n")
+	w.WriteString("        fun parse(s: String): " + camel + " = when (s) {\n")
+	for i, names := range rep.parseNames() {
+		quoted := make([]string, len(names))
+		for j, name := range names {
+			quoted[j] = cQuote(name, "$")
+		}
+		w.WriteString("            " + strings.Join(quoted, ", ") + " -> " + rep.Enums[i].Info.Upper + "\n")
+	}
+	w.WriteString("            else -> throw IllegalArgumentException(\"invalid " + camel + ": $s\")\n")
+	w.WriteString("        }\n")
+	w.WriteString("    }\n")
+	w.WriteString("}\n")
+}
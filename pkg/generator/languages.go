@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// foreignKinds are the kinds of the Go types of extra values that the outputs for
+// other languages can represent, by type name.
+var foreignKinds = map[string]constant.Kind{
+	"bool":    constant.Bool,
+	"string":  constant.String,
+	"int":     constant.Int,
+	"int8":    constant.Int,
+	"int16":   constant.Int,
+	"int32":   constant.Int,
+	"int64":   constant.Int,
+	"uint":    constant.Int,
+	"uint8":   constant.Int,
+	"uint16":  constant.Int,
+	"uint32":  constant.Int,
+	"uint64":  constant.Int,
+	"float32": constant.Float,
+	"float64": constant.Float,
+}
+
+// foreignValues returns the extra values of each constant converted from their
+// Go literals for the outputs in other languages, the zero value of the type for
+// invalid constants as in the Go container. Extra values of other types or written
+// as expressions other than literals are rejected with ErrInvalidConfig, as they
+// have no counterpart in the other language.
+func (rep EnumRepresentation) foreignValues(output string) ([][]constant.Value, error) {
+	values := make([][]constant.Value, len(rep.Enums))
+	for i, e := range rep.Enums {
+		values[i] = make([]constant.Value, len(rep.TypeInfo.NameTypePairs))
+		for j, field := range rep.TypeInfo.NameTypePairs {
+			kind, ok := foreignKinds[field.Type]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s output: %s has type %s, expected a bool, string or number", ErrInvalidConfig, output, field.Name, field.Type)
+			}
+			// the Go container leaves the extra values of invalid constants unset
+			expr := ""
+			if e.Info.Valid && j < len(e.TypeInfo.NameTypePairs) {
+				expr = e.TypeInfo.NameTypePairs[j].Value
+			}
+			v, err := foreignLiteral(kind, expr)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s output: %s of %s: %w", ErrInvalidConfig, output, field.Name, e.Info.Name, err)
+			}
+			values[i][j] = v
+		}
+	}
+	return values, nil
+}
+
+// foreignLiteral converts the Go literal expr to a constant of the kind, the zero
+// value when expr is empty.
+func foreignLiteral(kind constant.Kind, expr string) (constant.Value, error) {
+	if strings.TrimSpace(expr) == "" {
+		switch kind {
+		case constant.Bool:
+			return constant.MakeBool(false), nil
+		case constant.String:
+			return constant.MakeString(""), nil
+		case constant.Float:
+			return constant.MakeFloat64(0), nil
+		}
+		return constant.MakeInt64(0), nil
+	}
+	x, err := parser.ParseExpr(expr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", expr, err)
+	}
+	v := literalValue(x)
+	switch {
+	case v.Kind() == constant.Unknown:
+	case kind == constant.Float && v.Kind() == constant.Int:
+		return constant.ToFloat(v), nil
+	case kind == constant.Int && v.Kind() == constant.Float:
+		if v = constant.ToInt(v); v.Kind() == constant.Int {
+			return v, nil
+		}
+	case v.Kind() == kind:
+		return v, nil
+	}
+	return nil, fmt.Errorf("%s is not a literal of the type", expr)
+}
+
+// literalValue returns the value of a literal, a negated number or true and
+// false, and an unknown value for any other expression.
+func literalValue(x ast.Expr) constant.Value {
+	switch x := x.(type) {
+	case *ast.BasicLit:
+		if x.Kind == token.INT || x.Kind == token.FLOAT || x.Kind == token.STRING {
+			return constant.MakeFromLiteral(x.Value, x.Kind, 0)
+		}
+	case *ast.Ident:
+		switch x.Name {
+		case "true":
+			return constant.MakeBool(true)
+		case "false":
+			return constant.MakeBool(false)
+		}
+	case *ast.ParenExpr:
+		return literalValue(x.X)
+	case *ast.UnaryExpr:
+		v := literalValue(x.X)
+		if x.Op == token.SUB && (v.Kind() == constant.Int || v.Kind() == constant.Float) {
+			return constant.UnaryOp(token.SUB, v, 0)
+		}
+	}
+	return constant.MakeUnknown()
+}
+
+// checkForeignMembers returns ErrInvalidConfig when the accessor of an extra
+// value, named by member, clashes with the members every output declares.
+func checkForeignMembers(rep EnumRepresentation, output string, reserved []string, member func(string) string) error {
+	for _, field := range rep.TypeInfo.NameTypePairs {
+		if name := member(field.Name); slices.Contains(reserved, name) {
+			return fmt.Errorf("%w: %s output: %s clashes with the generated %s", ErrInvalidConfig, output, field.Name, name)
+		}
+	}
+	return nil
+}
+
+// foreignNumber formats a numeric constant as a literal that most languages
+// read back as the same number, floats always with a fraction or exponent.
+func foreignNumber(v constant.Value) string {
+	if v.Kind() == constant.Int {
+		return v.ExactString()
+	}
+	f, _ := constant.Float64Val(v)
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// cQuote quotes s as a double quoted string literal in the C family of languages,
+// escaping quotes, backslashes and control characters as \uXXXX. Characters in
+// escape are escaped with a backslash as well.
+func cQuote(s string, escape string) string {
+	return quoteLiteral(s, escape, `\u%04x`)
+}
+
+// rustQuote quotes s as a Rust string literal, which escapes control characters
+// as \u{X}.
+func rustQuote(s string) string {
+	return quoteLiteral(s, "", `\u{%x}`)
+}
+
+// quoteLiteral quotes s in double quotes, escaping quotes, backslashes and the
+// characters in escape with a backslash and control characters with the format.
+func quoteLiteral(s string, escape string, control string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\' || strings.ContainsRune(escape, r):
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, control, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrLockMismatch is returned in Lock mode when an entry recorded in the
+// lockfile has been renamed, renumbered or removed.
+var ErrLockMismatch = fmt.Errorf("enum does not match lockfile")
+
+// lockFilename returns the name of the lockfile for the enum, e.g. status.enums.lock.
+func (rep EnumRepresentation) lockFilename() string {
+	if rep.TypeInfo.Test {
+		return strings.ToLower(rep.TypeInfo.Name) + "_test.enums.lock"
+	}
+	return strings.ToLower(rep.TypeInfo.Name) + ".enums.lock"
+}
+
+// checkLock compares the enum against the entries recorded in the lockfile. Every
+// locked name must still be generated with the same value and no locked value
+// may be given to a different name. New entries are allowed.
+func checkLock(filename string, rep EnumRepresentation) error {
+	locked, err := readLock(filename)
+	if err != nil {
+		return err
+	}
+	var problems []string
+	for _, c := range diffEntries(locked, rep.entries()) {
+		if c.Kind != changeAdded {
+			problems = append(problems, c.String())
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w %s: %s", ErrLockMismatch, filename, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// readLock reads the entries of a lockfile. A missing lockfile has no entries.
+func readLock(filename string) ([]enumEntry, error) {
+	b, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+	var entries []enumEntry
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		quoted, err := strconv.QuotedPrefix(text)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: malformed name on line %d: %w", ErrLockMismatch, filename, line, err)
+		}
+		name, _ := strconv.Unquote(quoted)
+		v, err := strconv.Atoi(strings.TrimSpace(text[len(quoted):]))
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: malformed value on line %d: %w", ErrLockMismatch, filename, line, err)
+		}
+		entries = append(entries, enumEntry{Name: name, Value: v})
+	}
+	return entries, nil
+}
+
+// lockFile returns the lockfile contents recording the enum's names and values.
+func lockFile(rep EnumRepresentation) []byte {
+	b := new(bytes.Buffer)
+	b.WriteString("# Code generated by goenums. DO NOT EDIT.\n")
+	b.WriteString("# Locked names and values for " + rep.TypeInfo.Camel + ". Entries may be added but not renamed,\n")
+	b.WriteString("# renumbered or removed. Delete this file to accept an incompatible change.\n")
+	for _, e := range rep.entries() {
+		b.WriteString(strconv.Quote(e.Name) + " " + strconv.Itoa(e.Value) + "\n")
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,221 @@
+package generator
+
+import (
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Strategies the generated stringTo function looks a name up with.
+const (
+	// ParseLookupSwitch switches on the name, the default.
+	ParseLookupSwitch = "switch"
+	// ParseLookupLength switches on the length of the name first and then on the
+	// names of that length, so a lookup compares only the names it could be.
+	ParseLookupLength = "length"
+	// ParseLookupHash looks the name up in a table indexed by a minimal perfect
+	// hash found at generation time, comparing a single name whatever the number
+	// of values and aliases.
+	ParseLookupHash = "hash"
+)
+
+// parseLookups are the known lookup strategies.
+var parseLookups = []string{ParseLookupSwitch, ParseLookupLength, ParseLookupHash}
+
+// maxHashSeed bounds the search for the seed of a bucket of the perfect hash,
+// past which the switch is written instead.
+const maxHashSeed = 1 << 20
+
+// spelling is a name parsed to the enum value at index.
+type spelling struct {
+	name  string
+	index int
+}
+
+// spellings returns every name parsed by the enum in order.
+func (rep EnumRepresentation) spellings() []spelling {
+	var all []spelling
+	for i, names := range rep.parseNames() {
+		for _, name := range names {
+			all = append(all, spelling{name: name, index: i})
+		}
+	}
+	return all
+}
+
+// writeStringToTypeLength writes stringTo switching on the length of the name
+// and then on the names of that length.
+func writeStringToTypeLength(w io.StringWriter, rep EnumRepresentation) {
+	all := rep.spellings()
+	var lengths []int
+	for _, s := range all {
+		if !slices.Contains(lengths, len(s.name)) {
+			lengths = append(lengths, len(s.name))
+		}
+	}
+	slices.Sort(lengths)
+	w.WriteString("func stringTo" + rep.TypeInfo.Camel + "(s string) " + rep.TypeInfo.Camel + " {\n")
+	w.WriteString("\tswitch len(s) {\n")
+	for _, n := range lengths {
+		w.WriteString("\tcase " + strconv.Itoa(n) + ":\n")
+		w.WriteString("\t\tswitch s {\n")
+		for i, info := range rep.Enums {
+			var cases []string
+			for _, s := range all {
+				if s.index == i && len(s.name) == n {
+					cases = append(cases, strconv.Quote(s.name))
+				}
+			}
+			if len(cases) == 0 {
+				continue
+			}
+			w.WriteString("\t\tcase " + strings.Join(cases, ", ") + ":\n")
+			w.WriteString("\t\t\treturn " + rep.TypeInfo.Container + "." + info.Info.Upper + "\n")
+		}
+		w.WriteString("\t\t}\n")
+	}
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn invalid" + rep.TypeInfo.Camel + "\n")
+	w.WriteString("}\n\n")
+}
+
+// perfectHash is a minimal perfect hash of the names parsed by an enum using
+// hash and displace: the mixed hash of a name selects a bucket, and its hash
+// mixed with the seed of the bucket selects its slot in the table.
+type perfectHash struct {
+	seeds []uint32
+	table []spelling
+}
+
+// fnv returns the FNV-1a hash of s, computed once per lookup.
+func fnv(s string) uint32 {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return h
+}
+
+// mix is the murmur3 finalizer spreading the bits of h, so the seeds xored
+// into the hash give independent slots. The generated code mirrors it.
+func mix(h uint32) uint32 {
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
+
+// newPerfectHash finds the seeds placing each name in its own slot, placing the
+// largest buckets first. It reports false when a bucket has no seed below maxHashSeed.
+func newPerfectHash(all []spelling) (perfectHash, bool) {
+	n := uint32(len(all))
+	buckets := make([][]spelling, n)
+	for _, s := range all {
+		b := mix(fnv(s.name)) % n
+		buckets[b] = append(buckets[b], s)
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return len(buckets[b]) - len(buckets[a])
+	})
+	h := perfectHash{seeds: make([]uint32, n), table: make([]spelling, n)}
+	used := make([]bool, n)
+	for _, b := range order {
+		if len(buckets[b]) == 0 {
+			break
+		}
+		slots, ok := placeBucket(buckets[b], used, &h.seeds[b])
+		if !ok {
+			return perfectHash{}, false
+		}
+		for i, slot := range slots {
+			used[slot] = true
+			h.table[slot] = buckets[b][i]
+		}
+	}
+	return h, true
+}
+
+// placeBucket searches for the first seed hashing every name of the bucket to a
+// distinct free slot, storing it in seed and returning the slots.
+func placeBucket(bucket []spelling, used []bool, seed *uint32) ([]uint32, bool) {
+	n := uint32(len(used))
+	slots := make([]uint32, len(bucket))
+	for d := uint32(1); d < maxHashSeed; d++ {
+		ok := true
+		for i, s := range bucket {
+			slot := mix(fnv(s.name)^d) % n
+			if used[slot] || slices.Contains(slots[:i], slot) {
+				ok = false
+				break
+			}
+			slots[i] = slot
+		}
+		if ok {
+			*seed = d
+			return slots, true
+		}
+	}
+	return nil, false
+}
+
+// writeStringToTypeHash writes stringTo as a lookup in a table indexed by a
+// minimal perfect hash of the names, falling back to the switch in the unlikely
+// case no hash is found.
+func writeStringToTypeHash(w io.StringWriter, rep EnumRepresentation) {
+	h, ok := newPerfectHash(rep.spellings())
+	if !ok {
+		writeStringToTypeSwitch(w, rep)
+		return
+	}
+	n := strconv.Itoa(len(h.table))
+	seeds := "_" + rep.TypeInfo.Lower + "_seeds"
+	table := "_" + rep.TypeInfo.Lower + "_lookup"
+	mixer := rep.TypeInfo.Name + "Mix"
+	w.WriteString("// " + seeds + " holds the seed of each bucket of the perfect hash of the names.\n")
+	w.WriteString("var " + seeds + " = [" + n + "]uint32{")
+	for i, seed := range h.seeds {
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		w.WriteString(strconv.FormatUint(uint64(seed), 10))
+	}
+	w.WriteString("}\n\n")
+	w.WriteString("// " + table + " holds every name in the slot given by its perfect hash.\n")
+	w.WriteString("var " + table + " = [" + n + "]struct {\n")
+	w.WriteString("\tname  string\n")
+	w.WriteString("\tvalue " + rep.TypeInfo.Camel + "\n")
+	w.WriteString("}{\n")
+	for _, s := range h.table {
+		w.WriteString("\t{" + strconv.Quote(s.name) + ", " + rep.TypeInfo.Container + "." + rep.Enums[s.index].Info.Upper + "},\n")
+	}
+	w.WriteString("}\n\n")
+	w.WriteString("func stringTo" + rep.TypeInfo.Camel + "(s string) " + rep.TypeInfo.Camel + " {\n")
+	w.WriteString("\th := uint32(2166136261)\n")
+	w.WriteString("\tfor i := 0; i < len(s); i++ {\n")
+	w.WriteString("\t\th ^= uint32(s[i])\n")
+	w.WriteString("\t\th *= 16777619\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\te := &" + table + "[" + mixer + "(h^" + seeds + "[" + mixer + "(h)%" + n + "])%" + n + "]\n")
+	w.WriteString("\tif e.name == s {\n")
+	w.WriteString("\t\treturn e.value\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn invalid" + rep.TypeInfo.Camel + "\n")
+	w.WriteString("}\n\n")
+	w.WriteString("// " + mixer + " spreads the bits of the FNV-1a hash h of a name.\n")
+	w.WriteString("func " + mixer + "(h uint32) uint32 {\n")
+	w.WriteString("\th ^= h >> 16\n")
+	w.WriteString("\th *= 0x85ebca6b\n")
+	w.WriteString("\th ^= h >> 13\n")
+	w.WriteString("\th *= 0xc2b2ae35\n")
+	w.WriteString("\th ^= h >> 16\n")
+	w.WriteString("\treturn h\n")
+	w.WriteString("}\n\n")
+}
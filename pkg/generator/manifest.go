@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"runtime"
+	"runtime/debug"
+	"sort"
+)
+
+// manifestFilename is the manifest written next to the generated files in Manifest mode.
+const manifestFilename = "goenums-manifest.json"
+
+// manifest describes the enums generated into a package for build systems,
+// listing what each was generated from and into and with which options.
+type manifest struct {
+	// Version of goenums that last wrote the manifest
+	Version string `json:"version"`
+	// GoVersion goenums was built with
+	GoVersion string `json:"goVersion"`
+	// Enums generated into the package, sorted by input
+	Enums []manifestEnum `json:"enums"`
+}
+
+// manifestEnum is the manifest entry of one generated enum. Paths are relative to
+// the directory of the manifest.
+type manifestEnum struct {
+	Input    string   `json:"input"`
+	Type     string   `json:"type"`
+	Args     []string `json:"args"`
+	Checksum string   `json:"checksum"`
+	Outputs  []string `json:"outputs"`
+}
+
+// generatorVersion returns the version of the goenums module in the running binary,
+// or (devel) when it was built from a checkout.
+func generatorVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+	if info.Main.Path == "github.com/zarldev/goenums" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == "github.com/zarldev/goenums" {
+			return dep.Version
+		}
+	}
+	return "(devel)"
+}
+
+// writeManifest records the enum in the manifest in dir, replacing any previous
+// entry for the same input so each enum of the package keeps exactly one entry.
+func writeManifest(dir string, rep EnumRepresentation, outputs []string) error {
+	filename := path.Join(dir, manifestFilename)
+	var m manifest
+	b, err := os.ReadFile(filename)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(b, &m); err != nil {
+			return fmt.Errorf("failed to read manifest: %w", err)
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("failed to read manifest: %w", err)
+	}
+	args := rep.Config.args()
+	if args == nil {
+		args = []string{}
+	}
+	entry := manifestEnum{
+		Input:    path.Base(rep.TypeInfo.Filename),
+		Type:     rep.TypeInfo.Camel,
+		Args:     args,
+		Checksum: rep.TypeInfo.Checksum,
+		Outputs:  outputs,
+	}
+	enums := []manifestEnum{entry}
+	for _, e := range m.Enums {
+		if e.Input != entry.Input {
+			enums = append(enums, e)
+		}
+	}
+	sort.Slice(enums, func(i, j int) bool { return enums[i].Input < enums[j].Input })
+	m = manifest{Version: generatorVersion(), GoVersion: runtime.Version(), Enums: enums}
+	b, err = json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	err = os.WriteFile(filename, append(b, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// mappingCSVSections are the writers for the CSV mapping table.
+var mappingCSVSections = []func(io.StringWriter, EnumRepresentation){
+	writeMappingCSV,
+}
+
+// mappingJSONSections are the writers for the JSON mapping table.
+var mappingJSONSections = []func(io.StringWriter, EnumRepresentation){
+	writeMappingJSON,
+}
+
+// mappingAliasSeparator joins the aliases of a value in a single CSV column.
+const mappingAliasSeparator = "|"
+
+// mappingEntry is a row of the mapping table: the numeric value stored for a
+// constant, its name and the other spellings Parse accepts for it.
+type mappingEntry struct {
+	Value   int      `json:"value"`
+	Name    string   `json:"name"`
+	Valid   bool     `json:"valid"`
+	Aliases []string `json:"aliases"`
+}
+
+// mapping returns the rows of the mapping table in declaration order, including
+// the values marked invalid so every number found in a column can be decoded.
+func (rep EnumRepresentation) mapping() []mappingEntry {
+	names := rep.parseNames()
+	rows := make([]mappingEntry, len(rep.Enums))
+	for i, e := range rep.Enums {
+		rows[i] = mappingEntry{
+			Value:   e.Info.Value + rep.TypeInfo.Index,
+			Name:    names[i][0],
+			Valid:   e.Info.Valid,
+			Aliases: names[i][1:],
+		}
+	}
+	return rows
+}
+
+// writeMappingCSV writes the mapping table as CSV with a header row. CSV has no
+// comments, so unlike the other outputs it is not marked as generated.
+func writeMappingCSV(w io.StringWriter, rep EnumRepresentation) {
+	var b strings.Builder
+	cw := csv.NewWriter(&b)
+	cw.Write([]string{"value", "name", "valid", "aliases"})
+	for _, e := range rep.mapping() {
+		cw.Write([]string{strconv.Itoa(e.Value), e.Name, strconv.FormatBool(e.Valid), strings.Join(e.Aliases, mappingAliasSeparator)})
+	}
+	cw.Flush()
+	w.WriteString(b.String())
+}
+
+// writeMappingJSON writes the mapping table as a JSON document naming the enum
+// type. JSON has no comments, so it is not marked as generated either.
+func writeMappingJSON(w io.StringWriter, rep EnumRepresentation) {
+	doc := struct {
+		Type   string         `json:"type"`
+		Values []mappingEntry `json:"values"`
+	}{Type: rep.TypeInfo.Camel, Values: rep.mapping()}
+	// strings, ints and bools always marshal
+	b, _ := json.MarshalIndent(doc, "", "  ")
+	w.WriteString(string(b) + "\n")
+}
@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// namesDirective selects the style display names are derived from identifiers
+// in, e.g. //goenums:names=kebab in the doc comment of the enum type.
+const namesDirective = "names"
+
+// Name styles for the names directive.
+const (
+	// NamesTitle derives "Ready To Ship" from readyToShip.
+	NamesTitle = "title"
+	// NamesSnake derives "ready_to_ship" from readyToShip.
+	NamesSnake = "snake"
+	// NamesScreaming derives "READY_TO_SHIP" from readyToShip.
+	NamesScreaming = "screaming"
+	// NamesKebab derives "ready-to-ship" from readyToShip.
+	NamesKebab = "kebab"
+)
+
+// ErrInvalidDirective is returned when a goenums directive has an unsupported value.
+var ErrInvalidDirective = fmt.Errorf("invalid directive")
+
+// directiveValue returns the value of a //goenums:<name>=<value> or
+// //goenums:<name> <value> directive in the comment group.
+func directiveValue(doc *ast.CommentGroup, name string) (string, bool) {
+	values := directiveValues(doc, name)
+	if len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
+
+// directiveValues returns the value of every directive with the name in the
+// comment group, for directives that may be repeated.
+func directiveValues(doc *ast.CommentGroup, name string) []string {
+	if doc == nil {
+		return nil
+	}
+	var values []string
+	for _, c := range doc.List {
+		directive, ok := strings.CutPrefix(c.Text, directivePrefix)
+		if !ok {
+			continue
+		}
+		directive = strings.TrimSpace(directive)
+		key, value, ok := strings.Cut(directive, "=")
+		if !ok || strings.ContainsAny(key, " \t") {
+			key, value, ok = strings.Cut(directive, " ")
+		}
+		if ok && key == name {
+			values = append(values, strings.TrimSpace(value))
+		}
+	}
+	return values
+}
+
+// typeDoc returns the doc comment of the named type, which is on the declaration
+// rather than the spec when the type is declared on its own.
+func typeDoc(node *ast.File, name string) *ast.CommentGroup {
+	for _, decl := range node.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok || typeSpec.Name.Name != name {
+				continue
+			}
+			if typeSpec.Doc == nil && len(gen.Specs) == 1 {
+				return gen.Doc
+			}
+			return typeSpec.Doc
+		}
+	}
+	return nil
+}
+
+// applyNameStyle derives the names of the enums without a name in their comment
+// from their identifiers using the style of the names directive on the type.
+func applyNameStyle(enums []Enum, style string) ([]Enum, error) {
+	var convert func(string) string
+	switch style {
+	case NamesTitle:
+		convert = titleCase
+	case NamesSnake:
+		convert = snakeCase
+	case NamesScreaming:
+		convert = screamingSnakeCase
+	case NamesKebab:
+		convert = kebabCase
+	default:
+		return nil, fmt.Errorf("%w: unknown %s style %q, expected one of %s, %s, %s or %s",
+			ErrInvalidDirective, namesDirective, style, NamesTitle, NamesSnake, NamesScreaming, NamesKebab)
+	}
+	for i := range enums {
+		if enums[i].Info.AlternateName == enums[i].Info.Ident {
+			enums[i].Info.AlternateName = convert(enums[i].Info.Ident)
+		}
+	}
+	return enums, nil
+}
+
+// trimPrefix removes prefix from the identifiers the container fields and the names
+// without one in their comment are derived from, so StatusActive becomes ACTIVE and
+// "Active". Underscores left after the prefix are removed as well, and identifiers
+// that would not start with a letter are kept as they are.
+func trimPrefix(enums []Enum, prefix string) []Enum {
+	for i, e := range enums {
+		ident, ok := strings.CutPrefix(e.Info.Ident, prefix)
+		ident = strings.TrimLeft(ident, "_")
+		if r, _ := utf8.DecodeRuneInString(ident); !ok || !unicode.IsLetter(r) {
+			continue
+		}
+		if e.Info.AlternateName == e.Info.Ident {
+			enums[i].Info.AlternateName = ident
+		}
+		enums[i].Info.Ident = ident
+		enums[i].Info.Camel = camelCase(ident)
+		enums[i].Info.Lower = strings.ToLower(ident)
+		enums[i].Info.Upper = upperCase(ident)
+	}
+	return enums
+}
+
+// words splits an identifier into its words at underscores and case changes,
+// keeping runs of capitals such as acronyms together.
+func words(in string) []string {
+	var (
+		out  []string
+		word []rune
+	)
+	runes := []rune(in)
+	for i, r := range runes {
+		if r == '_' || r == '-' {
+			if len(word) > 0 {
+				out = append(out, string(word))
+				word = nil
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := runes[i-1]
+			if !unicode.IsUpper(prev) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				out = append(out, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		out = append(out, string(word))
+	}
+	return out
+}
+
+// snakeCase converts an identifier to snake_case.
+func snakeCase(in string) string {
+	return strings.ToLower(strings.Join(words(in), "_"))
+}
+
+// screamingSnakeCase converts an identifier to SCREAMING_SNAKE_CASE.
+func screamingSnakeCase(in string) string {
+	return strings.ToUpper(strings.Join(words(in), "_"))
+}
+
+// kebabCase converts an identifier to kebab-case.
+func kebabCase(in string) string {
+	return strings.ToLower(strings.Join(words(in), "-"))
+}
+
+// titleCase converts an identifier to space separated Title Case, keeping
+// acronyms unless the whole identifier is upper case.
+func titleCase(in string) string {
+	ws := words(in)
+	screaming := strings.ToUpper(in) == in
+	for i, w := range ws {
+		if !screaming && strings.ToUpper(w) == w && len([]rune(w)) > 1 {
+			continue
+		}
+		ws[i] = string(unicode.ToUpper([]rune(w)[0])) + strings.ToLower(string([]rune(w)[1:]))
+	}
+	return strings.Join(ws, " ")
+}
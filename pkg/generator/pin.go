@@ -0,0 +1,321 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pinnedDirective marks a const block whose enum constants are given explicit
+// values instead of iota, so adding, removing or reordering constants never
+// changes the value of another, e.g.
+//
+//	//goenums:pinned
+//	const (
+//		unknown status = 0 // invalid
+//		passed  status = 2
+//		failed  status = 1
+//	)
+//
+// The values need not be in order or contiguous, the gaps are handled like
+// those left by blank constants.
+const pinnedDirective = "pinned"
+
+// ErrDuplicateValue is returned when two constants of a pinned block have the
+// same value.
+var ErrDuplicateValue = fmt.Errorf("duplicate enum value")
+
+// pinnedValue returns the value of an integer literal.
+func pinnedValue(expr ast.Expr) (int, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(lit.Value, 0, 0)
+	return int(v), err == nil
+}
+
+// pinnedSpec reports whether the spec of a pinned block gives each of its
+// constants an integer literal, either untyped or of the enum type.
+func pinnedSpec(valueSpec *ast.ValueSpec, typeName string) bool {
+	if len(valueSpec.Values) != len(valueSpec.Names) {
+		return false
+	}
+	if valueSpec.Type != nil {
+		if typ, ok := valueSpec.Type.(*ast.Ident); !ok || typ.Name != typeName {
+			return false
+		}
+	}
+	for _, v := range valueSpec.Values {
+		if _, ok := pinnedValue(v); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// pinnedInfo is iotaInfo for a pinned block, where the enum starts at the first
+// typed constant with an explicit value. Its index is the lowest value, set by
+// rebasePinned once every constant is found.
+func pinnedInfo(valueSpec *ast.ValueSpec, typeComments map[string]string) (string, string, string, int) {
+	typ, ok := valueSpec.Type.(*ast.Ident)
+	if !ok || !pinnedSpec(valueSpec, typ.Name) {
+		return "", "", "", 0
+	}
+	return valueSpec.Names[0].Name, typ.Name, typeComments[typ.Name], 0
+}
+
+// rebasePinned orders the constants of a pinned block by value and makes their
+// values relative to the lowest, returned as the index of the enum, the same as
+// the values of iota constants are relative to the offset added to iota.
+func rebasePinned(enums []Enum) ([]Enum, int) {
+	if len(enums) == 0 {
+		return enums, 0
+	}
+	sort.SliceStable(enums, func(i, j int) bool {
+		return enums[i].Info.Value < enums[j].Info.Value
+	})
+	index := enums[0].Info.Value
+	for i := range enums {
+		enums[i].Info.Value -= index
+	}
+	return enums, index
+}
+
+// checkDuplicateValues returns ErrDuplicateValue when two constants of the enum,
+// ordered by value, share a value, as a pinned block or a spec with several
+// names such as a, b status = iota, iota can declare.
+func checkDuplicateValues(rep EnumRepresentation) error {
+	for i := 1; i < len(rep.Enums); i++ {
+		prev, e := rep.Enums[i-1].Info, rep.Enums[i].Info
+		if prev.Value == e.Value {
+			return fmt.Errorf("%w: %s and %s are both %d", ErrDuplicateValue, prev.Name, e.Name, e.Value+rep.TypeInfo.Index)
+		}
+	}
+	return nil
+}
+
+// Renumbering is a value of an enum whose number changed, found by FindRenumbered.
+type Renumbering struct {
+	// Constant declares the value and Name is the name it is parsed by.
+	Constant string
+	Name     string
+	Previous int
+	Current  int
+}
+
+func (r Renumbering) String() string {
+	return fmt.Sprintf("%s (%s) renumbered from %d to %d", r.Constant, strconv.Quote(r.Name), r.Previous, r.Current)
+}
+
+// FindRenumbered returns the values of the enum in filename whose number is no
+// longer the one it had before, matched by the name they are parsed by. The
+// previous numbers are those of previous, the source of the file at an earlier
+// revision, or when previous is nil those of the previously generated file, and
+// nothing is found when it does not exist. Inserting, removing or moving a
+// constant of an iota block renumbers the constants after it, silently changing
+// the meaning of the numbers already stored.
+func FindRenumbered(ctx context.Context, filename string, previous []byte, cfg Config) ([]Renumbering, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	rep, err := parseRepresentation(filename, nil, cfg)
+	if err != nil {
+		return nil, err
+	}
+	var entries []enumEntry
+	if previous != nil {
+		prev, err := parseRepresentation(filename, previous, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse previous version: %w", err)
+		}
+		entries = prev.entries()
+	} else {
+		generated, err := readGenerated(filepath.Join(filepath.Dir(filename), rep.TypeInfo.Lower+rep.goSuffix("_enums")), rep.TypeInfo.Camel)
+		if err != nil || generated == nil {
+			return nil, err
+		}
+		entries = generated.Entries
+	}
+	constants := make(map[string]string, len(rep.Enums))
+	for _, e := range rep.Enums {
+		constants[e.Info.AlternateName] = e.Info.Name
+	}
+	var found []Renumbering
+	for _, c := range diffEntries(entries, rep.entries()) {
+		if c.Kind != changeRenumbered {
+			continue
+		}
+		found = append(found, Renumbering{
+			Constant: constants[c.Current.Name],
+			Name:     c.Current.Name,
+			Previous: c.Previous.Value,
+			Current:  c.Current.Value,
+		})
+	}
+	return found, nil
+}
+
+// PinValues rewrites the const block of the enum in filename to give each of
+// its constants an explicit value under the //goenums:pinned directive instead
+// of iota, and regenerates the enum with cfg. A constant is pinned to its value
+// in values, keyed by the name it is parsed by, when it has one and to its
+// current value otherwise, or to a value after all the others when another
+// constant is pinned to that. With no values every constant keeps its current
+// value, freezing the numbers before the block is refactored.
+func PinValues(ctx context.Context, filename string, cfg Config, values map[string]int) error {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	rep, err := Parse(ctx, filename, src, cfg)
+	if err != nil {
+		return err
+	}
+	if len(rep.Enums) == 0 {
+		return fmt.Errorf("%s declares no enum constants", filepath.Base(filename))
+	}
+	pins := pinnedValues(rep, values)
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	decl := constDecl(node, rep.Enums[0].Info.Name)
+	if decl == nil {
+		return fmt.Errorf("%s is not declared in %s", rep.Enums[0].Info.Name, filepath.Base(filename))
+	}
+	edits := pinEdits(fset, decl, rep.TypeInfo.Name, pins)
+	if !hasDirective(decl.Doc, pinnedDirective) {
+		start := fset.Position(decl.Pos()).Offset
+		edits = append(edits, edit{start, start, directivePrefix + pinnedDirective + "\n"})
+	}
+	pinned, err := applyEdits(src, edits)
+	if err != nil {
+		return err
+	}
+	// the rewritten source is checked before it replaces the original
+	if _, err := Parse(ctx, filename, pinned, cfg); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, pinned, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	return ParseAndGenerateWithConfig(ctx, filename, cfg)
+}
+
+// pinnedValues returns the value each constant of the enum is pinned to, by
+// constant name, from values keyed by the names the constants are parsed by.
+func pinnedValues(rep EnumRepresentation, values map[string]int) map[string]int {
+	pins := make(map[string]int, len(rep.Enums))
+	taken := make(map[int]bool, len(rep.Enums))
+	last := 0
+	for _, e := range rep.Enums {
+		if v, ok := values[e.Info.AlternateName]; ok {
+			pins[e.Info.Name] = v
+			taken[v] = true
+		}
+		last = max(last, e.Info.Value+rep.TypeInfo.Index)
+	}
+	for v := range taken {
+		last = max(last, v)
+	}
+	for _, e := range rep.Enums {
+		if _, ok := pins[e.Info.Name]; ok {
+			continue
+		}
+		v := e.Info.Value + rep.TypeInfo.Index
+		if taken[v] {
+			last++
+			v = last
+		}
+		pins[e.Info.Name] = v
+		taken[v] = true
+	}
+	return pins
+}
+
+// constDecl returns the const declaration declaring name.
+func constDecl(node *ast.File, name string) *ast.GenDecl {
+	for _, decl := range node.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			for _, ident := range spec.(*ast.ValueSpec).Names {
+				if ident.Name == name {
+					return gen
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// pinEdits returns the edits giving each constant of the const declaration in
+// pins its value, typed as typeName. The blank constants leaving gaps between
+// iota values are removed, as the gaps are in the values themselves.
+func pinEdits(fset *token.FileSet, decl *ast.GenDecl, typeName string, pins map[string]int) []edit {
+	var edits []edit
+	for _, spec := range decl.Specs {
+		valueSpec := spec.(*ast.ValueSpec)
+		var names, values []string
+		enum := false
+		for _, ident := range valueSpec.Names {
+			if v, ok := pins[ident.Name]; ok {
+				enum = true
+				names = append(names, ident.Name)
+				values = append(values, strconv.Itoa(v))
+			}
+		}
+		switch {
+		case enum:
+			var end ast.Node = valueSpec.Names[len(valueSpec.Names)-1]
+			if valueSpec.Type != nil {
+				end = valueSpec.Type
+			}
+			if len(valueSpec.Values) > 0 {
+				end = valueSpec.Values[len(valueSpec.Values)-1]
+			}
+			edits = append(edits, edit{
+				fset.Position(valueSpec.Pos()).Offset,
+				fset.Position(end.End()).Offset,
+				strings.Join(names, ", ") + " " + typeName + " = " + strings.Join(values, ", "),
+			})
+		case len(valueSpec.Values) == 0 && blankNames(valueSpec) && decl.Lparen.IsValid():
+			start, end := valueSpec.Pos(), valueSpec.End()
+			if valueSpec.Doc != nil {
+				start = valueSpec.Doc.Pos()
+			}
+			if valueSpec.Comment != nil {
+				end = valueSpec.Comment.End()
+			}
+			file := fset.File(start)
+			endLine := fset.Position(end).Line
+			endOffset := fset.Position(decl.Rparen).Offset
+			if endLine < file.LineCount() {
+				endOffset = min(endOffset, file.Offset(file.LineStart(endLine+1)))
+			}
+			edits = append(edits, edit{file.Offset(file.LineStart(fset.Position(start).Line)), endOffset, ""})
+		}
+	}
+	return edits
+}
+
+// blankNames reports whether every constant of the spec is blank.
+func blankNames(valueSpec *ast.ValueSpec) bool {
+	for _, ident := range valueSpec.Names {
+		if ident.Name != "_" {
+			return false
+		}
+	}
+	return true
+}
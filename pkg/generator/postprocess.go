@@ -0,0 +1,201 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PostProcessor rewrites a generated Go file after it has been formatted with gofmt,
+// for repositories enforcing stricter formatting. filename is the name of the
+// generated file and the returned source must still be valid Go.
+type PostProcessor func(filename string, src []byte) ([]byte, error)
+
+// Post-processors registered by default.
+const (
+	// PostProcessCompact removes the empty lines at the start and end of blocks
+	// and adds a space after the // of comments that are not directives, the
+	// gofumpt rules the generated code can break. It is not gofumpt itself.
+	PostProcessCompact = "compact"
+	// PostProcessGroupImports groups the imports into a standard library section
+	// followed by a section for every other package, the default sections of gci.
+	PostProcessGroupImports = "group-imports"
+)
+
+var (
+	postProcessorsMu sync.RWMutex
+	// postProcessors are the registered post-processors by name.
+	postProcessors = map[string]PostProcessor{
+		PostProcessCompact:      compact,
+		PostProcessGroupImports: groupImports,
+	}
+)
+
+// RegisterPostProcessor registers p under name so it can be selected with the
+// PostProcess option, replacing any post-processor already registered with the
+// name. Programs embedding the generator use it to run the real formatters in
+// process, e.g. mvdan.cc/gofumpt/format.Source registered as gofumpt.
+func RegisterPostProcessor(name string, p PostProcessor) {
+	postProcessorsMu.Lock()
+	defer postProcessorsMu.Unlock()
+	postProcessors[name] = p
+}
+
+// PostProcessors returns the names of the registered post-processors in sorted order.
+func PostProcessors() []string {
+	postProcessorsMu.RLock()
+	defer postProcessorsMu.RUnlock()
+	names := make([]string, 0, len(postProcessors))
+	for name := range postProcessors {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// postProcessor returns the post-processor registered under name.
+func postProcessor(name string) (PostProcessor, bool) {
+	postProcessorsMu.RLock()
+	defer postProcessorsMu.RUnlock()
+	p, ok := postProcessors[name]
+	return p, ok
+}
+
+// validatePostProcessors checks every post-processor is registered.
+func validatePostProcessors(names []string) error {
+	for _, name := range names {
+		if _, ok := postProcessor(name); !ok {
+			return fmt.Errorf("unknown post-processor %q, expected one of %s", name, strings.Join(PostProcessors(), ", "))
+		}
+	}
+	return nil
+}
+
+// postProcess runs the post-processors of the config over the generated Go file
+// in order, each one receiving the output of the previous one.
+func postProcess(names []string, filename string, src []byte) ([]byte, error) {
+	for _, name := range names {
+		p, ok := postProcessor(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown post-processor %q", ErrInvalidConfig, name)
+		}
+		b, err := p(filename, src)
+		if err != nil {
+			return nil, fmt.Errorf("failed to post-process %s with %s: %w", filename, name, err)
+		}
+		src = b
+	}
+	return src, nil
+}
+
+// compact removes the empty lines gofmt leaves after an opening brace or
+// parenthesis and before a closing one, and adds a space after the // of line
+// comments that are not directives such as //go:build or //coverage:ignore.
+func compact(filename string, src []byte) ([]byte, error) {
+	lines := bytes.Split(src, []byte("\n"))
+	out := make([][]byte, 0, len(lines))
+	for i, line := range lines {
+		trimmed := bytes.TrimSpace(line)
+		if len(trimmed) == 0 && i > 0 && i < len(lines)-1 {
+			prev := bytes.TrimSpace(out[len(out)-1])
+			next := bytes.TrimSpace(lines[i+1])
+			if bytes.HasSuffix(prev, []byte("{")) || bytes.HasSuffix(prev, []byte("(")) ||
+				bytes.HasPrefix(next, []byte("}")) || bytes.HasPrefix(next, []byte(")")) {
+				continue
+			}
+		}
+		if text, ok := bytes.CutPrefix(trimmed, []byte("//")); ok && len(text) > 0 && text[0] != ' ' && text[0] != '\t' && !isDirective(text) {
+			indent := line[:len(line)-len(bytes.TrimLeft(line, " \t"))]
+			line = append(append(bytes.Clone(indent), "// "...), text...)
+		}
+		out = append(out, line)
+	}
+	return format.Source(bytes.Join(out, []byte("\n")))
+}
+
+// isDirective reports whether the text of a line comment after the // is a
+// directive, a lower case word and a colon directly followed by text, such as
+// go:build or goenums:invalid.
+func isDirective(text []byte) bool {
+	word, rest, ok := bytes.Cut(text, []byte(":"))
+	if !ok || len(word) == 0 || len(rest) == 0 || rest[0] == ' ' {
+		return false
+	}
+	for _, c := range word {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// groupImports rewrites the import block with the standard library imports in the first
+// section and every other import in a second one, each sorted by path.
+func groupImports(filename string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filename, src, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+	if len(node.Decls) == 0 {
+		return src, nil
+	}
+	var std, other []string
+	for _, imp := range node.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		spec := imp.Path.Value
+		if imp.Name != nil {
+			spec = imp.Name.Name + " " + spec
+		}
+		first, _, _ := strings.Cut(p, "/")
+		if strings.Contains(first, ".") {
+			other = append(other, spec)
+			continue
+		}
+		std = append(std, spec)
+	}
+	if len(std)+len(other) == 0 {
+		return src, nil
+	}
+	var start, end int
+	for _, decl := range node.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			if start == 0 {
+				start = fset.Position(gen.Pos()).Offset
+			}
+			end = fset.Position(gen.End()).Offset
+		}
+	}
+	byPath := func(a, b string) int {
+		_, pa, _ := strings.Cut(a, "\"")
+		_, pb, _ := strings.Cut(b, "\"")
+		return strings.Compare(pa, pb)
+	}
+	slices.SortFunc(std, byPath)
+	slices.SortFunc(other, byPath)
+	var b bytes.Buffer
+	b.Write(src[:start])
+	b.WriteString("import (\n")
+	for _, spec := range std {
+		b.WriteString("\t" + spec + "\n")
+	}
+	if len(std) > 0 && len(other) > 0 {
+		b.WriteString("\n")
+	}
+	for _, spec := range other {
+		b.WriteString("\t" + spec + "\n")
+	}
+	b.WriteString(")")
+	b.Write(src[end:])
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"go/constant"
+	"io"
+	"strconv"
+)
+
+// pythonSections are the writers for the Python module.
+var pythonSections = []func(io.StringWriter, EnumRepresentation){
+	writePythonGeneratedComment,
+	writePythonEnum,
+	writePythonTables,
+}
+
+// checkPython rejects the enums the Python output cannot represent.
+func checkPython(rep EnumRepresentation) error {
+	_, err := rep.foreignValues(OutputPython)
+	return err
+}
+
+// pythonLiteral formats the value as a Python literal.
+func pythonLiteral(v constant.Value) string {
+	switch v.Kind() {
+	case constant.Bool:
+		if constant.BoolVal(v) {
+			return "True"
+		}
+		return "False"
+	case constant.String:
+		return cQuote(constant.StringVal(v), "")
+	}
+	return foreignNumber(v)
+}
+
+func writePythonGeneratedComment(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("# Code generated by goenums. DO NOT EDIT.\n")
+	w.WriteString("\"\"\"" + rep.TypeInfo.Camel + " mirrors the " + rep.TypeInfo.Name + " constants of the Go package " + rep.PackageName + ".\"\"\"\n\n")
+	w.WriteString("import enum\n\n\n")
+}
+
+// writePythonEnum writes the enum as an enum.Enum with the values of the Go
+// constants, whose names, validity and extra values are looked up in the tables.
+func writePythonEnum(w io.StringWriter, rep EnumRepresentation) {
+	camel := rep.TypeInfo.Camel
+	w.WriteString("class " + camel + "(enum.Enum):\n")
+	w.WriteString("    \"\"\"" + camel + " values by the value of the Go constant.\"\"\"\n\n")
+	for _, e := range rep.Enums {
+		w.WriteString("    " + e.Info.Upper + " = " + strconv.Itoa(e.Info.Value+rep.TypeInfo.Index) + "\n")
+	}
+	w.WriteString("\n")
+	w.WriteString("    @property\n")
+	w.WriteString("    def label(self) -> str:\n")
+	w.WriteString("        \"\"\"The name the Go code writes for the value.\"\"\"\n")
+	w.WriteString("        return _LABELS[self]\n\n")
+	w.WriteString("    @property\n")
+	w.WriteString("    def is_valid(self) -> bool:\n")
+	w.WriteString("        \"\"\"Whether the value is valid, False for the values marked invalid.\"\"\"\n")
+	w.WriteString("        return self in _VALID\n\n")
+	w.WriteString("    @property\n")
+	w.WriteString("    def metadata(self) -> dict:\n")
+	w.WriteString("        \"\"\"The extra values of the value by their snake case names.\"\"\"\n")
+	w.WriteString("        return _METADATA[self]\n\n")
+	w.WriteString("    def __str__(self) -> str:\n")
+	w.WriteString("        return self.label\n\n")
+	w.WriteString("    @classmethod\n")
+	w.WriteString("    def parse(cls, s: str) -> \"" + camel + "\":\n")
+	w.WriteString("        \"\"\"Returns the " + camel + " named s, accepting every spelling the Go code parses.\n\n")
+	w.WriteString("        Raises ValueError when s is not the name of a " + camel + ".\n")
+	w.WriteString("        \"\"\"\n")
+	w.WriteString("        try:\n")
+	w.WriteString("            return _PARSE[s]\n")
+	w.WriteString("        except KeyError:\n")
+	w.WriteString("            raise ValueError(f\"invalid " + camel + ": {s!r}\") from None\n\n\n")
+}
+
+// writePythonTables writes the module level tables the enum looks up its names,
+// validity, extra values and parsed spellings in.
+func writePythonTables(w io.StringWriter, rep EnumRepresentation) {
+	camel := rep.TypeInfo.Camel
+	// checked before generating
+	values, _ := rep.foreignValues("")
+	w.WriteString("_LABELS = {\n")
+	for _, e := range rep.Enums {
+		w.WriteString("    " + camel + "." + e.Info.Upper + ": " + cQuote(e.Info.AlternateName, "") + ",\n")
+	}
+	w.WriteString("}\n\n")
+	w.WriteString("_VALID = frozenset({\n")
+	for _, e := range rep.Enums {
+		if e.Info.Valid {
+			w.WriteString("    " + camel + "." + e.Info.Upper + ",\n")
+		}
+	}
+	w.WriteString("})\n\n")
+	w.WriteString("_METADATA = {\n")
+	for i, e := range rep.Enums {
+		w.WriteString("    " + camel + "." + e.Info.Upper + ": {")
+		for j, field := range rep.TypeInfo.NameTypePairs {
+			if j > 0 {
+				w.WriteString(", ")
+			}
+			w.WriteString(cQuote(snakeCase(field.Name), "") + ": " + pythonLiteral(values[i][j]))
+		}
+		w.WriteString("},\n")
+	}
+	w.WriteString("}\n\n")
+	w.WriteString("_PARSE = {\n")
+	for i, names := range rep.parseNames() {
+		for _, name := range names {
+			w.WriteString("    " + cQuote(name, "") + ": " + camel + "." + rep.Enums[i].Info.Upper + ",\n")
+		}
+	}
+	w.WriteString("}\n")
+}
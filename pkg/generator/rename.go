@@ -0,0 +1,209 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// edit replaces the bytes of a file between two offsets.
+type edit struct {
+	start, end int
+	text       string
+}
+
+// applyEdits returns src with the edits applied and gofmt run over the result.
+func applyEdits(src []byte, edits []edit) ([]byte, error) {
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].start > edits[j].start
+	})
+	out := slices.Clone(src)
+	for _, e := range edits {
+		out = slices.Concat(out[:e.start], []byte(e.text), out[e.end:])
+	}
+	return format.Source(out)
+}
+
+// Rename renames the constant oldName of the enum declared in filename to newName,
+// along with the references to it in the file, and regenerates the enum with cfg.
+// The name the value was parsed and marshalled with is kept as a parse alias
+// when renaming the constant changes it, so stored values still decode. With
+// refs, the references to the constant and to its container field are also
+// rewritten across the module, tests included. It returns the files changed
+// other than the generated ones.
+func Rename(ctx context.Context, filename string, cfg Config, oldName, newName string, refs bool) ([]string, error) {
+	if !token.IsIdentifier(newName) {
+		return nil, fmt.Errorf("%q is not a valid constant name", newName)
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	before, err := Parse(ctx, filename, src, cfg)
+	if err != nil {
+		return nil, err
+	}
+	index := slices.IndexFunc(before.Enums, func(e Enum) bool { return e.Info.Name == oldName })
+	if index < 0 {
+		return nil, fmt.Errorf("%s has no constant %s", before.TypeInfo.Camel, oldName)
+	}
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	if node.Scope.Lookup(newName) != nil {
+		return nil, fmt.Errorf("%s is already declared in %s", newName, filepath.Base(filename))
+	}
+	obj := node.Scope.Lookup(oldName)
+	if obj == nil {
+		return nil, fmt.Errorf("%s is not declared in %s", oldName, filepath.Base(filename))
+	}
+	spec, ok := obj.Decl.(*ast.ValueSpec)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a constant", oldName)
+	}
+	// the declaration and every reference resolved to it in the file
+	var edits []edit
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Obj == obj {
+			edits = append(edits, edit{fset.Position(ident.Pos()).Offset, fset.Position(ident.End()).Offset, newName})
+		}
+		return true
+	})
+	renamed, err := applyEdits(src, slices.Clone(edits))
+	if err != nil {
+		return nil, err
+	}
+	after, err := Parse(ctx, filename, renamed, cfg)
+	if err != nil {
+		return nil, err
+	}
+	name := before.parseNames()[index][0]
+	if !slices.Contains(after.parseNames()[index], name) {
+		edits = append(edits, aliasEdit(fset, spec, name))
+		if renamed, err = applyEdits(src, edits); err != nil {
+			return nil, err
+		}
+		if after, err = Parse(ctx, filename, renamed, cfg); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.WriteFile(filename, renamed, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	changed := []string{filename}
+	if refs {
+		files, err := renameReferences(ctx, filename, before, after, index)
+		if err != nil {
+			return changed, err
+		}
+		changed = append(changed, files...)
+	}
+	if err := ParseAndGenerateWithConfig(ctx, filename, cfg); err != nil {
+		return changed, err
+	}
+	return changed, nil
+}
+
+// aliasEdit returns the edit adding name to the parse tag of the value comment
+// of spec, adding the tag or the comment when there is none.
+func aliasEdit(fset *token.FileSet, spec *ast.ValueSpec, name string) edit {
+	if spec.Comment == nil {
+		end := fset.Position(spec.End()).Offset
+		return edit{end, end, " // " + parseTag + ":" + strconv.Quote(name)}
+	}
+	c := spec.Comment.List[len(spec.Comment.List)-1]
+	start := fset.Position(c.Pos()).Offset
+	text := c.Text
+	for _, part := range splitQuoted(text, ' ') {
+		value, ok := strings.CutPrefix(part, parseTag+":")
+		if !ok {
+			continue
+		}
+		if names, err := strconv.Unquote(value); err == nil {
+			i := strings.Index(text, part)
+			text = text[:i] + parseTag + ":" + strconv.Quote(names+","+name) + text[i+len(part):]
+			return edit{start, start + len(c.Text), text}
+		}
+	}
+	if strings.HasPrefix(text, "/*") {
+		text = strings.TrimSuffix(text, "*/") + " " + parseTag + ":" + strconv.Quote(name) + " */"
+	} else {
+		text += " " + parseTag + ":" + strconv.Quote(name)
+	}
+	return edit{start, start + len(c.Text), text}
+}
+
+// renameReferences rewrites the references to the value at index of the enum in
+// the module enclosing filename, other than in filename itself, from its names
+// in before to those in after, returning the files changed.
+func renameReferences(ctx context.Context, filename string, before, after EnumRepresentation, index int) ([]string, error) {
+	importPath, err := packageImportPath(filepath.Dir(filename))
+	if err != nil {
+		return nil, err
+	}
+	e := &usageEnum{
+		usage:     EnumUsage{ImportPath: importPath, Type: before.TypeInfo.Camel},
+		pkg:       before.PackageName,
+		container: before.TypeInfo.PluralCamel,
+		uppers:    map[string]int{before.Enums[index].Info.Upper: index},
+		constants: map[string]int{before.Enums[index].Info.Name: index},
+	}
+	enums := []*usageEnum{e}
+	byContainer := map[string]*usageEnum{importPath + "." + e.container: e}
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	err = walkModule(filepath.Dir(filename), true, func(fset *token.FileSet, path string, node *ast.File) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if path == abs {
+			return nil
+		}
+		fileImportPath, err := packageImportPath(filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		var edits []edit
+		references(node, fileImportPath, enums, byContainer, func(_ *usageEnum, _ int, ident *ast.Ident, field bool, _ ast.Node) {
+			text := after.Enums[index].Info.Name
+			if field {
+				text = after.Enums[index].Info.Upper
+			}
+			edits = append(edits, edit{fset.Position(ident.Pos()).Offset, fset.Position(ident.End()).Offset, text})
+		})
+		if len(edits) == 0 {
+			return nil
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		out, err := applyEdits(src, edits)
+		if err != nil {
+			return fmt.Errorf("failed to rewrite %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, out, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		changed = append(changed, path)
+		return nil
+	})
+	if err != nil {
+		return changed, fmt.Errorf("failed to rename references: %w", err)
+	}
+	return changed, nil
+}
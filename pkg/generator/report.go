@@ -0,0 +1,165 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// enumEntry is the canonical name and numeric value of an enum entry.
+type enumEntry struct {
+	Name  string
+	Value int
+}
+
+// entries returns the names and values of the enum being generated.
+func (rep EnumRepresentation) entries() []enumEntry {
+	entries := make([]enumEntry, len(rep.Enums))
+	for i, e := range rep.Enums {
+		entries[i] = enumEntry{Name: e.Info.AlternateName, Value: e.Info.Value + rep.TypeInfo.Index}
+	}
+	return entries
+}
+
+// changeKind describes how an enum entry changed between two versions.
+type changeKind string
+
+const (
+	changeAdded      changeKind = "added"
+	changeRemoved    changeKind = "removed"
+	changeRenamed    changeKind = "renamed"
+	changeRenumbered changeKind = "renumbered"
+)
+
+// change is a single difference between two versions of an enum.
+type change struct {
+	Kind changeKind
+	// Previous is the entry before the change, empty when added
+	Previous enumEntry
+	// Current is the entry after the change, empty when removed
+	Current enumEntry
+}
+
+func (c change) String() string {
+	switch c.Kind {
+	case changeAdded:
+		return fmt.Sprintf("added %s = %d", strconv.Quote(c.Current.Name), c.Current.Value)
+	case changeRemoved:
+		return fmt.Sprintf("removed %s = %d", strconv.Quote(c.Previous.Name), c.Previous.Value)
+	case changeRenamed:
+		return fmt.Sprintf("renamed %s to %s = %d", strconv.Quote(c.Previous.Name), strconv.Quote(c.Current.Name), c.Current.Value)
+	default:
+		return fmt.Sprintf("renumbered %s from %d to %d", strconv.Quote(c.Current.Name), c.Previous.Value, c.Current.Value)
+	}
+}
+
+// diffEntries returns the changes from the previous to the current entries.
+// Entries are matched by name, and an entry whose name disappeared while its
+// value is now used by a new name is reported as renamed.
+func diffEntries(previous, current []enumEntry) []change {
+	prevByName := make(map[string]enumEntry, len(previous))
+	for _, e := range previous {
+		prevByName[e.Name] = e
+	}
+	currByName := make(map[string]enumEntry, len(current))
+	for _, e := range current {
+		currByName[e.Name] = e
+	}
+	renamedTo := make(map[string]bool)
+	var changes []change
+	for _, p := range previous {
+		c, ok := currByName[p.Name]
+		switch {
+		case ok && c.Value != p.Value:
+			changes = append(changes, change{Kind: changeRenumbered, Previous: p, Current: c})
+		case !ok:
+			if n, found := renamedEntry(p, current, prevByName, renamedTo); found {
+				renamedTo[n.Name] = true
+				changes = append(changes, change{Kind: changeRenamed, Previous: p, Current: n})
+			} else {
+				changes = append(changes, change{Kind: changeRemoved, Previous: p})
+			}
+		}
+	}
+	for _, c := range current {
+		if _, ok := prevByName[c.Name]; !ok && !renamedTo[c.Name] {
+			changes = append(changes, change{Kind: changeAdded, Current: c})
+		}
+	}
+	return changes
+}
+
+// renamedEntry finds the new entry taking over the value of a removed entry.
+func renamedEntry(p enumEntry, current []enumEntry, prevByName map[string]enumEntry, taken map[string]bool) (enumEntry, bool) {
+	for _, c := range current {
+		if _, existed := prevByName[c.Name]; !existed && !taken[c.Name] && c.Value == p.Value {
+			return c, true
+		}
+	}
+	return enumEntry{}, false
+}
+
+// writeReport writes a human readable report of the changes to the enum,
+// headed by the commands that generated the previous and current versions.
+func writeReport(w io.Writer, camel, previousCommand, currentCommand string, changes []change) error {
+	_, err := fmt.Fprintf(w, "%s changes\n", camel)
+	if err != nil {
+		return err
+	}
+	if previousCommand != "" {
+		fmt.Fprintf(w, "  previous: %s\n", previousCommand)
+	}
+	fmt.Fprintf(w, "  current:  %s\n", currentCommand)
+	if len(changes) == 0 {
+		_, err = fmt.Fprintln(w, "  no changes")
+		return err
+	}
+	for _, c := range changes {
+		_, err = fmt.Fprintf(w, "  %s\n", c)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteReleaseNotes writes markdown release notes describing how the enum in
+// filename changed between the previous and current versions of its source.
+// Removals, renames and renumberings are marked as breaking.
+func WriteReleaseNotes(w io.Writer, filename string, previous, current []byte) error {
+	prev, err := parseRepresentation(filename, previous, Config{})
+	if err != nil {
+		return fmt.Errorf("failed to parse previous version: %w", err)
+	}
+	curr, err := parseRepresentation(filename, current, Config{})
+	if err != nil {
+		return fmt.Errorf("failed to parse current version: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "### %s\n\n", curr.TypeInfo.Camel)
+	if err != nil {
+		return err
+	}
+	changes := diffEntries(prev.entries(), curr.entries())
+	if len(changes) == 0 {
+		_, err = fmt.Fprintln(w, "No changes.")
+		return err
+	}
+	for _, c := range changes {
+		var line string
+		switch c.Kind {
+		case changeAdded:
+			line = fmt.Sprintf("- Added `%s` (%d)", c.Current.Name, c.Current.Value)
+		case changeRemoved:
+			line = fmt.Sprintf("- **Breaking:** Removed `%s` (%d)", c.Previous.Name, c.Previous.Value)
+		case changeRenamed:
+			line = fmt.Sprintf("- **Breaking:** Renamed `%s` to `%s` (%d)", c.Previous.Name, c.Current.Name, c.Current.Value)
+		case changeRenumbered:
+			line = fmt.Sprintf("- **Breaking:** Changed the value of `%s` from %d to %d", c.Current.Name, c.Previous.Value, c.Current.Value)
+		}
+		_, err = fmt.Fprintln(w, line)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
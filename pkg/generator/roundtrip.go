@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrRoundTrip is returned by the round trip check when a value would not decode
+// back to itself from what the generated code writes or accepts for it.
+var ErrRoundTrip = fmt.Errorf("enum does not round trip")
+
+// parse mirrors the generated stringTo function, returning the index of the
+// value s parses to or -1 for the invalid value.
+func (rep EnumRepresentation) parse(names [][]string, s string) int {
+	for i := range rep.Enums {
+		for _, name := range names[i] {
+			if name == s || rep.Insensitive && strings.EqualFold(name, s) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// wireNames returns the strings written for the enum value by String and the
+// marshalers, the name followed by the values of its marshal tags.
+func (e Enum) wireNames() []string {
+	names := []string{e.Info.AlternateName}
+	for _, key := range marshalTags {
+		if v, ok := e.tagValue(key); ok {
+			names = append(names, v)
+		}
+	}
+	return names
+}
+
+// checkRoundTrip returns ErrRoundTrip unless every spelling of every value parses
+// back to that value under the configured case sensitivity, every string written
+// for a value survives the decoders unchanged and the invalid placeholder does not
+// parse to another value.
+func checkRoundTrip(rep EnumRepresentation) error {
+	names := rep.parseNames()
+	for i, e := range rep.Enums {
+		for _, name := range e.spellings() {
+			if got := rep.parse(names, name); got != i {
+				return fmt.Errorf("%w: %q of %s parses to %s", ErrRoundTrip, name, e.Info.Name, rep.parsedName(got))
+			}
+		}
+		for _, name := range e.wireNames() {
+			if err := rep.checkWireName(name); err != nil {
+				return fmt.Errorf("%w: %q of %s %w", ErrRoundTrip, name, e.Info.Name, err)
+			}
+		}
+	}
+	if rep.InvalidPlaceholder != "" {
+		if got := rep.parse(names, rep.InvalidPlaceholder); got != -1 && rep.Enums[got].Info.Valid {
+			return fmt.Errorf("%w: placeholder %q parses to %s", ErrRoundTrip, rep.InvalidPlaceholder, rep.Enums[got].Info.Name)
+		}
+	}
+	return nil
+}
+
+// parsedName describes the result of parse for error messages.
+func (rep EnumRepresentation) parsedName(i int) string {
+	if i < 0 {
+		return "no value"
+	}
+	return rep.Enums[i].Info.Name
+}
+
+// checkWireName returns an error describing why the marshaled name would not be
+// read back as written: the JSON unmarshaler trims spaces, while the empty and
+// null handling of -emptyinvalid and -emptydefault runs before parsing.
+func (rep EnumRepresentation) checkWireName(name string) error {
+	if strings.Trim(name, " ") != name {
+		return fmt.Errorf("is trimmed by UnmarshalJSON")
+	}
+	if (rep.EmptyInvalid || rep.EmptyDefault) && (name == "" || name == "null") {
+		return fmt.Errorf("is decoded as an empty value")
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package generator
+
+import (
+	"go/constant"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// rustSections are the writers for the Rust module.
+var rustSections = []func(io.StringWriter, EnumRepresentation){
+	writeRustGeneratedComment,
+	writeRustEnum,
+	writeRustMethods,
+	writeRustTraits,
+}
+
+// rustReserved are the methods of the generated Rust enum and the keywords of the
+// language, which the accessors of the extra values cannot be named.
+var rustReserved = []string{
+	"value", "label", "is_valid",
+	"as", "async", "await", "break", "const", "continue", "crate", "dyn", "else", "enum", "extern",
+	"false", "fn", "for", "if", "impl", "in", "let", "loop", "match", "mod", "move", "mut", "pub",
+	"ref", "return", "self", "static", "struct", "super", "trait", "true", "type", "unsafe", "use",
+	"where", "while", "abstract", "become", "box", "do", "final", "macro", "override", "priv",
+	"try", "typeof", "unsized", "virtual", "yield",
+}
+
+// rustTypes are the Rust types of the kinds of extra values.
+var rustTypes = map[constant.Kind]string{
+	constant.Bool:   "bool",
+	constant.String: "&'static str",
+	constant.Int:    "i64",
+	constant.Float:  "f64",
+}
+
+// checkRust rejects the enums the Rust output cannot represent.
+func checkRust(rep EnumRepresentation) error {
+	if _, err := rep.foreignValues(OutputRust); err != nil {
+		return err
+	}
+	return checkForeignMembers(rep, OutputRust, rustReserved, snakeCase)
+}
+
+// rustLiteral formats the value as a Rust literal.
+func rustLiteral(v constant.Value) string {
+	switch v.Kind() {
+	case constant.Bool:
+		return strconv.FormatBool(constant.BoolVal(v))
+	case constant.String:
+		return rustQuote(constant.StringVal(v))
+	}
+	return foreignNumber(v)
+}
+
+// rustVariant returns the path of the variant of the enum value.
+func (rep EnumRepresentation) rustVariant(e Enum) string {
+	return rep.TypeInfo.Camel + "::" + e.Info.Camel
+}
+
+func writeRustGeneratedComment(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("// Code generated by goenums. DO NOT EDIT.\n\n")
+	w.WriteString("//! " + rep.TypeInfo.Camel + " mirrors the " + rep.TypeInfo.Name + " constants of the Go package " + rep.PackageName + ".\n\n")
+	w.WriteString("use std::fmt;\n")
+	w.WriteString("use std::str::FromStr;\n\n")
+	w.WriteString("use serde::{Deserialize, Serialize};\n\n")
+}
+
+// writeRustEnum writes the enum with the values of the Go constants as the
+// discriminants and serde attributes naming each variant as the Go code does,
+// accepting its other spellings as aliases. In strict mode the values marked
+// invalid cannot be deserialized, as they cannot be unmarshaled in Go.
+func writeRustEnum(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash, Serialize, Deserialize)]\n")
+	w.WriteString("#[repr(i64)]\n")
+	w.WriteString("pub enum " + rep.TypeInfo.Camel + " {\n")
+	for i, names := range rep.parseNames() {
+		e := rep.Enums[i]
+		attrs := []string{"rename = " + rustQuote(names[0])}
+		for _, name := range names[1:] {
+			attrs = append(attrs, "alias = "+rustQuote(name))
+		}
+		if !e.Info.Valid && rep.Strict {
+			attrs = append(attrs, "skip_deserializing")
+		}
+		w.WriteString("    #[serde(" + strings.Join(attrs, ", ") + ")]\n")
+		w.WriteString("    " + e.Info.Camel + " = " + strconv.Itoa(e.Info.Value+rep.TypeInfo.Index) + ",\n")
+	}
+	w.WriteString("}\n\n")
+}
+
+// writeRustMethods writes the accessors of the value, name, validity and extra
+// values, and the list of every variant.
+func writeRustMethods(w io.StringWriter, rep EnumRepresentation) {
+	camel := rep.TypeInfo.Camel
+	// checked before generating
+	values, _ := rep.foreignValues("")
+	w.WriteString("impl " + camel + " {\n")
+	w.WriteString("    /// Every declared value, including those marked invalid.\n")
+	w.WriteString("    pub const ALL: [" + camel + "; " + strconv.Itoa(len(rep.Enums)) + "] = [\n")
+	for _, e := range rep.Enums {
+		w.WriteString("        " + rep.rustVariant(e) + ",\n")
+	}
+	w.WriteString("    ];\n\n")
+	w.WriteString("    /// Returns the value of the Go constant.\n")
+	w.WriteString("    pub fn value(self) -> i64 {\n")
+	w.WriteString("        self as i64\n")
+	w.WriteString("    }\n\n")
+	w.WriteString("    /// Returns the name the Go code writes for the value.\n")
+	w.WriteString("    pub fn label(self) -> &'static str {\n")
+	w.WriteString("        match self {\n")
+	for _, e := range rep.Enums {
+		w.WriteString("            " + rep.rustVariant(e) + " => " + rustQuote(e.Info.AlternateName) + ",\n")
+	}
+	w.WriteString("        }\n")
+	w.WriteString("    }\n\n")
+	var valid []string
+	for _, e := range rep.Enums {
+		if e.Info.Valid {
+			valid = append(valid, rep.rustVariant(e))
+		}
+	}
+	w.WriteString("    /// Reports whether the value is valid, false for the values marked invalid.\n")
+	w.WriteString("    pub fn is_valid(self) -> bool {\n")
+	if len(valid) == 0 {
+		w.WriteString("        false\n")
+	} else {
+		w.WriteString("        matches!(self, " + strings.Join(valid, " | ") + ")\n")
+	}
+	w.WriteString("    }\n")
+	for j, field := range rep.TypeInfo.NameTypePairs {
+		w.WriteString("\n")
+		w.WriteString("    pub fn " + snakeCase(field.Name) + "(self) -> " + rustTypes[foreignKinds[field.Type]] + " {\n")
+		w.WriteString("        match self {\n")
+		for i, e := range rep.Enums {
+			w.WriteString("            " + rep.rustVariant(e) + " => " + rustLiteral(values[i][j]) + ",\n")
+		}
+		w.WriteString("        }\n")
+		w.WriteString("    }\n")
+	}
+	w.WriteString("}\n\n")
+}
+
+// writeRustTraits writes Display writing the name of the value and FromStr
+// parsing every spelling the Go code parses.
+func writeRustTraits(w io.StringWriter, rep EnumRepresentation) {
+	camel := rep.TypeInfo.Camel
+	errName := "Parse" + camel + "Error"
+	w.WriteString("impl fmt::Display for " + camel + " {\n")
+	w.WriteString("    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {\n")
+	w.WriteString("        f.write_str(self.label())\n")
+	w.WriteString("    }\n")
+	w.WriteString("}\n\n")
+	w.WriteString("/// The error parsing a string that is not the name of a " + camel + ".\n")
+	w.WriteString("#[derive(Debug, Clone, PartialEq, Eq)]\n")
+	w.WriteString("pub struct " + errName + "(pub String);\n\n")
+	w.WriteString("impl fmt::Display for " + errName + " {\n")
+	w.WriteString("    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {\n")
+	w.WriteString("        write!(f, \"invalid " + camel + ": {}\", self.0)\n")
+	w.WriteString("    }\n")
+	w.WriteString("}\n\n")
+	w.WriteString("impl std::error::Error for " + errName + " {}\n\n")
+	w.WriteString("impl FromStr for " + camel + " {\n")
+	w.WriteString("    type Err = " + errName + ";\n\n")
+	w.WriteString("    fn from_str(s: &str) -> Result<Self, Self::Err> {\n")
+	w.WriteString("        match s {\n")
+	for i, names := range rep.parseNames() {
+		quoted := make([]string, len(names))
+		for j, name := range names {
+			quoted[j] = rustQuote(name)
+		}
+		w.WriteString("            " + strings.Join(quoted, " | ") + " => Ok(" + rep.rustVariant(rep.Enums[i]) + "),\n")
+	}
+	w.WriteString("            _ => Err(" + errName + "(s.to_string())),\n")
+	w.WriteString("        }\n")
+	w.WriteString("    }\n")
+	w.WriteString("}\n")
+}
@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteSite parses the enums found by FindEnums with the options returned by
+// config and writes a static HTML catalog of them into dir: an index.html with
+// a searchable table of every enum, and a page per enum listing its values with
+// their names, aliases, descriptions and extra values, linked to the other enums
+// of its package. Nothing is fetched when the pages are viewed.
+func WriteSite(ctx context.Context, dir string, candidates []Candidate, config func(Candidate) (Config, error)) error {
+	enums, err := catalog(ctx, candidates, config)
+	if err != nil {
+		return err
+	}
+	for _, e := range enums {
+		e.Page = sitePage(e.ImportPath, e.Type)
+	}
+	linkSiteEnums(enums)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create site directory: %w", err)
+	}
+	if err := writeSitePage(filepath.Join(dir, "index.html"), "index", enums); err != nil {
+		return err
+	}
+	for _, e := range enums {
+		if err := writeSitePage(filepath.Join(dir, e.Page), "enum", e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sitePage returns the file name of the page of the enum, unique in the module.
+func sitePage(importPath, typ string) string {
+	clean := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, importPath)
+	return clean + "." + typ + ".html"
+}
+
+// linkSiteEnums links each enum to the other enums of its package and its
+// extra values to the pages of the enums they are typed with.
+func linkSiteEnums(enums []*catalogEnum) {
+	for _, e := range enums {
+		for _, other := range enums {
+			if other != e && other.ImportPath == e.ImportPath {
+				e.Related = append(e.Related, other)
+			}
+		}
+		for i, f := range e.Fields {
+			for _, other := range enums {
+				if other.ImportPath == e.ImportPath && other.Type == f.Type {
+					e.Fields[i].Link = other.Page
+				}
+			}
+		}
+	}
+}
+
+// writeSitePage executes the named template of the site into filename.
+func writeSitePage(filename, name string, data any) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filename, err)
+	}
+	if err := siteTemplates.ExecuteTemplate(f, name, data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	return f.Close()
+}
+
+// siteTemplates render the pages of the site. The search box filters the rows
+// of the table on the page by their text.
+var siteTemplates = template.Must(template.New("site").Parse(`
+{{- define "head" -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 72rem; padding: 0 1rem; color: #222; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #ddd; padding: .4rem .6rem; text-align: left; vertical-align: top; }
+th { background: #f5f5f5; }
+tr.invalid { color: #999; }
+code { font-size: .9em; }
+input[type=search] { font-size: 1rem; margin: 1rem 0; padding: .4rem; width: 100%; box-sizing: border-box; }
+nav { margin-bottom: 1rem; }
+</style>
+</head>
+<body>
+{{- end}}
+
+{{- define "search" -}}
+<input type="search" id="search" placeholder="Search" aria-label="Search">
+<script>
+document.getElementById("search").addEventListener("input", function () {
+	var q = this.value.toLowerCase();
+	document.querySelectorAll("tbody tr").forEach(function (tr) {
+		tr.hidden = q !== "" && tr.textContent.toLowerCase().indexOf(q) < 0;
+	});
+});
+</script>
+{{- end}}
+
+{{- define "index" -}}
+{{template "head" "Enums"}}
+<h1>Enums</h1>
+{{template "search"}}
+<table>
+<thead><tr><th>Type</th><th>Package</th><th>Values</th><th>Description</th><th>Owner</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr><td><a href="{{.Page}}">{{.Type}}</a></td><td><code>{{.ImportPath}}</code></td><td>{{len .Values}}</td><td>{{.Header.Description}}</td><td>{{.Header.Owner}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+</body>
+</html>
+{{end}}
+
+{{- define "enum" -}}
+{{template "head" .Type}}
+<nav><a href="index.html">All enums</a>
+{{- range .Related}} · <a href="{{.Page}}">{{.Type}}</a>{{end}}</nav>
+<h1>{{.Type}}</h1>
+<p>Package <code>{{.ImportPath}}</code>, declared in <code>{{.Source}}</code>.</p>
+{{- with .Header.Description}}
+<p>{{.}}</p>
+{{- end}}
+{{- with .Header.Owner}}
+<p>Owned by {{.}}.</p>
+{{- end}}
+{{- with .Header.Docs}}
+<ul>
+{{- range .}}
+<li><a href="{{.}}">{{.}}</a></li>
+{{- end}}
+</ul>
+{{- end}}
+{{template "search"}}
+<table>
+<thead><tr><th>Constant</th><th>Name</th><th>Display name</th><th>Value</th><th>Aliases</th><th>Description</th>
+{{- range .Fields}}<th>{{if .Link}}<a href="{{.Link}}">{{.Name}}</a>{{else}}{{.Name}}{{end}} <code>{{.Type}}</code>{{with .Unit}} in {{.}}{{end}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Values}}
+<tr{{if not .Valid}} class="invalid"{{end}}><td><code>{{.Constant}}</code>{{if not .Valid}} (invalid){{end}}{{if .Default}} (default){{end}}</td><td>{{.Name}}</td><td>{{.DisplayName}}</td><td>{{.Value}}</td><td>{{range $i, $a := .Aliases}}{{if $i}}, {{end}}{{$a}}{{end}}</td><td>{{.Description}}</td>
+{{- range .Fields}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+</body>
+</html>
+{{end}}
+`))
@@ -0,0 +1,90 @@
+package generator
+
+import "sort"
+
+// valueSlots returns the enum values by their position from the first value,
+// with nil for the positions skipped by blank constants, e.g. the _ in
+//
+//	const (
+//		active status = iota
+//		_
+//		archived
+//	)
+func (rep EnumRepresentation) valueSlots() []*Enum {
+	var slots []*Enum
+	for i := range rep.Enums {
+		for len(slots) < rep.Enums[i].Info.Value {
+			slots = append(slots, nil)
+		}
+		slots = append(slots, &rep.Enums[i])
+	}
+	return slots
+}
+
+// sparse reports whether blank constants leave gaps between the values of the
+// enum, so the tables indexed by value have empty entries to check for.
+func (rep EnumRepresentation) sparse() bool {
+	for i := 1; i < len(rep.Enums); i++ {
+		if rep.Enums[i].Info.Value != rep.Enums[i-1].Info.Value+1 {
+			return true
+		}
+	}
+	return false
+}
+
+// valueLookup reports whether integers are parsed by matching the constant values
+// instead of by position among the valid values. Positions only reach every value
+// when the values run from the offset without gaps, and numbers scanned with SQLInt
+// are the values Value stored.
+func (rep EnumRepresentation) valueLookup() bool {
+	return rep.SQLInt || rep.sparse() || len(rep.Enums) > 0 && rep.Enums[0].Info.Value != 0
+}
+
+// unusedValue returns a value no constant of the enum has, the first gap
+// between the values or the value after the last one.
+func (rep EnumRepresentation) unusedValue() int {
+	next := 0
+	for _, e := range rep.Enums {
+		if e.Info.Value != next {
+			break
+		}
+		next++
+	}
+	return next + rep.TypeInfo.Index
+}
+
+// maxSlotsPerValue bounds the slots of the tables indexed by value per value of
+// the enum, beyond the minSlots every enum may have.
+const (
+	maxSlotsPerValue = 2
+	minSlots         = 64
+)
+
+// tabled reports whether the values of the enum are dense enough for the names
+// to be looked up in tables indexed by value. Values spread further apart, such
+// as the bit flags of 1 << iota, are looked up with a switch instead, as the
+// tables would hold a slot for every number up to the largest value.
+func (rep EnumRepresentation) tabled() bool {
+	if len(rep.Enums) == 0 {
+		return true
+	}
+	span := rep.Enums[len(rep.Enums)-1].Info.Value + 1
+	return span <= max(maxSlotsPerValue*len(rep.Enums), minSlots)
+}
+
+// sortValues orders the constants by value, as the expressions of a spec with
+// several names need not give them in order, e.g. b, a = iota*2 + 1, iota * 2,
+// and lowers the index when a value is below it so the values start from zero.
+func sortValues(enums []Enum, index int) ([]Enum, int) {
+	sort.SliceStable(enums, func(i, j int) bool {
+		return enums[i].Info.Value < enums[j].Info.Value
+	})
+	if len(enums) > 0 && enums[0].Info.Value < 0 {
+		shift := enums[0].Info.Value
+		for i := range enums {
+			enums[i].Info.Value -= shift
+		}
+		index += shift
+	}
+	return enums, index
+}
@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"io"
+	"strconv"
+)
+
+// sqlcSections are the writers for the sqlc overrides snippet.
+var sqlcSections = []func(io.StringWriter, EnumRepresentation){
+	writeSQLCGeneratedComment,
+	writeSQLCOverrides,
+}
+
+func writeSQLCGeneratedComment(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("# Code generated by goenums. DO NOT EDIT.\n")
+	w.WriteString("# sqlc overrides mapping the " + snakeCase(rep.TypeInfo.Name) + " database type to " + rep.TypeInfo.Camel + ".\n")
+	w.WriteString("# Paste the entries into the overrides section of sqlc.yaml, changing db_type\n")
+	w.WriteString("# to a column override if the database type name differs.\n")
+}
+
+func writeSQLCOverrides(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("overrides:\n")
+	w.WriteString("  - db_type: " + strconv.Quote(snakeCase(rep.TypeInfo.Name)) + "\n")
+	w.WriteString("    go_type:\n")
+	if rep.ImportPath != "" {
+		w.WriteString("      import: " + strconv.Quote(rep.ImportPath) + "\n")
+		w.WriteString("      package: " + strconv.Quote(rep.PackageName) + "\n")
+	}
+	w.WriteString("      type: " + strconv.Quote(rep.TypeInfo.Camel) + "\n")
+	w.WriteString("  - db_type: " + strconv.Quote(snakeCase(rep.TypeInfo.Name)) + "\n")
+	w.WriteString("    nullable: true\n")
+	w.WriteString("    go_type:\n")
+	if rep.ImportPath != "" {
+		w.WriteString("      import: " + strconv.Quote(rep.ImportPath) + "\n")
+		w.WriteString("      package: " + strconv.Quote(rep.PackageName) + "\n")
+	}
+	w.WriteString("      type: " + strconv.Quote(rep.TypeInfo.Camel) + "\n")
+	w.WriteString("      pointer: true\n")
+}
@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Stats summarise the enums of a repository and how they are generated, for
+// platform teams sizing the generation footprint. They are computed from the
+// sources alone, nothing is written or sent anywhere.
+type Stats struct {
+	// Enums and Packages are the number of enums and of packages declaring them.
+	Enums    int `json:"enums"`
+	Packages int `json:"packages"`
+	// Failed is the number of enums whose source or options could not be read.
+	Failed int `json:"failed"`
+	// Values is the number of constants declared by the enums, Invalid of them marked invalid.
+	Values  int `json:"values"`
+	Invalid int `json:"invalid"`
+	// EnumsWithFields is the number of enums declaring extra values, and Fields
+	// the number of extra values declared by type.
+	EnumsWithFields int            `json:"enumsWithFields"`
+	Fields          map[string]int `json:"fields"`
+	// Handlers and Outputs are the number of enums generating each handler and output.
+	Handlers map[string]int `json:"handlers"`
+	Outputs  map[string]int `json:"outputs"`
+	// Options is the number of enums generated with each command line option.
+	Options map[string]int `json:"options"`
+}
+
+// CollectStats parses the enums found by FindEnums with the options returned by
+// config, the zero Config when nil, and summarises them.
+func CollectStats(candidates []Candidate, config func(Candidate) (Config, error)) Stats {
+	s := Stats{
+		Fields:   make(map[string]int),
+		Handlers: make(map[string]int),
+		Outputs:  make(map[string]int),
+		Options:  make(map[string]int),
+	}
+	var dirs []string
+	for _, c := range candidates {
+		s.Enums++
+		if dir := filepath.Dir(c.Filename); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+		rep, err := statsRepresentation(c, config)
+		if err != nil {
+			s.Failed++
+			continue
+		}
+		s.add(rep)
+	}
+	s.Packages = len(dirs)
+	return s
+}
+
+// statsRepresentation parses the enum of the candidate with its options.
+func statsRepresentation(c Candidate, config func(Candidate) (Config, error)) (EnumRepresentation, error) {
+	var cfg Config
+	if config != nil {
+		var err error
+		cfg, err = config(c)
+		if err != nil {
+			return EnumRepresentation{}, err
+		}
+	}
+	if err := cfg.validate(); err != nil {
+		return EnumRepresentation{}, err
+	}
+	src, err := os.ReadFile(c.Filename)
+	if err != nil {
+		return EnumRepresentation{}, fmt.Errorf("failed to read source: %w", err)
+	}
+	return parseRepresentation(filepath.Base(c.Filename), src, cfg)
+}
+
+// add counts the enum in the stats.
+func (s *Stats) add(rep EnumRepresentation) {
+	for _, e := range rep.Enums {
+		s.Values++
+		if !e.Info.Valid {
+			s.Invalid++
+		}
+	}
+	if len(rep.TypeInfo.NameTypePairs) > 0 {
+		s.EnumsWithFields++
+	}
+	for _, pair := range rep.TypeInfo.NameTypePairs {
+		s.Fields[pair.Type]++
+	}
+	for _, h := range handlers {
+		if rep.hasHandler(h) {
+			s.Handlers[h]++
+		}
+	}
+	for _, o := range outputs {
+		if rep.hasOutput(o) {
+			s.Outputs[o]++
+		}
+	}
+	for _, arg := range rep.Config.args() {
+		if name, ok := strings.CutPrefix(arg, "-"); ok {
+			s.Options[name]++
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// stringerSections write the Stringer output, the String method of the base
+// type as generated by golang.org/x/tools/cmd/stringer.
+var stringerSections = []func(io.StringWriter, EnumRepresentation){
+	writeGeneratedComment,
+	writePackage,
+	writeStringerImports,
+	writeCompileCheck,
+	writeStringerMethod,
+}
+
+// stringerFilename returns the name of the file stringer generates for the type.
+func stringerFilename(rep EnumRepresentation) string {
+	return strings.ToLower(rep.TypeInfo.Name) + rep.goSuffix("_string")
+}
+
+// checkStringer rejects enums with extra values, which stringer cannot represent.
+func checkStringer(rep EnumRepresentation) error {
+	if len(rep.TypeInfo.NameTypePairs) > 0 {
+		return fmt.Errorf("stringer mode only generates plain enums, %s declares extra values", rep.TypeInfo.Name)
+	}
+	return nil
+}
+
+func writeStringerImports(w io.StringWriter, rep EnumRepresentation) {
+	w.WriteString("import \"strconv\"\n\n")
+}
+
+// stringerName returns the name stringer gives the constant, its identifier
+// without the trimmed prefix.
+func (rep EnumRepresentation) stringerName(e Enum) string {
+	return strings.TrimPrefix(e.Info.Ident, rep.TrimPrefix)
+}
+
+// writeStringerMethod writes the _<type>_name and _<type>_index tables and the
+// String method of the base type reading them, as stringer does, or a switch
+// when the values are too far apart for the tables.
+func writeStringerMethod(w io.StringWriter, rep EnumRepresentation) {
+	typ := rep.TypeInfo.Name
+	if !rep.tabled() {
+		writeStringSwitch(w, typ, typ, rep.Enums, rep.stringerName)
+		return
+	}
+	var names strings.Builder
+	indexes := []int{0}
+	for _, e := range rep.valueSlots() {
+		if e != nil {
+			names.WriteString(rep.stringerName(*e))
+		}
+		indexes = append(indexes, names.Len())
+	}
+	indexType := "uint8"
+	switch last := indexes[len(indexes)-1]; {
+	case last > math.MaxUint16:
+		indexType = "uint32"
+	case last > math.MaxUint8:
+		indexType = "uint16"
+	}
+	values := make([]string, len(indexes))
+	for i, index := range indexes {
+		values[i] = strconv.Itoa(index)
+	}
+	w.WriteString("const _" + typ + "_name = " + strconv.Quote(names.String()) + "\n\n")
+	w.WriteString("var _" + typ + "_index = [...]" + indexType + "{" + strings.Join(values, ", ") + "}\n\n")
+	w.WriteString("func (i " + typ + ") String() string {\n")
+	value := "i"
+	if offset := rep.TypeInfo.Index; offset != 0 {
+		w.WriteString("\ti -= " + strconv.Itoa(offset) + "\n")
+		value = "i+" + strconv.Itoa(offset)
+	}
+	outOfRange := "i < 0 || i >= " + typ + "(len(_" + typ + "_index)-1)"
+	if rep.sparse() {
+		outOfRange += " || _" + typ + "_index[i] == _" + typ + "_index[i+1]"
+	}
+	w.WriteString("\tif " + outOfRange + " {\n")
+	w.WriteString("\t\treturn \"" + typ + "(\" + strconv.FormatInt(int64(" + value + "), 10) + \")\"\n")
+	w.WriteString("\t}\n")
+	w.WriteString("\treturn _" + typ + "_name[_" + typ + "_index[i]:_" + typ + "_index[i+1]]\n")
+	w.WriteString("}\n")
+}
@@ -0,0 +1,271 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Style holds the options for the appearance of the generated Go code, so a
+// repository can standardise it without forking the writers. The zero value
+// generates the default appearance.
+type Style struct {
+	// Receiver is the name of the receiver of the methods on the wrapper type, p by default.
+	Receiver string `json:"receiver,omitempty"`
+	// ErrorFormat is the message of the errors the generated code returns for invalid
+	// values, with {action} replaced by what failed, such as parse or marshal, and
+	// {type} by the wrapper type. Defaults to DefaultErrorFormat.
+	ErrorFormat string `json:"errorFormat,omitempty"`
+	// Comments selects the comments written into the Go files, CommentsFull by
+	// default or CommentsNone for only the generated header and directives.
+	Comments string `json:"comments,omitempty"`
+	// Order lists sections of the Go enum file written first, in the order given,
+	// followed by the others in their default order, see Sections.
+	Order []string `json:"order,omitempty"`
+}
+
+// DefaultErrorFormat is the message of the errors returned by the generated code
+// when Style.ErrorFormat is empty.
+const DefaultErrorFormat = "failed to {action} invalid {type}"
+
+// Comment verbosities for Style.Comments.
+const (
+	CommentsFull = "full"
+	CommentsNone = "none"
+)
+
+// defaultReceiver is the name of the receiver the writers generate the methods with.
+const defaultReceiver = "p"
+
+// section is a named group of writers of the Go enum file that Style.Order can move.
+type section struct {
+	name    string
+	writers []func(io.StringWriter, EnumRepresentation)
+}
+
+// headerSections are the writers for the start of the Go enum file, which is
+// always written first.
+var headerSections = []func(io.StringWriter, EnumRepresentation){
+	writeGeneratedComment,
+	writePackage,
+	writeImports,
+}
+
+// bodySections are the sections of the Go enum file after its header in their default order.
+var bodySections = []section{
+	{name: "types", writers: []func(io.StringWriter, EnumRepresentation){
+		writeWrapperType,
+		writeContainerAccessor,
+	}},
+	{name: "container", writers: []func(io.StringWriter, EnumRepresentation){
+		writeAllMethod,
+		writeAllWithInvalidMethod,
+		writeCountMethods,
+		writeOrdinalMethod,
+		writeUnderlyingMethod,
+		writeBaseMethod,
+		writeWrapFunction,
+		writeAsFunction,
+		writeNamesFunctions,
+	}},
+	{name: "parse", writers: []func(io.StringWriter, EnumRepresentation){
+		writeParseMethod,
+		writeStrictParseMethod,
+		writeExhaustiveMethod,
+		writeIsValidMethod,
+		writeClosestMethod,
+		writeZeroMethods,
+		writeDefaultMethod,
+	}},
+	{name: "json", writers: []func(io.StringWriter, EnumRepresentation){
+		writeMarshalNameMethod,
+		writeJSONMarshalMethod,
+		writeJSONUnmarshalMethod,
+	}},
+	{name: "sql", writers: []func(io.StringWriter, EnumRepresentation){
+		writeScanMethod,
+		writeValueMethod,
+		writeSQLConstraint,
+		writePgtypeMethods,
+	}},
+	{name: "accessors", writers: []func(io.StringWriter, EnumRepresentation){
+		writeAccessorMethods,
+		writeTagMethods,
+	}},
+	{name: "format", writers: []func(io.StringWriter, EnumRepresentation){
+		writeFormatMethod,
+		writeGoStringMethod,
+		writeCacheKeyMethod,
+	}},
+	{name: "yaml", writers: []func(io.StringWriter, EnumRepresentation){
+		writeYAMLMarshalMethod,
+		writeYAMLUnmarshalMethod,
+	}},
+	{name: "string", writers: []func(io.StringWriter, EnumRepresentation){
+		writeCompileCheck,
+		writeStringMethod,
+	}},
+}
+
+// Sections returns the names of the sections of the Go enum file Style.Order
+// can reorder, in their default order.
+func Sections() []string {
+	names := make([]string, len(bodySections))
+	for i, s := range bodySections {
+		names[i] = s.name
+	}
+	return names
+}
+
+// goSections returns the writers for the Go enum file, with the sections named
+// by Style.Order first.
+func (rep EnumRepresentation) goSections() []func(io.StringWriter, EnumRepresentation) {
+	ordered := slices.Clone(bodySections)
+	slices.SortStableFunc(ordered, func(a, b section) int {
+		return orderIndex(rep.Style.Order, a.name) - orderIndex(rep.Style.Order, b.name)
+	})
+	writers := slices.Clone(headerSections)
+	for _, s := range ordered {
+		writers = append(writers, s.writers...)
+	}
+	return writers
+}
+
+// orderIndex returns the position of the section in order, or the length of
+// order for sections not in it so they keep their default order after it.
+func orderIndex(order []string, name string) int {
+	if i := slices.Index(order, name); i >= 0 {
+		return i
+	}
+	return len(order)
+}
+
+// validate checks the style options are supported.
+func (s Style) validate() error {
+	if s.Receiver != "" && (!token.IsIdentifier(s.Receiver) || s.Receiver == "_") {
+		return fmt.Errorf("receiver %q must be an identifier", s.Receiver)
+	}
+	switch s.Comments {
+	case "", CommentsFull, CommentsNone:
+	default:
+		return fmt.Errorf("unknown comments %q, expected %q or %q", s.Comments, CommentsFull, CommentsNone)
+	}
+	names := Sections()
+	for i, name := range s.Order {
+		if !slices.Contains(names, name) {
+			return fmt.Errorf("unknown section %q, expected one of %s", name, strings.Join(names, ", "))
+		}
+		if slices.Contains(s.Order[:i], name) {
+			return fmt.Errorf("section %q is ordered twice", name)
+		}
+	}
+	return nil
+}
+
+// errorMessage returns the message of the errors the generated code returns
+// when action fails for an invalid value, escaped for use in a format string.
+func (rep EnumRepresentation) errorMessage(action string) string {
+	msg := rep.Style.ErrorFormat
+	if msg == "" {
+		msg = DefaultErrorFormat
+	}
+	msg = strings.NewReplacer("{action}", action, "{type}", rep.TypeInfo.Camel).Replace(msg)
+	return strings.ReplaceAll(msg, "%", "%%")
+}
+
+// restyled reports whether the generated Go files are rewritten after formatting
+// to apply the style.
+func (s Style) restyled() bool {
+	return s.Receiver != "" && s.Receiver != defaultReceiver || s.Comments == CommentsNone
+}
+
+// applyStyle renames the receivers and removes the comments of the formatted Go
+// file as configured by the style.
+func applyStyle(rep EnumRepresentation, filename string, src []byte) ([]byte, error) {
+	if !rep.Style.restyled() {
+		return src, nil
+	}
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if r := rep.Style.Receiver; r != "" && r != defaultReceiver {
+		for _, decl := range node.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil {
+				continue
+			}
+			if err := renameReceiver(fn, r); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+			}
+		}
+	}
+	if rep.Style.Comments == CommentsNone {
+		node.Comments = slices.DeleteFunc(node.Comments, func(c *ast.CommentGroup) bool {
+			return c.Pos() > node.Package && !isDirectiveGroup(c)
+		})
+	}
+	var b bytes.Buffer
+	if err := format.Node(&b, fset, node); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// renameReceiver renames the receiver of the generated method to name, failing
+// when the method already uses the name for something else.
+func renameReceiver(fn *ast.FuncDecl, name string) error {
+	if len(fn.Recv.List) != 1 || len(fn.Recv.List[0].Names) != 1 {
+		return nil
+	}
+	recv := fn.Recv.List[0].Names[0]
+	if recv.Name != defaultReceiver {
+		return nil
+	}
+	var (
+		refs  = []*ast.Ident{recv}
+		clash bool
+		visit func(ast.Node) bool
+	)
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			// the selected field or method can neither refer to the receiver nor clash with it
+			ast.Inspect(n.X, visit)
+			return false
+		case *ast.Ident:
+			clash = clash || n.Name == name
+			if n != recv && n.Obj == recv.Obj {
+				refs = append(refs, n)
+			}
+		}
+		return true
+	}
+	ast.Inspect(fn, visit)
+	if clash {
+		return fmt.Errorf("receiver %q is already used in the generated %s method", name, fn.Name.Name)
+	}
+	for _, id := range refs {
+		id.Name = name
+	}
+	return nil
+}
+
+// isDirectiveGroup reports whether the comment group holds a directive, such as
+// //go:build or //coverage:ignore, which is kept whatever the comment verbosity.
+func isDirectiveGroup(c *ast.CommentGroup) bool {
+	for _, line := range c.List {
+		text, ok := strings.CutPrefix(line.Text, "//")
+		if ok && isDirective([]byte(text)) {
+			return true
+		}
+	}
+	return false
+}
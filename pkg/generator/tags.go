@@ -0,0 +1,241 @@
+package generator
+
+import (
+	"go/token"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// tag is a key:"value" metadata pair from a value comment, such as json:"ready".
+type tag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// marshalTags are the tag keys that replace the name written by a marshaler:
+// json for JSON, db for database/sql and pgx text values and yaml for YAML.
+// Parse accepts their values as well as the name so every representation round trips.
+var marshalTags = []string{"json", "db", "yaml"}
+
+// parseTag holds a comma separated list of extra spellings Parse accepts for a
+// value, such as names from before a rename. They are never written by a marshaler
+// and have no accessor.
+const parseTag = "parse"
+
+// tagInitialisms are the tag keys kept upper case in accessor names.
+var tagInitialisms = map[string]bool{
+	"api":  true,
+	"db":   true,
+	"id":   true,
+	"json": true,
+	"sql":  true,
+	"url":  true,
+	"xml":  true,
+	"yaml": true,
+}
+
+// getTags extracts the key:"value" pairs from the value comment,
+// returning the remaining comment and the tags in declaration order.
+func getTags(comment string) (string, []tag) {
+	var (
+		rest []string
+		tags []tag
+	)
+	for _, part := range splitQuoted(strings.TrimSpace(comment), ' ') {
+		key, value, ok := strings.Cut(part, ":")
+		if ok && token.IsIdentifier(key) && strings.HasPrefix(value, `"`) {
+			if v, err := strconv.Unquote(value); err == nil {
+				tags = append(tags, tag{Key: key, Value: v})
+				continue
+			}
+		}
+		rest = append(rest, part)
+	}
+	if len(tags) == 0 {
+		return comment, nil
+	}
+	return strings.Join(rest, " "), tags
+}
+
+// tagValue returns the value of the tag key declared on the enum value.
+func (e Enum) tagValue(key string) (string, bool) {
+	for _, t := range e.Info.Tags {
+		if t.Key == key {
+			return t.Value, true
+		}
+	}
+	return "", false
+}
+
+// jsonName returns the name the value is marshalled to JSON with, its json tag
+// or its name.
+func (e Enum) jsonName() string {
+	if name, ok := e.tagValue("json"); ok {
+		return name
+	}
+	return e.Info.AlternateName
+}
+
+// escapesJSON reports whether a JSON name of the enum needs escaping, so
+// UnmarshalJSON has to unescape the names it reads.
+func (rep EnumRepresentation) escapesJSON() bool {
+	names := []string{rep.InvalidPlaceholder}
+	for _, e := range rep.Enums {
+		names = append(names, e.jsonName())
+	}
+	for _, name := range names {
+		if jsonQuote(name) != `"`+name+`"` {
+			return true
+		}
+	}
+	return false
+}
+
+// tagKeys returns the tag keys with an accessor declared on the enum values in order of first use.
+func (rep EnumRepresentation) tagKeys() []string {
+	var keys []string
+	seen := map[string]bool{parseTag: true}
+	for _, e := range rep.Enums {
+		for _, t := range e.Info.Tags {
+			if !seen[t.Key] {
+				seen[t.Key] = true
+				keys = append(keys, t.Key)
+			}
+		}
+	}
+	return keys
+}
+
+// hasTag reports whether any enum value declares the tag key.
+func (rep EnumRepresentation) hasTag(key string) bool {
+	for _, e := range rep.Enums {
+		if _, ok := e.tagValue(key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// tagMethod returns the name of the accessor for the tag key, e.g. JSONName for json
+// and DisplayName for display.
+func tagMethod(key string) string {
+	if tagInitialisms[key] {
+		return strings.ToUpper(key) + "Name"
+	}
+	var b strings.Builder
+	for _, w := range words(key) {
+		b.WriteString(camelCase(strings.ToLower(w)))
+	}
+	return b.String() + "Name"
+}
+
+// tagName returns the expression a marshaler writes for the tag key, the tag
+// accessor when any value declares the tag and the name otherwise.
+func (rep EnumRepresentation) tagName(key string) string {
+	if rep.hasTag(key) {
+		return "p." + tagMethod(key) + "()"
+	}
+	return "p.String()"
+}
+
+// spellings returns every string Parse accepts for the enum value: the name,
+// the values of its marshal tags and its parse only spellings.
+func (e Enum) spellings() []string {
+	names := []string{e.Info.AlternateName}
+	for _, key := range marshalTags {
+		if v, ok := e.tagValue(key); ok {
+			names = append(names, v)
+		}
+	}
+	if v, ok := e.tagValue(parseTag); ok {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// parseNames returns the spellings stringTo matches for each enum value with the
+// name first. Repeated spellings are skipped so the generated switch has no
+// duplicate cases; checkDuplicateNames rejects those shared between values.
+func (rep EnumRepresentation) parseNames() [][]string {
+	seen := make(map[string]bool, len(rep.Enums))
+	for _, e := range rep.Enums {
+		seen[e.Info.AlternateName] = true
+	}
+	names := make([][]string, len(rep.Enums))
+	for i, e := range rep.Enums {
+		names[i] = []string{e.Info.AlternateName}
+		for _, name := range e.spellings()[1:] {
+			if !seen[name] {
+				seen[name] = true
+				names[i] = append(names[i], name)
+			}
+		}
+	}
+	return names
+}
+
+// writeNamesFunctions writes the functions listing the names of the valid values
+// and every spelling Parse accepts for them, for CLIs and validators to display.
+func writeNamesFunctions(w io.StringWriter, rep EnumRepresentation) {
+	var names, spellings []string
+	for i, s := range rep.parseNames() {
+		if !rep.Enums[i].Info.Valid {
+			continue
+		}
+		names = append(names, strconv.Quote(s[0]))
+		for _, name := range s {
+			spellings = append(spellings, strconv.Quote(name))
+		}
+	}
+	w.WriteString("// " + rep.TypeInfo.Camel + "Names returns the names of the valid " + rep.TypeInfo.Camel + " values in declaration order.\n")
+	w.WriteString("func " + rep.TypeInfo.Camel + "Names() []string {\n")
+	w.WriteString("\treturn []string{" + strings.Join(names, ", ") + "}\n")
+	w.WriteString("}\n\n")
+	w.WriteString("// " + rep.TypeInfo.Camel + "Strings returns every string Parse" + rep.TypeInfo.Camel + " accepts for the valid values,\n")
+	w.WriteString("// each name followed by its tag values and aliases.\n")
+	w.WriteString("func " + rep.TypeInfo.Camel + "Strings() []string {\n")
+	w.WriteString("\treturn []string{" + strings.Join(spellings, ", ") + "}\n")
+	w.WriteString("}\n\n")
+}
+
+// displayTag is the tag holding the human readable name of a value. Its accessor,
+// DisplayName, is always generated so user interfaces never need the wire name.
+const displayTag = "display"
+
+func writeTagMethods(w io.StringWriter, rep EnumRepresentation) {
+	keys := rep.tagKeys()
+	if !slices.Contains(keys, displayTag) {
+		keys = append(keys, displayTag)
+	}
+	for _, key := range keys {
+		method := tagMethod(key)
+		if hasField(rep.TypeInfo.NameTypePairs, method) {
+			continue
+		}
+		if key == displayTag {
+			w.WriteString("// " + method + " returns the human readable name of the " + rep.TypeInfo.Camel + " from its display tag,\n")
+			w.WriteString("// or its name when it has none. The name is still used on the wire.\n")
+		} else {
+			w.WriteString("// " + method + " returns the " + key + " tag of the " + rep.TypeInfo.Camel + ", or its name when it has none.\n")
+		}
+		w.WriteString("func (p " + rep.TypeInfo.Camel + ") " + method + "() string {\n")
+		if rep.hasTag(key) {
+			w.WriteString("\tswitch p." + rep.TypeInfo.Name + " {\n")
+			for _, e := range rep.Enums {
+				if v, ok := e.tagValue(key); ok {
+					w.WriteString("\tcase " + e.Info.Name + ":\n")
+					w.WriteString("\t\treturn " + strconv.Quote(v) + "\n")
+				}
+			}
+			w.WriteString("\t}\n")
+		}
+		w.WriteString("\treturn p.String()\n")
+		w.WriteString("}\n\n")
+	}
+}
@@ -0,0 +1,511 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -accessors testdata/accessors/planets.go
+// source checksum: 410777d2997b401d
+
+package accessors
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+type Planet struct {
+	planet
+	gravity             float64
+	radiusKm            float64
+	massKg              float64
+	orbitKm             float64
+	orbitDays           float64
+	surfacePressureBars float64
+	moons               int
+	rings               bool
+}
+
+type planetsContainer struct {
+	// UNKNOWN is "unknown" with the value 0, marked invalid.
+	UNKNOWN Planet
+	// MERCURY is "Mercury" with the value 1.
+	MERCURY Planet
+	// VENUS is "Venus" with the value 2.
+	VENUS Planet
+	// EARTH is "Earth" with the value 3.
+	EARTH Planet
+	// MARS is "Mars" with the value 4.
+	MARS Planet
+	// JUPITER is "Jupiter" with the value 5.
+	JUPITER Planet
+	// SATURN is "Saturn" with the value 6.
+	SATURN Planet
+	// URANUS is "Uranus" with the value 7.
+	URANUS Planet
+	// NEPTUNE is "Neptune" with the value 8.
+	NEPTUNE Planet
+}
+
+var Planets = planetsContainer{
+	MERCURY: Planet{
+		planet:              mercury,
+		gravity:             0.378,
+		radiusKm:            2439.7,
+		massKg:              3.3e23,
+		orbitKm:             57910000,
+		orbitDays:           88,
+		surfacePressureBars: 0.0000000001,
+		moons:               0,
+		rings:               false,
+	},
+	VENUS: Planet{
+		planet:              venus,
+		gravity:             0.907,
+		radiusKm:            6051.8,
+		massKg:              4.87e24,
+		orbitKm:             108200000,
+		orbitDays:           225,
+		surfacePressureBars: 92,
+		moons:               0,
+		rings:               false,
+	},
+	EARTH: Planet{
+		planet:              earth,
+		gravity:             1,
+		radiusKm:            6378.1,
+		massKg:              5.97e24,
+		orbitKm:             149600000,
+		orbitDays:           365,
+		surfacePressureBars: 1,
+		moons:               1,
+		rings:               false,
+	},
+	MARS: Planet{
+		planet:              mars,
+		gravity:             0.377,
+		radiusKm:            3389.5,
+		massKg:              6.42e23,
+		orbitKm:             227900000,
+		orbitDays:           687,
+		surfacePressureBars: 0.01,
+		moons:               2,
+		rings:               false,
+	},
+	JUPITER: Planet{
+		planet:              jupiter,
+		gravity:             2.36,
+		radiusKm:            69911,
+		massKg:              1.90e27,
+		orbitKm:             778600000,
+		orbitDays:           4333,
+		surfacePressureBars: 20,
+		moons:               4,
+		rings:               true,
+	},
+	SATURN: Planet{
+		planet:              saturn,
+		gravity:             0.916,
+		radiusKm:            58232,
+		massKg:              5.68e26,
+		orbitKm:             1433500000,
+		orbitDays:           10759,
+		surfacePressureBars: 1,
+		moons:               7,
+		rings:               true,
+	},
+	URANUS: Planet{
+		planet:              uranus,
+		gravity:             0.889,
+		radiusKm:            25362,
+		massKg:              8.68e25,
+		orbitKm:             2872500000,
+		orbitDays:           30687,
+		surfacePressureBars: 1.3,
+		moons:               13,
+		rings:               true,
+	},
+	NEPTUNE: Planet{
+		planet:              neptune,
+		gravity:             1.12,
+		radiusKm:            24622,
+		massKg:              1.02e26,
+		orbitKm:             4495100000,
+		orbitDays:           60190,
+		surfacePressureBars: 1.5,
+		moons:               2,
+		rings:               true,
+	},
+}
+
+func (c planetsContainer) All() []Planet {
+	return []Planet{
+		c.MERCURY,
+		c.VENUS,
+		c.EARTH,
+		c.MARS,
+		c.JUPITER,
+		c.SATURN,
+		c.URANUS,
+		c.NEPTUNE,
+	}
+}
+
+// AllWithInvalid returns every declared Planet, including those marked invalid, in declaration order.
+func (c planetsContainer) AllWithInvalid() []Planet {
+	return []Planet{
+		{planet: unknown},
+		c.MERCURY,
+		c.VENUS,
+		c.EARTH,
+		c.MARS,
+		c.JUPITER,
+		c.SATURN,
+		c.URANUS,
+		c.NEPTUNE,
+	}
+}
+
+// Count returns the number of valid Planet values.
+func (c planetsContainer) Count() int {
+	return 8
+}
+
+// First returns the first valid Planet in declaration order.
+func (c planetsContainer) First() Planet {
+	return c.MERCURY
+}
+
+// Last returns the last valid Planet in declaration order.
+func (c planetsContainer) Last() Planet {
+	return c.NEPTUNE
+}
+
+// Ordinal returns the position the Planet is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p Planet) Ordinal() int {
+	switch p.planet {
+	case unknown:
+		return 0
+	case mercury:
+		return 1
+	case venus:
+		return 2
+	case earth:
+		return 3
+	case mars:
+		return 4
+	case jupiter:
+		return 5
+	case saturn:
+		return 6
+	case uranus:
+		return 7
+	case neptune:
+		return 8
+	}
+	return -1
+}
+
+// Underlying returns the planet constant the Planet wraps.
+func (p Planet) Underlying() planet {
+	return p.planet
+}
+
+// Base returns the planet constant the Planet wraps, the inverse of AsPlanet.
+func (p Planet) Base() planet {
+	return p.planet
+}
+
+// WrapPlanet returns the Planet wrapping the planet constant, or an error when it is not valid.
+func WrapPlanet(v planet) (Planet, error) {
+	if i := int(v); i >= 0 && i < len(Planets.AllWithInvalid()) {
+		if p := Planets.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidPlanet, fmt.Errorf("failed to wrap invalid Planet: %d", v)
+}
+
+// AsPlanet returns the Planet wrapping the planet constant, or the invalid Planet when it is not valid.
+func AsPlanet(v planet) Planet {
+	p, _ := WrapPlanet(v)
+	return p
+}
+
+// PlanetNames returns the names of the valid Planet values in declaration order.
+func PlanetNames() []string {
+	return []string{"Mercury", "Venus", "Earth", "Mars", "Jupiter", "Saturn", "Uranus", "Neptune"}
+}
+
+// PlanetStrings returns every string ParsePlanet accepts for the valid values,
+// each name followed by its tag values and aliases.
+func PlanetStrings() []string {
+	return []string{"Mercury", "Venus", "Earth", "Mars", "Jupiter", "Saturn", "Uranus", "Neptune"}
+}
+
+var invalidPlanet = Planet{}
+
+func ParsePlanet(a any) (Planet, error) {
+	res := invalidPlanet
+	switch v := a.(type) {
+	case Planet:
+		return v, nil
+	case []byte:
+		res = stringToPlanet(string(v))
+	case string:
+		res = stringToPlanet(v)
+	case fmt.Stringer:
+		res = stringToPlanet(v.String())
+	case int:
+		res = intToPlanet(v)
+	case int64:
+		res = intToPlanet(int(v))
+	case int32:
+		res = intToPlanet(int(v))
+	}
+	return res, nil
+}
+
+func stringToPlanet(s string) Planet {
+	switch s {
+	case "unknown":
+		return Planets.UNKNOWN
+	case "Mercury":
+		return Planets.MERCURY
+	case "Venus":
+		return Planets.VENUS
+	case "Earth":
+		return Planets.EARTH
+	case "Mars":
+		return Planets.MARS
+	case "Jupiter":
+		return Planets.JUPITER
+	case "Saturn":
+		return Planets.SATURN
+	case "Uranus":
+		return Planets.URANUS
+	case "Neptune":
+		return Planets.NEPTUNE
+	}
+	return invalidPlanet
+}
+
+func intToPlanet(i int) Planet {
+	if i < 0 || i >= len(Planets.All()) {
+		return invalidPlanet
+	}
+	return Planets.All()[i]
+}
+
+func ExhaustivePlanets(f func(Planet)) {
+	for _, p := range Planets.All() {
+		f(p)
+	}
+}
+
+// ExhaustivePlanetsIncludingInvalid calls f with every declared Planet, including those marked invalid.
+func ExhaustivePlanetsIncludingInvalid(f func(Planet)) {
+	for _, p := range Planets.AllWithInvalid() {
+		f(p)
+	}
+}
+
+var validPlanets = map[Planet]bool{
+	Planets.MERCURY: true,
+	Planets.VENUS:   true,
+	Planets.EARTH:   true,
+	Planets.MARS:    true,
+	Planets.JUPITER: true,
+	Planets.SATURN:  true,
+	Planets.URANUS:  true,
+	Planets.NEPTUNE: true,
+}
+
+func (p Planet) IsValid() bool {
+	return validPlanets[p]
+}
+
+// IsZero reports whether the Planet is unset, meaning it holds the invalid value.
+func (p Planet) IsZero() bool {
+	return p.planet == invalidPlanet.planet && !p.IsValid()
+}
+
+// IsSet reports whether the Planet holds a value other than the invalid value.
+func (p Planet) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the Planet, for use in optional fields.
+func (p Planet) Ptr() *Planet {
+	return &p
+}
+
+// _planets_json holds the quoted JSON name of each declared Planet.
+var _planets_json = [...]string{
+	`"unknown"`,
+	`"Mercury"`,
+	`"Venus"`,
+	`"Earth"`,
+	`"Mars"`,
+	`"Jupiter"`,
+	`"Saturn"`,
+	`"Uranus"`,
+	`"Neptune"`,
+}
+
+// AppendJSON appends the JSON encoding of the Planet written by MarshalJSON to dst.
+func (p Planet) AppendJSON(dst []byte) []byte {
+	if i := int(p.planet); i >= 0 && i < len(_planets_json) {
+		return append(dst, _planets_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
+func (p Planet) MarshalJSON() ([]byte, error) {
+	return p.AppendJSON(nil), nil
+}
+
+func (p *Planet) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(bytes.Trim(b, `"`), ` `)
+	newp, err := ParsePlanet(b)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p *Planet) Scan(value any) error {
+	newp, err := ParsePlanet(value)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p Planet) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// PlanetSQLValues is the comma separated list of valid Planet values as stored by Value.
+const PlanetSQLValues = "'Mercury', 'Venus', 'Earth', 'Mars', 'Jupiter', 'Saturn', 'Uranus', 'Neptune'"
+
+// PlanetCheckConstraint returns a CHECK constraint restricting col to the valid Planet values.
+func PlanetCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + PlanetSQLValues + "))"
+}
+
+func (p Planet) Gravity() float64 {
+	return p.gravity
+}
+
+func (p Planet) RadiusKm() float64 {
+	return p.radiusKm
+}
+
+func (p Planet) MassKg() float64 {
+	return p.massKg
+}
+
+func (p Planet) OrbitKm() float64 {
+	return p.orbitKm
+}
+
+func (p Planet) OrbitDays() float64 {
+	return p.orbitDays
+}
+
+func (p Planet) SurfacePressureBars() float64 {
+	return p.surfacePressureBars
+}
+
+func (p Planet) Moons() int {
+	return p.moons
+}
+
+func (p Planet) Rings() bool {
+	return p.rings
+}
+
+// DisplayName returns the human readable name of the Planet from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p Planet) DisplayName() string {
+	return p.String()
+}
+
+func (p Planet) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.planet)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		if f.Flag('+') {
+			fmt.Fprintf(f, "%s{Gravity: %v, RadiusKm: %v, MassKg: %v, OrbitKm: %v, OrbitDays: %v, SurfacePressureBars: %v, Moons: %v, Rings: %v}", p.String(), p.gravity, p.radiusKm, p.massKg, p.orbitKm, p.orbitDays, p.surfacePressureBars, p.moons, p.rings)
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p Planet) GoString() string {
+	switch p.planet {
+	case mercury:
+		return "Planets.MERCURY"
+	case venus:
+		return "Planets.VENUS"
+	case earth:
+		return "Planets.EARTH"
+	case mars:
+		return "Planets.MARS"
+	case jupiter:
+		return "Planets.JUPITER"
+	case saturn:
+		return "Planets.SATURN"
+	case uranus:
+		return "Planets.URANUS"
+	case neptune:
+		return "Planets.NEPTUNE"
+	}
+	return "Planet{planet: " + strconv.FormatInt(int64(p.planet), 10) + "}"
+}
+
+// CacheKey returns a key for the Planet namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p Planet) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[unknown-0]
+	_ = x[mercury-1]
+	_ = x[venus-2]
+	_ = x[earth-3]
+	_ = x[mars-4]
+	_ = x[jupiter-5]
+	_ = x[saturn-6]
+	_ = x[uranus-7]
+	_ = x[neptune-8]
+}
+
+const _planets_name = "unknownMercuryVenusEarthMarsJupiterSaturnUranusNeptune"
+
+var _planets_index = [...]uint16{0, 7, 14, 19, 24, 28, 35, 41, 47, 54}
+
+func (i planet) String() string {
+	if i < 0 || i >= planet(len(_planets_index)-1) {
+		return "planets(" + (strconv.FormatInt(int64(i), 10) + ")")
+	}
+	return _planets_name[_planets_index[i]:_planets_index[i+1]]
+}
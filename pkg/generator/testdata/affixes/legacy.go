@@ -0,0 +1,7 @@
+package affixes
+
+// Status and Statuses are hand written and predate the generated enum, which
+// is generated with a suffix so both can be used while migrating.
+type Status string
+
+var Statuses = []Status{"passed", "failed"}
@@ -0,0 +1,14 @@
+package affixes
+
+type status int
+
+//go:generate goenums -suffix Enum status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
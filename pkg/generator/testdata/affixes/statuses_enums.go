@@ -0,0 +1,371 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -suffix Enum testdata/affixes/status.go
+// source checksum: 7c9d224caecd9651
+
+package affixes
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+type StatusEnum struct {
+	status
+}
+
+type statusesContainer struct {
+	// UNKNOWN is "unknown" with the value 0, marked invalid.
+	UNKNOWN StatusEnum
+	// FAILED is "failed" with the value 1.
+	FAILED StatusEnum
+	// PASSED is "passed" with the value 2.
+	PASSED StatusEnum
+	// SKIPPED is "skipped" with the value 3.
+	SKIPPED StatusEnum
+	// SCHEDULED is "scheduled" with the value 4.
+	SCHEDULED StatusEnum
+	// RUNNING is "running" with the value 5.
+	RUNNING StatusEnum
+	// BOOKED is "booked" with the value 6.
+	BOOKED StatusEnum
+}
+
+var StatusesEnum = statusesContainer{
+	FAILED: StatusEnum{
+		status: failed,
+	},
+	PASSED: StatusEnum{
+		status: passed,
+	},
+	SKIPPED: StatusEnum{
+		status: skipped,
+	},
+	SCHEDULED: StatusEnum{
+		status: scheduled,
+	},
+	RUNNING: StatusEnum{
+		status: running,
+	},
+	BOOKED: StatusEnum{
+		status: booked,
+	},
+}
+
+func (c statusesContainer) All() []StatusEnum {
+	return []StatusEnum{
+		c.FAILED,
+		c.PASSED,
+		c.SKIPPED,
+		c.SCHEDULED,
+		c.RUNNING,
+		c.BOOKED,
+	}
+}
+
+// AllWithInvalid returns every declared StatusEnum, including those marked invalid, in declaration order.
+func (c statusesContainer) AllWithInvalid() []StatusEnum {
+	return []StatusEnum{
+		{status: unknown},
+		c.FAILED,
+		c.PASSED,
+		c.SKIPPED,
+		c.SCHEDULED,
+		c.RUNNING,
+		c.BOOKED,
+	}
+}
+
+// Count returns the number of valid StatusEnum values.
+func (c statusesContainer) Count() int {
+	return 6
+}
+
+// First returns the first valid StatusEnum in declaration order.
+func (c statusesContainer) First() StatusEnum {
+	return c.FAILED
+}
+
+// Last returns the last valid StatusEnum in declaration order.
+func (c statusesContainer) Last() StatusEnum {
+	return c.BOOKED
+}
+
+// Ordinal returns the position the StatusEnum is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p StatusEnum) Ordinal() int {
+	switch p.status {
+	case unknown:
+		return 0
+	case failed:
+		return 1
+	case passed:
+		return 2
+	case skipped:
+		return 3
+	case scheduled:
+		return 4
+	case running:
+		return 5
+	case booked:
+		return 6
+	}
+	return -1
+}
+
+// Underlying returns the status constant the StatusEnum wraps.
+func (p StatusEnum) Underlying() status {
+	return p.status
+}
+
+// Base returns the status constant the StatusEnum wraps, the inverse of AsStatusEnum.
+func (p StatusEnum) Base() status {
+	return p.status
+}
+
+// WrapStatusEnum returns the StatusEnum wrapping the status constant, or an error when it is not valid.
+func WrapStatusEnum(v status) (StatusEnum, error) {
+	if i := int(v); i >= 0 && i < len(StatusesEnum.AllWithInvalid()) {
+		if p := StatusesEnum.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidStatusEnum, fmt.Errorf("failed to wrap invalid StatusEnum: %d", v)
+}
+
+// AsStatusEnum returns the StatusEnum wrapping the status constant, or the invalid StatusEnum when it is not valid.
+func AsStatusEnum(v status) StatusEnum {
+	p, _ := WrapStatusEnum(v)
+	return p
+}
+
+// StatusEnumNames returns the names of the valid StatusEnum values in declaration order.
+func StatusEnumNames() []string {
+	return []string{"failed", "passed", "skipped", "scheduled", "running", "booked"}
+}
+
+// StatusEnumStrings returns every string ParseStatusEnum accepts for the valid values,
+// each name followed by its tag values and aliases.
+func StatusEnumStrings() []string {
+	return []string{"failed", "passed", "skipped", "scheduled", "running", "booked"}
+}
+
+var invalidStatusEnum = StatusEnum{}
+
+func ParseStatusEnum(a any) (StatusEnum, error) {
+	res := invalidStatusEnum
+	switch v := a.(type) {
+	case StatusEnum:
+		return v, nil
+	case []byte:
+		res = stringToStatusEnum(string(v))
+	case string:
+		res = stringToStatusEnum(v)
+	case fmt.Stringer:
+		res = stringToStatusEnum(v.String())
+	case int:
+		res = intToStatusEnum(v)
+	case int64:
+		res = intToStatusEnum(int(v))
+	case int32:
+		res = intToStatusEnum(int(v))
+	}
+	return res, nil
+}
+
+func stringToStatusEnum(s string) StatusEnum {
+	switch s {
+	case "unknown":
+		return StatusesEnum.UNKNOWN
+	case "failed":
+		return StatusesEnum.FAILED
+	case "passed":
+		return StatusesEnum.PASSED
+	case "skipped":
+		return StatusesEnum.SKIPPED
+	case "scheduled":
+		return StatusesEnum.SCHEDULED
+	case "running":
+		return StatusesEnum.RUNNING
+	case "booked":
+		return StatusesEnum.BOOKED
+	}
+	return invalidStatusEnum
+}
+
+func intToStatusEnum(i int) StatusEnum {
+	if i < 0 || i >= len(StatusesEnum.All()) {
+		return invalidStatusEnum
+	}
+	return StatusesEnum.All()[i]
+}
+
+func ExhaustiveStatusEnums(f func(StatusEnum)) {
+	for _, p := range StatusesEnum.All() {
+		f(p)
+	}
+}
+
+// ExhaustiveStatusEnumsIncludingInvalid calls f with every declared StatusEnum, including those marked invalid.
+func ExhaustiveStatusEnumsIncludingInvalid(f func(StatusEnum)) {
+	for _, p := range StatusesEnum.AllWithInvalid() {
+		f(p)
+	}
+}
+
+var validStatusesEnum = map[StatusEnum]bool{
+	StatusesEnum.FAILED:    true,
+	StatusesEnum.PASSED:    true,
+	StatusesEnum.SKIPPED:   true,
+	StatusesEnum.SCHEDULED: true,
+	StatusesEnum.RUNNING:   true,
+	StatusesEnum.BOOKED:    true,
+}
+
+func (p StatusEnum) IsValid() bool {
+	return validStatusesEnum[p]
+}
+
+// IsZero reports whether the StatusEnum is unset, meaning it holds the invalid value.
+func (p StatusEnum) IsZero() bool {
+	return p.status == invalidStatusEnum.status && !p.IsValid()
+}
+
+// IsSet reports whether the StatusEnum holds a value other than the invalid value.
+func (p StatusEnum) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the StatusEnum, for use in optional fields.
+func (p StatusEnum) Ptr() *StatusEnum {
+	return &p
+}
+
+// _statuses_json holds the quoted JSON name of each declared StatusEnum.
+var _statuses_json = [...]string{
+	`"unknown"`,
+	`"failed"`,
+	`"passed"`,
+	`"skipped"`,
+	`"scheduled"`,
+	`"running"`,
+	`"booked"`,
+}
+
+// AppendJSON appends the JSON encoding of the StatusEnum written by MarshalJSON to dst.
+func (p StatusEnum) AppendJSON(dst []byte) []byte {
+	if i := int(p.status); i >= 0 && i < len(_statuses_json) {
+		return append(dst, _statuses_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
+func (p StatusEnum) MarshalJSON() ([]byte, error) {
+	return p.AppendJSON(nil), nil
+}
+
+func (p *StatusEnum) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(bytes.Trim(b, `"`), ` `)
+	newp, err := ParseStatusEnum(b)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p *StatusEnum) Scan(value any) error {
+	newp, err := ParseStatusEnum(value)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p StatusEnum) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// StatusEnumSQLValues is the comma separated list of valid StatusEnum values as stored by Value.
+const StatusEnumSQLValues = "'failed', 'passed', 'skipped', 'scheduled', 'running', 'booked'"
+
+// StatusEnumCheckConstraint returns a CHECK constraint restricting col to the valid StatusEnum values.
+func StatusEnumCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + StatusEnumSQLValues + "))"
+}
+
+// DisplayName returns the human readable name of the StatusEnum from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p StatusEnum) DisplayName() string {
+	return p.String()
+}
+
+func (p StatusEnum) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.status)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p StatusEnum) GoString() string {
+	switch p.status {
+	case failed:
+		return "StatusesEnum.FAILED"
+	case passed:
+		return "StatusesEnum.PASSED"
+	case skipped:
+		return "StatusesEnum.SKIPPED"
+	case scheduled:
+		return "StatusesEnum.SCHEDULED"
+	case running:
+		return "StatusesEnum.RUNNING"
+	case booked:
+		return "StatusesEnum.BOOKED"
+	}
+	return "StatusEnum{status: " + strconv.FormatInt(int64(p.status), 10) + "}"
+}
+
+// CacheKey returns a key for the StatusEnum namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p StatusEnum) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[unknown-0]
+	_ = x[failed-1]
+	_ = x[passed-2]
+	_ = x[skipped-3]
+	_ = x[scheduled-4]
+	_ = x[running-5]
+	_ = x[booked-6]
+}
+
+const _statuses_name = "unknownfailedpassedskippedscheduledrunningbooked"
+
+var _statuses_index = [...]uint16{0, 7, 13, 19, 26, 35, 42, 48}
+
+func (i status) String() string {
+	if i < 0 || i >= status(len(_statuses_index)-1) {
+		return "statuses(" + (strconv.FormatInt(int64(i), 10) + ")")
+	}
+	return _statuses_name[_statuses_index[i]:_statuses_index[i+1]]
+}
@@ -0,0 +1,12 @@
+package basemethods
+
+// Status is used in exported APIs, so the methods are generated on it directly.
+type Status uint8
+
+//go:generate goenums -base-methods status.go
+const (
+	Unknown Status = iota // invalid
+	Active                // parse:"enabled"
+	Suspended
+	Archived
+)
@@ -0,0 +1,115 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -base-methods testdata/basemethods/status.go
+// source checksum: 0857d2a4fe2e6d5a
+
+package basemethods
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[Unknown-0]
+	_ = x[Active-1]
+	_ = x[Suspended-2]
+	_ = x[Archived-3]
+}
+
+const _statuses_name = "UnknownActiveSuspendedArchived"
+
+var _statuses_index = [...]uint16{0, 7, 13, 22, 30}
+
+func (i Status) String() string {
+	if i < 0 || i >= Status(len(_statuses_index)-1) {
+		return "statuses(" + (strconv.FormatInt(int64(i), 10) + ")")
+	}
+	return _statuses_name[_statuses_index[i]:_statuses_index[i+1]]
+}
+
+// IsValid reports whether the Status is one of the valid constants.
+func (i Status) IsValid() bool {
+	switch i {
+	case Active, Suspended, Archived:
+		return true
+	}
+	return false
+}
+
+// ParseStatus parses a name, alias or number to a valid Status.
+func ParseStatus(a any) (Status, error) {
+	var res Status
+	ok := false
+	switch v := a.(type) {
+	case Status:
+		res, ok = v, true
+	case []byte:
+		res, ok = stringToStatus(string(v))
+	case string:
+		res, ok = stringToStatus(v)
+	case fmt.Stringer:
+		res, ok = stringToStatus(v.String())
+	case int:
+		res = Status(v)
+		ok = int(res) == v
+	case int64:
+		res = Status(v)
+		ok = int64(res) == v
+	case int32:
+		res = Status(v)
+		ok = int32(res) == v
+	}
+	if !ok || !res.IsValid() {
+		return res, fmt.Errorf("failed to parse invalid Status: %v", a)
+	}
+	return res, nil
+}
+
+func stringToStatus(s string) (Status, bool) {
+	switch s {
+	case "Unknown":
+		return Unknown, true
+	case "Active", "enabled":
+		return Active, true
+	case "Suspended":
+		return Suspended, true
+	case "Archived":
+		return Archived, true
+	}
+	return 0, false
+}
+
+func (i Status) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + i.String() + `"`), nil
+}
+
+func (i *Status) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(bytes.Trim(b, `"`), ` `)
+	v, err := ParseStatus(b)
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+func (i *Status) Scan(value any) error {
+	v, err := ParseStatus(value)
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+func (i Status) Value() (driver.Value, error) {
+	return i.String(), nil
+}
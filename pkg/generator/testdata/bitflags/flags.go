@@ -0,0 +1,10 @@
+package bitflags
+
+type flag int
+
+//go:generate goenums flags.go
+const (
+	read flag = 1 << iota
+	write
+	exec
+)
@@ -0,0 +1,19 @@
+package bitflagswide
+
+type perm int
+
+//go:generate goenums perms.go
+const (
+	read perm = 1 << iota
+	write
+	exec
+	list
+	create
+	remove
+	rename
+	share
+	lock
+	audit
+	admin
+	owner
+)
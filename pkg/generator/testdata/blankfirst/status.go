@@ -0,0 +1,10 @@
+package blankfirst
+
+type status int
+
+//go:generate goenums status.go
+const (
+	_ status = iota
+	active
+	archived
+)
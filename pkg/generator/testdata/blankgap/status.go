@@ -0,0 +1,12 @@
+package blankgap
+
+type status int
+
+//go:generate goenums status.go
+const (
+	_ status = iota
+	_
+	active
+	_
+	archived
+)
@@ -0,0 +1,13 @@
+package coverage
+
+type status int
+
+//go:generate goenums -f -coverage status.go
+const (
+	unknown status = iota // invalid
+	failed                // db:"FAILED"
+	passed                // parse:"ok, succeeded"
+	skipped
+	scheduled
+	running
+)
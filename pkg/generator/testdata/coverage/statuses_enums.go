@@ -0,0 +1,377 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -f -coverage testdata/coverage/status.go
+// source checksum: ba6cc7f5d11f2f8b
+//coverage:ignore
+
+package coverage
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+type Status struct {
+	status
+}
+
+type statusesContainer struct {
+	// UNKNOWN is "unknown" with the value 0, marked invalid.
+	UNKNOWN Status
+	// FAILED is "failed" with the value 1.
+	FAILED Status
+	// PASSED is "passed" with the value 2.
+	PASSED Status
+	// SKIPPED is "skipped" with the value 3.
+	SKIPPED Status
+	// SCHEDULED is "scheduled" with the value 4.
+	SCHEDULED Status
+	// RUNNING is "running" with the value 5.
+	RUNNING Status
+}
+
+var Statuses = statusesContainer{
+	FAILED: Status{
+		status: failed,
+	},
+	PASSED: Status{
+		status: passed,
+	},
+	SKIPPED: Status{
+		status: skipped,
+	},
+	SCHEDULED: Status{
+		status: scheduled,
+	},
+	RUNNING: Status{
+		status: running,
+	},
+}
+
+func (c statusesContainer) All() []Status {
+	return []Status{
+		c.FAILED,
+		c.PASSED,
+		c.SKIPPED,
+		c.SCHEDULED,
+		c.RUNNING,
+	}
+}
+
+// AllWithInvalid returns every declared Status, including those marked invalid, in declaration order.
+func (c statusesContainer) AllWithInvalid() []Status {
+	return []Status{
+		{status: unknown},
+		c.FAILED,
+		c.PASSED,
+		c.SKIPPED,
+		c.SCHEDULED,
+		c.RUNNING,
+	}
+}
+
+// Count returns the number of valid Status values.
+func (c statusesContainer) Count() int {
+	return 5
+}
+
+// First returns the first valid Status in declaration order.
+func (c statusesContainer) First() Status {
+	return c.FAILED
+}
+
+// Last returns the last valid Status in declaration order.
+func (c statusesContainer) Last() Status {
+	return c.RUNNING
+}
+
+// Ordinal returns the position the Status is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p Status) Ordinal() int {
+	switch p.status {
+	case unknown:
+		return 0
+	case failed:
+		return 1
+	case passed:
+		return 2
+	case skipped:
+		return 3
+	case scheduled:
+		return 4
+	case running:
+		return 5
+	}
+	return -1
+}
+
+// Underlying returns the status constant the Status wraps.
+func (p Status) Underlying() status {
+	return p.status
+}
+
+// Base returns the status constant the Status wraps, the inverse of AsStatus.
+func (p Status) Base() status {
+	return p.status
+}
+
+// WrapStatus returns the Status wrapping the status constant, or an error when it is not valid.
+func WrapStatus(v status) (Status, error) {
+	if i := int(v); i >= 0 && i < len(Statuses.AllWithInvalid()) {
+		if p := Statuses.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidStatus, fmt.Errorf("failed to wrap invalid Status: %d", v)
+}
+
+// AsStatus returns the Status wrapping the status constant, or the invalid Status when it is not valid.
+func AsStatus(v status) Status {
+	p, _ := WrapStatus(v)
+	return p
+}
+
+// StatusNames returns the names of the valid Status values in declaration order.
+func StatusNames() []string {
+	return []string{"failed", "passed", "skipped", "scheduled", "running"}
+}
+
+// StatusStrings returns every string ParseStatus accepts for the valid values,
+// each name followed by its tag values and aliases.
+func StatusStrings() []string {
+	return []string{"failed", "FAILED", "passed", "ok", "succeeded", "skipped", "scheduled", "running"}
+}
+
+var invalidStatus = Status{}
+
+func ParseStatus(a any) (Status, error) {
+	res := invalidStatus
+	switch v := a.(type) {
+	case Status:
+		return v, nil
+	case []byte:
+		res = stringToStatus(string(v))
+	case string:
+		res = stringToStatus(v)
+	case fmt.Stringer:
+		res = stringToStatus(v.String())
+	case int:
+		res = intToStatus(v)
+	case int64:
+		res = intToStatus(int(v))
+	case int32:
+		res = intToStatus(int(v))
+	}
+	if res == invalidStatus {
+		return res, fmt.Errorf("failed to parse invalid Status: %v", a)
+	}
+	return res, nil
+}
+
+var stringToStatusMap = map[string]Status{
+	"unknown":   Statuses.UNKNOWN,
+	"failed":    Statuses.FAILED,
+	"FAILED":    Statuses.FAILED,
+	"passed":    Statuses.PASSED,
+	"ok":        Statuses.PASSED,
+	"succeeded": Statuses.PASSED,
+	"skipped":   Statuses.SKIPPED,
+	"scheduled": Statuses.SCHEDULED,
+	"running":   Statuses.RUNNING,
+}
+
+func stringToStatus(s string) Status {
+	if p, ok := stringToStatusMap[s]; ok {
+		return p
+	}
+	return invalidStatus
+}
+
+var intToStatusMap = map[int]Status{
+	0: Statuses.FAILED,
+	1: Statuses.PASSED,
+	2: Statuses.SKIPPED,
+	3: Statuses.SCHEDULED,
+	4: Statuses.RUNNING,
+}
+
+func intToStatus(i int) Status {
+	if p, ok := intToStatusMap[i]; ok {
+		return p
+	}
+	return invalidStatus
+}
+
+func ExhaustiveStatuss(f func(Status)) {
+	for _, p := range Statuses.All() {
+		f(p)
+	}
+}
+
+// ExhaustiveStatussIncludingInvalid calls f with every declared Status, including those marked invalid.
+func ExhaustiveStatussIncludingInvalid(f func(Status)) {
+	for _, p := range Statuses.AllWithInvalid() {
+		f(p)
+	}
+}
+
+var validStatuses = map[Status]bool{
+	Statuses.FAILED:    true,
+	Statuses.PASSED:    true,
+	Statuses.SKIPPED:   true,
+	Statuses.SCHEDULED: true,
+	Statuses.RUNNING:   true,
+}
+
+func (p Status) IsValid() bool {
+	return validStatuses[p]
+}
+
+// IsZero reports whether the Status is unset, meaning it holds the invalid value.
+func (p Status) IsZero() bool {
+	return p.status == invalidStatus.status && !p.IsValid()
+}
+
+// IsSet reports whether the Status holds a value other than the invalid value.
+func (p Status) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the Status, for use in optional fields.
+func (p Status) Ptr() *Status {
+	return &p
+}
+
+// _statuses_json holds the quoted JSON name of each declared Status.
+var _statuses_json = [...]string{
+	`"unknown"`,
+	`"failed"`,
+	`"passed"`,
+	`"skipped"`,
+	`"scheduled"`,
+	`"running"`,
+}
+
+// AppendJSON appends the JSON encoding of the Status written by MarshalJSON to dst.
+func (p Status) AppendJSON(dst []byte) []byte {
+	if i := int(p.status); i >= 0 && i < len(_statuses_json) {
+		return append(dst, _statuses_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
+func (p Status) MarshalJSON() ([]byte, error) {
+	return p.AppendJSON(nil), nil
+}
+
+func (p *Status) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(bytes.Trim(b, `"`), ` `)
+	newp, err := ParseStatus(b)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p *Status) Scan(value any) error {
+	newp, err := ParseStatus(value)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p Status) Value() (driver.Value, error) {
+	return p.DBName(), nil
+}
+
+// StatusSQLValues is the comma separated list of valid Status values as stored by Value.
+const StatusSQLValues = "'FAILED', 'passed', 'skipped', 'scheduled', 'running'"
+
+// StatusCheckConstraint returns a CHECK constraint restricting col to the valid Status values.
+func StatusCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + StatusSQLValues + "))"
+}
+
+// DBName returns the db tag of the Status, or its name when it has none.
+func (p Status) DBName() string {
+	switch p.status {
+	case failed:
+		return "FAILED"
+	}
+	return p.String()
+}
+
+// DisplayName returns the human readable name of the Status from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p Status) DisplayName() string {
+	return p.String()
+}
+
+func (p Status) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.status)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p Status) GoString() string {
+	switch p.status {
+	case failed:
+		return "Statuses.FAILED"
+	case passed:
+		return "Statuses.PASSED"
+	case skipped:
+		return "Statuses.SKIPPED"
+	case scheduled:
+		return "Statuses.SCHEDULED"
+	case running:
+		return "Statuses.RUNNING"
+	}
+	return "Status{status: " + strconv.FormatInt(int64(p.status), 10) + "}"
+}
+
+// CacheKey returns a key for the Status namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p Status) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[unknown-0]
+	_ = x[failed-1]
+	_ = x[passed-2]
+	_ = x[skipped-3]
+	_ = x[scheduled-4]
+	_ = x[running-5]
+}
+
+const _statuses_name = "unknownfailedpassedskippedscheduledrunning"
+
+var _statuses_index = [...]uint16{0, 7, 13, 19, 26, 35, 42}
+
+func (i status) String() string {
+	if i < 0 || i >= status(len(_statuses_index)-1) {
+		return "statuses(" + (strconv.FormatInt(int64(i), 10) + ")")
+	}
+	return _statuses_name[_statuses_index[i]:_statuses_index[i+1]]
+}
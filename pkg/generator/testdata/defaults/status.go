@@ -0,0 +1,18 @@
+package defaults
+
+type status int
+
+//go:generate goenums status.go
+const (
+	unknown status = iota // invalid
+	active
+	inactive
+	suspended
+	// enabled is the name used before active
+	enabled = active
+)
+
+//goenums:default
+const defaultStatus = active
+
+const maxRetries = 3
@@ -0,0 +1,12 @@
+package descriptions
+
+type planet int // Gravity[float64],Description[string]
+
+//go:generate goenums planets.go
+const (
+	unknown planet = iota // invalid
+	mercury               // Mercury 0.378,"The smallest planet, and the closest to the Sun."
+	venus                 // Venus 0.907,"Hottest planet; its clouds are (mostly) sulfuric acid!"
+	earth                 // Earth 1,"Home, \"sweet\" home - not an invalid planet."
+	mars                  // Mars 0.377,"The red planet." desc="The red planet: dusty, cold & thin-aired."
+)
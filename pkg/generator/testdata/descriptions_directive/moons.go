@@ -0,0 +1,10 @@
+package descriptionsdirective
+
+type moon int
+
+//go:generate goenums moons.go
+const (
+	luna   moon = iota // Luna desc="Earth's only natural satellite."
+	phobos             // Phobos desc="The larger, inner moon of Mars."
+	deimos             // Deimos
+)
@@ -0,0 +1,325 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums testdata/descriptions_directive/moons.go
+// source checksum: cebece65993b4cf3
+
+package descriptionsdirective
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+type Moon struct {
+	moon
+	description string
+}
+
+type moonsContainer struct {
+	// LUNA is "Luna" with the value 0.
+	LUNA Moon
+	// PHOBOS is "Phobos" with the value 1.
+	PHOBOS Moon
+	// DEIMOS is "Deimos" with the value 2.
+	DEIMOS Moon
+}
+
+var Moons = moonsContainer{
+	LUNA: Moon{
+		moon:        luna,
+		description: "Earth's only natural satellite.",
+	},
+	PHOBOS: Moon{
+		moon:        phobos,
+		description: "The larger, inner moon of Mars.",
+	},
+	DEIMOS: Moon{
+		moon: deimos,
+	},
+}
+
+func (c moonsContainer) All() []Moon {
+	return []Moon{
+		c.LUNA,
+		c.PHOBOS,
+		c.DEIMOS,
+	}
+}
+
+// AllWithInvalid returns every declared Moon, including those marked invalid, in declaration order.
+func (c moonsContainer) AllWithInvalid() []Moon {
+	return []Moon{
+		c.LUNA,
+		c.PHOBOS,
+		c.DEIMOS,
+	}
+}
+
+// Count returns the number of valid Moon values.
+func (c moonsContainer) Count() int {
+	return 3
+}
+
+// First returns the first valid Moon in declaration order.
+func (c moonsContainer) First() Moon {
+	return c.LUNA
+}
+
+// Last returns the last valid Moon in declaration order.
+func (c moonsContainer) Last() Moon {
+	return c.DEIMOS
+}
+
+// Ordinal returns the position the Moon is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p Moon) Ordinal() int {
+	switch p.moon {
+	case luna:
+		return 0
+	case phobos:
+		return 1
+	case deimos:
+		return 2
+	}
+	return -1
+}
+
+// Underlying returns the moon constant the Moon wraps.
+func (p Moon) Underlying() moon {
+	return p.moon
+}
+
+// Base returns the moon constant the Moon wraps, the inverse of AsMoon.
+func (p Moon) Base() moon {
+	return p.moon
+}
+
+// WrapMoon returns the Moon wrapping the moon constant, or an error when it is not valid.
+func WrapMoon(v moon) (Moon, error) {
+	if i := int(v); i >= 0 && i < len(Moons.AllWithInvalid()) {
+		if p := Moons.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidMoon, fmt.Errorf("failed to wrap invalid Moon: %d", v)
+}
+
+// AsMoon returns the Moon wrapping the moon constant, or the invalid Moon when it is not valid.
+func AsMoon(v moon) Moon {
+	p, _ := WrapMoon(v)
+	return p
+}
+
+// MoonNames returns the names of the valid Moon values in declaration order.
+func MoonNames() []string {
+	return []string{"Luna", "Phobos", "Deimos"}
+}
+
+// MoonStrings returns every string ParseMoon accepts for the valid values,
+// each name followed by its tag values and aliases.
+func MoonStrings() []string {
+	return []string{"Luna", "Phobos", "Deimos"}
+}
+
+var invalidMoon = Moon{}
+
+func ParseMoon(a any) (Moon, error) {
+	res := invalidMoon
+	switch v := a.(type) {
+	case Moon:
+		return v, nil
+	case []byte:
+		res = stringToMoon(string(v))
+	case string:
+		res = stringToMoon(v)
+	case fmt.Stringer:
+		res = stringToMoon(v.String())
+	case int:
+		res = intToMoon(v)
+	case int64:
+		res = intToMoon(int(v))
+	case int32:
+		res = intToMoon(int(v))
+	}
+	return res, nil
+}
+
+func stringToMoon(s string) Moon {
+	switch s {
+	case "Luna":
+		return Moons.LUNA
+	case "Phobos":
+		return Moons.PHOBOS
+	case "Deimos":
+		return Moons.DEIMOS
+	}
+	return invalidMoon
+}
+
+func intToMoon(i int) Moon {
+	if i < 0 || i >= len(Moons.All()) {
+		return invalidMoon
+	}
+	return Moons.All()[i]
+}
+
+func ExhaustiveMoons(f func(Moon)) {
+	for _, p := range Moons.All() {
+		f(p)
+	}
+}
+
+// ExhaustiveMoonsIncludingInvalid calls f with every declared Moon, including those marked invalid.
+func ExhaustiveMoonsIncludingInvalid(f func(Moon)) {
+	for _, p := range Moons.AllWithInvalid() {
+		f(p)
+	}
+}
+
+var validMoons = map[Moon]bool{
+	Moons.LUNA:   true,
+	Moons.PHOBOS: true,
+	Moons.DEIMOS: true,
+}
+
+func (p Moon) IsValid() bool {
+	return validMoons[p]
+}
+
+// IsZero reports whether the Moon is unset, meaning it holds the invalid value.
+func (p Moon) IsZero() bool {
+	return p.moon == invalidMoon.moon && !p.IsValid()
+}
+
+// IsSet reports whether the Moon holds a value other than the invalid value.
+func (p Moon) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the Moon, for use in optional fields.
+func (p Moon) Ptr() *Moon {
+	return &p
+}
+
+// _moons_json holds the quoted JSON name of each declared Moon.
+var _moons_json = [...]string{
+	`"Luna"`,
+	`"Phobos"`,
+	`"Deimos"`,
+}
+
+// AppendJSON appends the JSON encoding of the Moon written by MarshalJSON to dst.
+func (p Moon) AppendJSON(dst []byte) []byte {
+	if i := int(p.moon); i >= 0 && i < len(_moons_json) {
+		return append(dst, _moons_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
+func (p Moon) MarshalJSON() ([]byte, error) {
+	return p.AppendJSON(nil), nil
+}
+
+func (p *Moon) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(bytes.Trim(b, `"`), ` `)
+	newp, err := ParseMoon(b)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p *Moon) Scan(value any) error {
+	newp, err := ParseMoon(value)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p Moon) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// MoonSQLValues is the comma separated list of valid Moon values as stored by Value.
+const MoonSQLValues = "'Luna', 'Phobos', 'Deimos'"
+
+// MoonCheckConstraint returns a CHECK constraint restricting col to the valid Moon values.
+func MoonCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + MoonSQLValues + "))"
+}
+
+func (p Moon) Description() string {
+	return p.description
+}
+
+// DisplayName returns the human readable name of the Moon from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p Moon) DisplayName() string {
+	return p.String()
+}
+
+func (p Moon) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.moon)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		if f.Flag('+') {
+			fmt.Fprintf(f, "%s{Description: %v}", p.String(), p.description)
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p Moon) GoString() string {
+	switch p.moon {
+	case luna:
+		return "Moons.LUNA"
+	case phobos:
+		return "Moons.PHOBOS"
+	case deimos:
+		return "Moons.DEIMOS"
+	}
+	return "Moon{moon: " + strconv.FormatInt(int64(p.moon), 10) + "}"
+}
+
+// CacheKey returns a key for the Moon namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p Moon) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[luna-0]
+	_ = x[phobos-1]
+	_ = x[deimos-2]
+}
+
+const _moons_name = "LunaPhobosDeimos"
+
+var _moons_index = [...]uint16{0, 4, 10, 16}
+
+func (i moon) String() string {
+	if i < 0 || i >= moon(len(_moons_index)-1) {
+		return "moons(" + (strconv.FormatInt(int64(i), 10) + ")")
+	}
+	return _moons_name[_moons_index[i]:_moons_index[i+1]]
+}
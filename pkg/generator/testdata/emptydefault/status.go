@@ -0,0 +1,12 @@
+package emptydefault
+
+type status int
+
+//go:generate goenums -f -emptydefault -yaml v2 status.go
+const (
+	unknown status = iota // invalid
+	//goenums:default
+	pending
+	active
+	closed
+)
@@ -0,0 +1,363 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -f -yaml v2 -emptydefault testdata/emptydefault/status.go
+// source checksum: 101f410417aad682
+
+package emptydefault
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+type Status struct {
+	status
+}
+
+type statusesContainer struct {
+	// UNKNOWN is "unknown" with the value 0, marked invalid.
+	UNKNOWN Status
+	// PENDING is "pending" with the value 1.
+	PENDING Status
+	// ACTIVE is "active" with the value 2.
+	ACTIVE Status
+	// CLOSED is "closed" with the value 3.
+	CLOSED Status
+}
+
+var Statuses = statusesContainer{
+	PENDING: Status{
+		status: pending,
+	},
+	ACTIVE: Status{
+		status: active,
+	},
+	CLOSED: Status{
+		status: closed,
+	},
+}
+
+func (c statusesContainer) All() []Status {
+	return []Status{
+		c.PENDING,
+		c.ACTIVE,
+		c.CLOSED,
+	}
+}
+
+// AllWithInvalid returns every declared Status, including those marked invalid, in declaration order.
+func (c statusesContainer) AllWithInvalid() []Status {
+	return []Status{
+		{status: unknown},
+		c.PENDING,
+		c.ACTIVE,
+		c.CLOSED,
+	}
+}
+
+// Count returns the number of valid Status values.
+func (c statusesContainer) Count() int {
+	return 3
+}
+
+// First returns the first valid Status in declaration order.
+func (c statusesContainer) First() Status {
+	return c.PENDING
+}
+
+// Last returns the last valid Status in declaration order.
+func (c statusesContainer) Last() Status {
+	return c.CLOSED
+}
+
+// Ordinal returns the position the Status is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p Status) Ordinal() int {
+	switch p.status {
+	case unknown:
+		return 0
+	case pending:
+		return 1
+	case active:
+		return 2
+	case closed:
+		return 3
+	}
+	return -1
+}
+
+// Underlying returns the status constant the Status wraps.
+func (p Status) Underlying() status {
+	return p.status
+}
+
+// Base returns the status constant the Status wraps, the inverse of AsStatus.
+func (p Status) Base() status {
+	return p.status
+}
+
+// WrapStatus returns the Status wrapping the status constant, or an error when it is not valid.
+func WrapStatus(v status) (Status, error) {
+	if i := int(v); i >= 0 && i < len(Statuses.AllWithInvalid()) {
+		if p := Statuses.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidStatus, fmt.Errorf("failed to wrap invalid Status: %d", v)
+}
+
+// AsStatus returns the Status wrapping the status constant, or the invalid Status when it is not valid.
+func AsStatus(v status) Status {
+	p, _ := WrapStatus(v)
+	return p
+}
+
+// StatusNames returns the names of the valid Status values in declaration order.
+func StatusNames() []string {
+	return []string{"pending", "active", "closed"}
+}
+
+// StatusStrings returns every string ParseStatus accepts for the valid values,
+// each name followed by its tag values and aliases.
+func StatusStrings() []string {
+	return []string{"pending", "active", "closed"}
+}
+
+var invalidStatus = Status{}
+
+func ParseStatus(a any) (Status, error) {
+	res := invalidStatus
+	switch v := a.(type) {
+	case Status:
+		return v, nil
+	case []byte:
+		res = stringToStatus(string(v))
+	case string:
+		res = stringToStatus(v)
+	case fmt.Stringer:
+		res = stringToStatus(v.String())
+	case int:
+		res = intToStatus(v)
+	case int64:
+		res = intToStatus(int(v))
+	case int32:
+		res = intToStatus(int(v))
+	}
+	if res == invalidStatus {
+		return res, fmt.Errorf("failed to parse invalid Status: %v", a)
+	}
+	return res, nil
+}
+
+func stringToStatus(s string) Status {
+	switch s {
+	case "unknown":
+		return Statuses.UNKNOWN
+	case "pending":
+		return Statuses.PENDING
+	case "active":
+		return Statuses.ACTIVE
+	case "closed":
+		return Statuses.CLOSED
+	}
+	return invalidStatus
+}
+
+func intToStatus(i int) Status {
+	if i < 0 || i >= len(Statuses.All()) {
+		return invalidStatus
+	}
+	return Statuses.All()[i]
+}
+
+func ExhaustiveStatuss(f func(Status)) {
+	for _, p := range Statuses.All() {
+		f(p)
+	}
+}
+
+// ExhaustiveStatussIncludingInvalid calls f with every declared Status, including those marked invalid.
+func ExhaustiveStatussIncludingInvalid(f func(Status)) {
+	for _, p := range Statuses.AllWithInvalid() {
+		f(p)
+	}
+}
+
+var validStatuses = map[Status]bool{
+	Statuses.PENDING: true,
+	Statuses.ACTIVE:  true,
+	Statuses.CLOSED:  true,
+}
+
+func (p Status) IsValid() bool {
+	return validStatuses[p]
+}
+
+// IsZero reports whether the Status is unset, meaning it holds the invalid value.
+func (p Status) IsZero() bool {
+	return p.status == invalidStatus.status && !p.IsValid()
+}
+
+// IsSet reports whether the Status holds a value other than the invalid value.
+func (p Status) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the Status, for use in optional fields.
+func (p Status) Ptr() *Status {
+	return &p
+}
+
+// DefaultStatus returns the default Status, Statuses.PENDING.
+func DefaultStatus() Status {
+	return Statuses.PENDING
+}
+
+// _statuses_json holds the quoted JSON name of each declared Status.
+var _statuses_json = [...]string{
+	`"unknown"`,
+	`"pending"`,
+	`"active"`,
+	`"closed"`,
+}
+
+// AppendJSON appends the JSON encoding of the Status written by MarshalJSON to dst.
+func (p Status) AppendJSON(dst []byte) []byte {
+	if i := int(p.status); i >= 0 && i < len(_statuses_json) {
+		return append(dst, _statuses_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
+func (p Status) MarshalJSON() ([]byte, error) {
+	return p.AppendJSON(nil), nil
+}
+
+func (p *Status) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(bytes.Trim(b, `"`), ` `)
+	if len(b) == 0 || string(b) == "null" {
+		*p = DefaultStatus()
+		return nil
+	}
+	newp, err := ParseStatus(b)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p *Status) Scan(value any) error {
+	if b, ok := value.([]byte); ok && len(b) == 0 {
+		value = nil
+	}
+	if value == nil || value == "" {
+		*p = DefaultStatus()
+		return nil
+	}
+	newp, err := ParseStatus(value)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p Status) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// StatusSQLValues is the comma separated list of valid Status values as stored by Value.
+const StatusSQLValues = "'pending', 'active', 'closed'"
+
+// StatusCheckConstraint returns a CHECK constraint restricting col to the valid Status values.
+func StatusCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + StatusSQLValues + "))"
+}
+
+// DisplayName returns the human readable name of the Status from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p Status) DisplayName() string {
+	return p.String()
+}
+
+func (p Status) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.status)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p Status) GoString() string {
+	switch p.status {
+	case pending:
+		return "Statuses.PENDING"
+	case active:
+		return "Statuses.ACTIVE"
+	case closed:
+		return "Statuses.CLOSED"
+	}
+	return "Status{status: " + strconv.FormatInt(int64(p.status), 10) + "}"
+}
+
+// CacheKey returns a key for the Status namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p Status) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
+func (p Status) MarshalYAML() (any, error) {
+	return p.String(), nil
+}
+
+func (p *Status) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	if s == "" {
+		*p = DefaultStatus()
+		return nil
+	}
+	newp, err := ParseStatus(s)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[unknown-0]
+	_ = x[pending-1]
+	_ = x[active-2]
+	_ = x[closed-3]
+}
+
+const _statuses_name = "unknownpendingactiveclosed"
+
+var _statuses_index = [...]uint16{0, 7, 14, 20, 26}
+
+func (i status) String() string {
+	if i < 0 || i >= status(len(_statuses_index)-1) {
+		return "statuses(" + (strconv.FormatInt(int64(i), 10) + ")")
+	}
+	return _statuses_name[_statuses_index[i]:_statuses_index[i+1]]
+}
@@ -0,0 +1,10 @@
+package emptyinvalid
+
+type color int
+
+//go:generate goenums -emptyinvalid -jsonv2 color.go
+const (
+	red color = iota
+	green
+	blue
+)
@@ -0,0 +1,321 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -jsonv2 -emptyinvalid testdata/emptyinvalid/color.go
+// source checksum: 23e9881923ff6b80
+
+package emptyinvalid
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+type Color struct {
+	color
+}
+
+type colorsContainer struct {
+	// RED is "red" with the value 0.
+	RED Color
+	// GREEN is "green" with the value 1.
+	GREEN Color
+	// BLUE is "blue" with the value 2.
+	BLUE Color
+}
+
+var Colors = colorsContainer{
+	RED: Color{
+		color: red,
+	},
+	GREEN: Color{
+		color: green,
+	},
+	BLUE: Color{
+		color: blue,
+	},
+}
+
+func (c colorsContainer) All() []Color {
+	return []Color{
+		c.RED,
+		c.GREEN,
+		c.BLUE,
+	}
+}
+
+// AllWithInvalid returns every declared Color, including those marked invalid, in declaration order.
+func (c colorsContainer) AllWithInvalid() []Color {
+	return []Color{
+		c.RED,
+		c.GREEN,
+		c.BLUE,
+	}
+}
+
+// Count returns the number of valid Color values.
+func (c colorsContainer) Count() int {
+	return 3
+}
+
+// First returns the first valid Color in declaration order.
+func (c colorsContainer) First() Color {
+	return c.RED
+}
+
+// Last returns the last valid Color in declaration order.
+func (c colorsContainer) Last() Color {
+	return c.BLUE
+}
+
+// Ordinal returns the position the Color is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p Color) Ordinal() int {
+	switch p.color {
+	case red:
+		return 0
+	case green:
+		return 1
+	case blue:
+		return 2
+	}
+	return -1
+}
+
+// Underlying returns the color constant the Color wraps.
+func (p Color) Underlying() color {
+	return p.color
+}
+
+// Base returns the color constant the Color wraps, the inverse of AsColor.
+func (p Color) Base() color {
+	return p.color
+}
+
+// WrapColor returns the Color wrapping the color constant, or an error when it is not valid.
+func WrapColor(v color) (Color, error) {
+	if i := int(v); i >= 0 && i < len(Colors.AllWithInvalid()) {
+		if p := Colors.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidColor, fmt.Errorf("failed to wrap invalid Color: %d", v)
+}
+
+// AsColor returns the Color wrapping the color constant, or the invalid Color when it is not valid.
+func AsColor(v color) Color {
+	p, _ := WrapColor(v)
+	return p
+}
+
+// ColorNames returns the names of the valid Color values in declaration order.
+func ColorNames() []string {
+	return []string{"red", "green", "blue"}
+}
+
+// ColorStrings returns every string ParseColor accepts for the valid values,
+// each name followed by its tag values and aliases.
+func ColorStrings() []string {
+	return []string{"red", "green", "blue"}
+}
+
+var invalidColor = Color{}
+
+func ParseColor(a any) (Color, error) {
+	res := invalidColor
+	switch v := a.(type) {
+	case Color:
+		return v, nil
+	case []byte:
+		res = stringToColor(string(v))
+	case string:
+		res = stringToColor(v)
+	case fmt.Stringer:
+		res = stringToColor(v.String())
+	case int:
+		res = intToColor(v)
+	case int64:
+		res = intToColor(int(v))
+	case int32:
+		res = intToColor(int(v))
+	}
+	return res, nil
+}
+
+func stringToColor(s string) Color {
+	switch s {
+	case "red":
+		return Colors.RED
+	case "green":
+		return Colors.GREEN
+	case "blue":
+		return Colors.BLUE
+	}
+	return invalidColor
+}
+
+func intToColor(i int) Color {
+	if i < 0 || i >= len(Colors.All()) {
+		return invalidColor
+	}
+	return Colors.All()[i]
+}
+
+func ExhaustiveColors(f func(Color)) {
+	for _, p := range Colors.All() {
+		f(p)
+	}
+}
+
+// ExhaustiveColorsIncludingInvalid calls f with every declared Color, including those marked invalid.
+func ExhaustiveColorsIncludingInvalid(f func(Color)) {
+	for _, p := range Colors.AllWithInvalid() {
+		f(p)
+	}
+}
+
+var validColors = map[Color]bool{
+	Colors.RED:   true,
+	Colors.GREEN: true,
+	Colors.BLUE:  true,
+}
+
+func (p Color) IsValid() bool {
+	return validColors[p]
+}
+
+// IsZero reports whether the Color is unset, meaning it holds the invalid value.
+func (p Color) IsZero() bool {
+	return p.color == invalidColor.color && !p.IsValid()
+}
+
+// IsSet reports whether the Color holds a value other than the invalid value.
+func (p Color) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the Color, for use in optional fields.
+func (p Color) Ptr() *Color {
+	return &p
+}
+
+// _colors_json holds the quoted JSON name of each declared Color.
+var _colors_json = [...]string{
+	`"red"`,
+	`"green"`,
+	`"blue"`,
+}
+
+// AppendJSON appends the JSON encoding of the Color written by MarshalJSON to dst.
+func (p Color) AppendJSON(dst []byte) []byte {
+	if !p.IsValid() {
+		return append(dst, `""`...)
+	}
+	if i := int(p.color); i >= 0 && i < len(_colors_json) {
+		return append(dst, _colors_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
+func (p Color) MarshalJSON() ([]byte, error) {
+	return p.AppendJSON(nil), nil
+}
+
+func (p *Color) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(bytes.Trim(b, `"`), ` `)
+	if len(b) == 0 || string(b) == "null" {
+		*p = invalidColor
+		return nil
+	}
+	newp, err := ParseColor(b)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p *Color) Scan(value any) error {
+	newp, err := ParseColor(value)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p Color) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// ColorSQLValues is the comma separated list of valid Color values as stored by Value.
+const ColorSQLValues = "'red', 'green', 'blue'"
+
+// ColorCheckConstraint returns a CHECK constraint restricting col to the valid Color values.
+func ColorCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + ColorSQLValues + "))"
+}
+
+// DisplayName returns the human readable name of the Color from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p Color) DisplayName() string {
+	return p.String()
+}
+
+func (p Color) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.color)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p Color) GoString() string {
+	switch p.color {
+	case red:
+		return "Colors.RED"
+	case green:
+		return "Colors.GREEN"
+	case blue:
+		return "Colors.BLUE"
+	}
+	return "Color{color: " + strconv.FormatInt(int64(p.color), 10) + "}"
+}
+
+// CacheKey returns a key for the Color namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p Color) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[red-0]
+	_ = x[green-1]
+	_ = x[blue-2]
+}
+
+const _colors_name = "redgreenblue"
+
+var _colors_index = [...]uint16{0, 3, 8, 12}
+
+func (i color) String() string {
+	if i < 0 || i >= color(len(_colors_index)-1) {
+		return "colors(" + (strconv.FormatInt(int64(i), 10) + ")")
+	}
+	return _colors_name[_colors_index[i]:_colors_index[i+1]]
+}
@@ -0,0 +1,35 @@
+//go:build goexperiment.jsonv2
+
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -jsonv2 -emptyinvalid testdata/emptyinvalid/color.go
+// source checksum: 23e9881923ff6b80
+
+package emptyinvalid
+
+import "encoding/json/jsontext"
+
+func (p Color) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !p.IsValid() {
+		return enc.WriteToken(jsontext.String(""))
+	}
+	return enc.WriteToken(jsontext.String(p.String()))
+}
+
+func (p *Color) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return err
+	}
+	if tok.Kind() == 'n' || tok.String() == "" {
+		*p = invalidColor
+		return nil
+	}
+	newp, err := ParseColor(tok.String())
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
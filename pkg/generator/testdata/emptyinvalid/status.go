@@ -0,0 +1,14 @@
+package emptyinvalid
+
+type status int
+
+//go:generate goenums -emptyinvalid -jsonv2 status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
@@ -0,0 +1,13 @@
+package floatintegral
+
+type status int
+
+//go:generate goenums -f -float-input integral status.go
+const (
+	failed status = iota // invalid
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
@@ -0,0 +1,369 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -f -float-input integral testdata/floatintegral/status.go
+// source checksum: 0d57bf602e2d7273
+
+package floatintegral
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+type Status struct {
+	status
+}
+
+type statusesContainer struct {
+	// FAILED is "failed" with the value 0, marked invalid.
+	FAILED Status
+	// PASSED is "passed" with the value 1.
+	PASSED Status
+	// SKIPPED is "skipped" with the value 2.
+	SKIPPED Status
+	// SCHEDULED is "scheduled" with the value 3.
+	SCHEDULED Status
+	// RUNNING is "running" with the value 4.
+	RUNNING Status
+	// BOOKED is "booked" with the value 5.
+	BOOKED Status
+}
+
+var Statuses = statusesContainer{
+	PASSED: Status{
+		status: passed,
+	},
+	SKIPPED: Status{
+		status: skipped,
+	},
+	SCHEDULED: Status{
+		status: scheduled,
+	},
+	RUNNING: Status{
+		status: running,
+	},
+	BOOKED: Status{
+		status: booked,
+	},
+}
+
+func (c statusesContainer) All() []Status {
+	return []Status{
+		c.PASSED,
+		c.SKIPPED,
+		c.SCHEDULED,
+		c.RUNNING,
+		c.BOOKED,
+	}
+}
+
+// AllWithInvalid returns every declared Status, including those marked invalid, in declaration order.
+func (c statusesContainer) AllWithInvalid() []Status {
+	return []Status{
+		{status: failed},
+		c.PASSED,
+		c.SKIPPED,
+		c.SCHEDULED,
+		c.RUNNING,
+		c.BOOKED,
+	}
+}
+
+// Count returns the number of valid Status values.
+func (c statusesContainer) Count() int {
+	return 5
+}
+
+// First returns the first valid Status in declaration order.
+func (c statusesContainer) First() Status {
+	return c.PASSED
+}
+
+// Last returns the last valid Status in declaration order.
+func (c statusesContainer) Last() Status {
+	return c.BOOKED
+}
+
+// Ordinal returns the position the Status is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p Status) Ordinal() int {
+	switch p.status {
+	case failed:
+		return 0
+	case passed:
+		return 1
+	case skipped:
+		return 2
+	case scheduled:
+		return 3
+	case running:
+		return 4
+	case booked:
+		return 5
+	}
+	return -1
+}
+
+// Underlying returns the status constant the Status wraps.
+func (p Status) Underlying() status {
+	return p.status
+}
+
+// Base returns the status constant the Status wraps, the inverse of AsStatus.
+func (p Status) Base() status {
+	return p.status
+}
+
+// WrapStatus returns the Status wrapping the status constant, or an error when it is not valid.
+func WrapStatus(v status) (Status, error) {
+	if i := int(v); i >= 0 && i < len(Statuses.AllWithInvalid()) {
+		if p := Statuses.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidStatus, fmt.Errorf("failed to wrap invalid Status: %d", v)
+}
+
+// AsStatus returns the Status wrapping the status constant, or the invalid Status when it is not valid.
+func AsStatus(v status) Status {
+	p, _ := WrapStatus(v)
+	return p
+}
+
+// StatusNames returns the names of the valid Status values in declaration order.
+func StatusNames() []string {
+	return []string{"passed", "skipped", "scheduled", "running", "booked"}
+}
+
+// StatusStrings returns every string ParseStatus accepts for the valid values,
+// each name followed by its tag values and aliases.
+func StatusStrings() []string {
+	return []string{"passed", "skipped", "scheduled", "running", "booked"}
+}
+
+var invalidStatus = Status{}
+
+func ParseStatus(a any) (Status, error) {
+	res := invalidStatus
+	switch v := a.(type) {
+	case Status:
+		return v, nil
+	case []byte:
+		res = stringToStatus(string(v))
+	case string:
+		res = stringToStatus(v)
+	case fmt.Stringer:
+		res = stringToStatus(v.String())
+	case int:
+		res = intToStatus(v)
+	case int64:
+		res = intToStatus(int(v))
+	case int32:
+		res = intToStatus(int(v))
+	case float64:
+		res = floatToStatus(v)
+	case float32:
+		res = floatToStatus(float64(v))
+	}
+	if res == invalidStatus {
+		return res, fmt.Errorf("failed to parse invalid Status: %v", a)
+	}
+	return res, nil
+}
+
+func stringToStatus(s string) Status {
+	switch s {
+	case "failed":
+		return Statuses.FAILED
+	case "passed":
+		return Statuses.PASSED
+	case "skipped":
+		return Statuses.SKIPPED
+	case "scheduled":
+		return Statuses.SCHEDULED
+	case "running":
+		return Statuses.RUNNING
+	case "booked":
+		return Statuses.BOOKED
+	}
+	return invalidStatus
+}
+
+func intToStatus(i int) Status {
+	if i < 0 || i >= len(Statuses.All()) {
+		return invalidStatus
+	}
+	return Statuses.All()[i]
+}
+
+func floatToStatus(f float64) Status {
+	if i := int(f); float64(i) == f {
+		return intToStatus(i)
+	}
+	return invalidStatus
+}
+
+func ExhaustiveStatuss(f func(Status)) {
+	for _, p := range Statuses.All() {
+		f(p)
+	}
+}
+
+// ExhaustiveStatussIncludingInvalid calls f with every declared Status, including those marked invalid.
+func ExhaustiveStatussIncludingInvalid(f func(Status)) {
+	for _, p := range Statuses.AllWithInvalid() {
+		f(p)
+	}
+}
+
+var validStatuses = map[Status]bool{
+	Statuses.PASSED:    true,
+	Statuses.SKIPPED:   true,
+	Statuses.SCHEDULED: true,
+	Statuses.RUNNING:   true,
+	Statuses.BOOKED:    true,
+}
+
+func (p Status) IsValid() bool {
+	return validStatuses[p]
+}
+
+// IsZero reports whether the Status is unset, meaning it holds the invalid value.
+func (p Status) IsZero() bool {
+	return p.status == invalidStatus.status && !p.IsValid()
+}
+
+// IsSet reports whether the Status holds a value other than the invalid value.
+func (p Status) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the Status, for use in optional fields.
+func (p Status) Ptr() *Status {
+	return &p
+}
+
+// _statuses_json holds the quoted JSON name of each declared Status.
+var _statuses_json = [...]string{
+	`"failed"`,
+	`"passed"`,
+	`"skipped"`,
+	`"scheduled"`,
+	`"running"`,
+	`"booked"`,
+}
+
+// AppendJSON appends the JSON encoding of the Status written by MarshalJSON to dst.
+func (p Status) AppendJSON(dst []byte) []byte {
+	if i := int(p.status); i >= 0 && i < len(_statuses_json) {
+		return append(dst, _statuses_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
+func (p Status) MarshalJSON() ([]byte, error) {
+	return p.AppendJSON(nil), nil
+}
+
+func (p *Status) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(bytes.Trim(b, `"`), ` `)
+	newp, err := ParseStatus(b)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p *Status) Scan(value any) error {
+	newp, err := ParseStatus(value)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p Status) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// StatusSQLValues is the comma separated list of valid Status values as stored by Value.
+const StatusSQLValues = "'passed', 'skipped', 'scheduled', 'running', 'booked'"
+
+// StatusCheckConstraint returns a CHECK constraint restricting col to the valid Status values.
+func StatusCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + StatusSQLValues + "))"
+}
+
+// DisplayName returns the human readable name of the Status from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p Status) DisplayName() string {
+	return p.String()
+}
+
+func (p Status) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.status)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p Status) GoString() string {
+	switch p.status {
+	case passed:
+		return "Statuses.PASSED"
+	case skipped:
+		return "Statuses.SKIPPED"
+	case scheduled:
+		return "Statuses.SCHEDULED"
+	case running:
+		return "Statuses.RUNNING"
+	case booked:
+		return "Statuses.BOOKED"
+	}
+	return "Status{status: " + strconv.FormatInt(int64(p.status), 10) + "}"
+}
+
+// CacheKey returns a key for the Status namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p Status) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[failed-0]
+	_ = x[passed-1]
+	_ = x[skipped-2]
+	_ = x[scheduled-3]
+	_ = x[running-4]
+	_ = x[booked-5]
+}
+
+const _statuses_name = "failedpassedskippedscheduledrunningbooked"
+
+var _statuses_index = [...]uint16{0, 6, 12, 19, 28, 35, 41}
+
+func (i status) String() string {
+	if i < 0 || i >= status(len(_statuses_index)-1) {
+		return "statuses(" + (strconv.FormatInt(int64(i), 10) + ")")
+	}
+	return _statuses_name[_statuses_index[i]:_statuses_index[i+1]]
+}
@@ -0,0 +1,13 @@
+package floatround
+
+type status int
+
+//go:generate goenums -f -float-input round status.go
+const (
+	failed status = iota // invalid
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
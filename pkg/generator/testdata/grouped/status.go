@@ -0,0 +1,10 @@
+package grouped
+
+type status int
+
+//go:generate goenums -f -zero-valid status.go
+const (
+	pending, active status = iota * 2, iota*2 + 1
+	paused, closed
+	_, archived
+)
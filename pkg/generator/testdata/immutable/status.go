@@ -0,0 +1,14 @@
+package immutable
+
+type status int
+
+//go:generate goenums -immutable status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
@@ -0,0 +1,14 @@
+package insensitive
+
+type status int
+
+//go:generate goenums -insensitive status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
@@ -0,0 +1,14 @@
+package jsonv2
+
+type status int
+
+//go:generate goenums -jsonv2 status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
@@ -0,0 +1,28 @@
+//go:build goexperiment.jsonv2
+
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -jsonv2 testdata/jsonv2/status.go
+// source checksum: 399e91b8c4b9c150
+
+package jsonv2
+
+import "encoding/json/jsontext"
+
+func (p Status) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String(p.String()))
+}
+
+func (p *Status) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return err
+	}
+	newp, err := ParseStatus(tok.String())
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
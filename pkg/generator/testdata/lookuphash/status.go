@@ -0,0 +1,13 @@
+package lookuphash
+
+type status int
+
+//go:generate goenums -f -parse-lookup hash status.go
+const (
+	unknown status = iota // invalid
+	failed                // db:"FAILED"
+	passed                // parse:"ok, succeeded"
+	skipped
+	scheduled
+	running
+)
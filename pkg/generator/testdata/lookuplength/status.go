@@ -0,0 +1,13 @@
+package lookuplength
+
+type status int
+
+//go:generate goenums -f -parse-lookup length status.go
+const (
+	unknown status = iota // invalid
+	failed                // db:"FAILED"
+	passed                // parse:"ok, succeeded"
+	skipped
+	scheduled
+	running
+)
@@ -0,0 +1,13 @@
+package lookupswitch
+
+type status int
+
+//go:generate goenums -f -parse-lookup switch status.go
+const (
+	unknown status = iota // invalid
+	failed                // db:"FAILED"
+	passed                // parse:"ok, succeeded"
+	skipped
+	scheduled
+	running
+)
@@ -0,0 +1,14 @@
+package marshalinvalid
+
+type status int
+
+//go:generate goenums -marshal-invalid error -yaml v2 -jsonv2 status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
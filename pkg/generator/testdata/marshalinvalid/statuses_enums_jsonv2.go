@@ -0,0 +1,32 @@
+//go:build goexperiment.jsonv2
+
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -marshal-invalid error -yaml v2 -jsonv2 testdata/marshalinvalid/status.go
+// source checksum: 7282967e184d24ec
+
+package marshalinvalid
+
+import "encoding/json/jsontext"
+
+func (p Status) MarshalJSONTo(enc *jsontext.Encoder) error {
+	name, err := p.marshalName(p.String())
+	if err != nil {
+		return err
+	}
+	return enc.WriteToken(jsontext.String(name))
+}
+
+func (p *Status) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return err
+	}
+	newp, err := ParseStatus(tok.String())
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
@@ -0,0 +1,15 @@
+package names
+
+// orderStatus is the state of an order.
+//
+//goenums:names=title
+type orderStatus int
+
+//go:generate goenums orderstatus.go
+const (
+	unknown orderStatus = iota // invalid
+	readyToShip
+	inTransit
+	delivered
+	returnedToSender // RTS
+)
@@ -0,0 +1,339 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums testdata/names/orderstatus.go
+// source checksum: 8cbced6c81d80958
+
+package names
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+type OrderStatus struct {
+	orderStatus
+}
+
+type orderstatusesContainer struct {
+	// UNKNOWN is "Unknown" with the value 0, marked invalid.
+	UNKNOWN OrderStatus
+	// READYTOSHIP is "Ready To Ship" with the value 1.
+	READYTOSHIP OrderStatus
+	// INTRANSIT is "In Transit" with the value 2.
+	INTRANSIT OrderStatus
+	// DELIVERED is "Delivered" with the value 3.
+	DELIVERED OrderStatus
+	// RETURNEDTOSENDER is "RTS" with the value 4.
+	RETURNEDTOSENDER OrderStatus
+}
+
+var OrderStatuses = orderstatusesContainer{
+	READYTOSHIP: OrderStatus{
+		orderStatus: readyToShip,
+	},
+	INTRANSIT: OrderStatus{
+		orderStatus: inTransit,
+	},
+	DELIVERED: OrderStatus{
+		orderStatus: delivered,
+	},
+	RETURNEDTOSENDER: OrderStatus{
+		orderStatus: returnedToSender,
+	},
+}
+
+func (c orderstatusesContainer) All() []OrderStatus {
+	return []OrderStatus{
+		c.READYTOSHIP,
+		c.INTRANSIT,
+		c.DELIVERED,
+		c.RETURNEDTOSENDER,
+	}
+}
+
+// AllWithInvalid returns every declared OrderStatus, including those marked invalid, in declaration order.
+func (c orderstatusesContainer) AllWithInvalid() []OrderStatus {
+	return []OrderStatus{
+		{orderStatus: unknown},
+		c.READYTOSHIP,
+		c.INTRANSIT,
+		c.DELIVERED,
+		c.RETURNEDTOSENDER,
+	}
+}
+
+// Count returns the number of valid OrderStatus values.
+func (c orderstatusesContainer) Count() int {
+	return 4
+}
+
+// First returns the first valid OrderStatus in declaration order.
+func (c orderstatusesContainer) First() OrderStatus {
+	return c.READYTOSHIP
+}
+
+// Last returns the last valid OrderStatus in declaration order.
+func (c orderstatusesContainer) Last() OrderStatus {
+	return c.RETURNEDTOSENDER
+}
+
+// Ordinal returns the position the OrderStatus is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p OrderStatus) Ordinal() int {
+	switch p.orderStatus {
+	case unknown:
+		return 0
+	case readyToShip:
+		return 1
+	case inTransit:
+		return 2
+	case delivered:
+		return 3
+	case returnedToSender:
+		return 4
+	}
+	return -1
+}
+
+// Underlying returns the orderStatus constant the OrderStatus wraps.
+func (p OrderStatus) Underlying() orderStatus {
+	return p.orderStatus
+}
+
+// Base returns the orderStatus constant the OrderStatus wraps, the inverse of AsOrderStatus.
+func (p OrderStatus) Base() orderStatus {
+	return p.orderStatus
+}
+
+// WrapOrderStatus returns the OrderStatus wrapping the orderStatus constant, or an error when it is not valid.
+func WrapOrderStatus(v orderStatus) (OrderStatus, error) {
+	if i := int(v); i >= 0 && i < len(OrderStatuses.AllWithInvalid()) {
+		if p := OrderStatuses.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidOrderStatus, fmt.Errorf("failed to wrap invalid OrderStatus: %d", v)
+}
+
+// AsOrderStatus returns the OrderStatus wrapping the orderStatus constant, or the invalid OrderStatus when it is not valid.
+func AsOrderStatus(v orderStatus) OrderStatus {
+	p, _ := WrapOrderStatus(v)
+	return p
+}
+
+// OrderStatusNames returns the names of the valid OrderStatus values in declaration order.
+func OrderStatusNames() []string {
+	return []string{"Ready To Ship", "In Transit", "Delivered", "RTS"}
+}
+
+// OrderStatusStrings returns every string ParseOrderStatus accepts for the valid values,
+// each name followed by its tag values and aliases.
+func OrderStatusStrings() []string {
+	return []string{"Ready To Ship", "In Transit", "Delivered", "RTS"}
+}
+
+var invalidOrderStatus = OrderStatus{}
+
+func ParseOrderStatus(a any) (OrderStatus, error) {
+	res := invalidOrderStatus
+	switch v := a.(type) {
+	case OrderStatus:
+		return v, nil
+	case []byte:
+		res = stringToOrderStatus(string(v))
+	case string:
+		res = stringToOrderStatus(v)
+	case fmt.Stringer:
+		res = stringToOrderStatus(v.String())
+	case int:
+		res = intToOrderStatus(v)
+	case int64:
+		res = intToOrderStatus(int(v))
+	case int32:
+		res = intToOrderStatus(int(v))
+	}
+	return res, nil
+}
+
+func stringToOrderStatus(s string) OrderStatus {
+	switch s {
+	case "Unknown":
+		return OrderStatuses.UNKNOWN
+	case "Ready To Ship":
+		return OrderStatuses.READYTOSHIP
+	case "In Transit":
+		return OrderStatuses.INTRANSIT
+	case "Delivered":
+		return OrderStatuses.DELIVERED
+	case "RTS":
+		return OrderStatuses.RETURNEDTOSENDER
+	}
+	return invalidOrderStatus
+}
+
+func intToOrderStatus(i int) OrderStatus {
+	if i < 0 || i >= len(OrderStatuses.All()) {
+		return invalidOrderStatus
+	}
+	return OrderStatuses.All()[i]
+}
+
+func ExhaustiveOrderStatuss(f func(OrderStatus)) {
+	for _, p := range OrderStatuses.All() {
+		f(p)
+	}
+}
+
+// ExhaustiveOrderStatussIncludingInvalid calls f with every declared OrderStatus, including those marked invalid.
+func ExhaustiveOrderStatussIncludingInvalid(f func(OrderStatus)) {
+	for _, p := range OrderStatuses.AllWithInvalid() {
+		f(p)
+	}
+}
+
+var validOrderStatuses = map[OrderStatus]bool{
+	OrderStatuses.READYTOSHIP:      true,
+	OrderStatuses.INTRANSIT:        true,
+	OrderStatuses.DELIVERED:        true,
+	OrderStatuses.RETURNEDTOSENDER: true,
+}
+
+func (p OrderStatus) IsValid() bool {
+	return validOrderStatuses[p]
+}
+
+// IsZero reports whether the OrderStatus is unset, meaning it holds the invalid value.
+func (p OrderStatus) IsZero() bool {
+	return p.orderStatus == invalidOrderStatus.orderStatus && !p.IsValid()
+}
+
+// IsSet reports whether the OrderStatus holds a value other than the invalid value.
+func (p OrderStatus) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the OrderStatus, for use in optional fields.
+func (p OrderStatus) Ptr() *OrderStatus {
+	return &p
+}
+
+// _orderstatuses_json holds the quoted JSON name of each declared OrderStatus.
+var _orderstatuses_json = [...]string{
+	`"Unknown"`,
+	`"Ready To Ship"`,
+	`"In Transit"`,
+	`"Delivered"`,
+	`"RTS"`,
+}
+
+// AppendJSON appends the JSON encoding of the OrderStatus written by MarshalJSON to dst.
+func (p OrderStatus) AppendJSON(dst []byte) []byte {
+	if i := int(p.orderStatus); i >= 0 && i < len(_orderstatuses_json) {
+		return append(dst, _orderstatuses_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
+func (p OrderStatus) MarshalJSON() ([]byte, error) {
+	return p.AppendJSON(nil), nil
+}
+
+func (p *OrderStatus) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(bytes.Trim(b, `"`), ` `)
+	newp, err := ParseOrderStatus(b)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p *OrderStatus) Scan(value any) error {
+	newp, err := ParseOrderStatus(value)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p OrderStatus) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// OrderStatusSQLValues is the comma separated list of valid OrderStatus values as stored by Value.
+const OrderStatusSQLValues = "'Ready To Ship', 'In Transit', 'Delivered', 'RTS'"
+
+// OrderStatusCheckConstraint returns a CHECK constraint restricting col to the valid OrderStatus values.
+func OrderStatusCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + OrderStatusSQLValues + "))"
+}
+
+// DisplayName returns the human readable name of the OrderStatus from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p OrderStatus) DisplayName() string {
+	return p.String()
+}
+
+func (p OrderStatus) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.orderStatus)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p OrderStatus) GoString() string {
+	switch p.orderStatus {
+	case readyToShip:
+		return "OrderStatuses.READYTOSHIP"
+	case inTransit:
+		return "OrderStatuses.INTRANSIT"
+	case delivered:
+		return "OrderStatuses.DELIVERED"
+	case returnedToSender:
+		return "OrderStatuses.RETURNEDTOSENDER"
+	}
+	return "OrderStatus{orderStatus: " + strconv.FormatInt(int64(p.orderStatus), 10) + "}"
+}
+
+// CacheKey returns a key for the OrderStatus namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p OrderStatus) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[unknown-0]
+	_ = x[readyToShip-1]
+	_ = x[inTransit-2]
+	_ = x[delivered-3]
+	_ = x[returnedToSender-4]
+}
+
+const _orderstatuses_name = "UnknownReady To ShipIn TransitDeliveredRTS"
+
+var _orderstatuses_index = [...]uint16{0, 7, 20, 30, 39, 42}
+
+func (i orderStatus) String() string {
+	if i < 0 || i >= orderStatus(len(_orderstatuses_index)-1) {
+		return "orderstatuses(" + (strconv.FormatInt(int64(i), 10) + ")")
+	}
+	return _orderstatuses_name[_orderstatuses_index[i]:_orderstatuses_index[i+1]]
+}
@@ -2,6 +2,7 @@
 // This file was generated by github.com/zarldev/goenums
 // using the command:
 // goenums testdata/orders/orders.go
+// source checksum: 23d73c6e169df8e7
 
 package orders
 
@@ -17,13 +18,20 @@ type Order struct {
 }
 
 type ordersContainer struct {
-	CREATED     Order
-	APPROVED    Order
-	PROCESSING  Order
+	// CREATED is "CREATED" with the value 0.
+	CREATED Order
+	// APPROVED is "APPROVED" with the value 1.
+	APPROVED Order
+	// PROCESSING is "PROCESSING" with the value 2.
+	PROCESSING Order
+	// READYTOSHIP is "READY_TO_SHIP" with the value 3.
 	READYTOSHIP Order
-	SHIPPED     Order
-	DELIVERED   Order
-	CANCELLED   Order
+	// SHIPPED is "SHIPPED" with the value 4.
+	SHIPPED Order
+	// DELIVERED is "DELIVERED" with the value 5.
+	DELIVERED Order
+	// CANCELLED is "CANCELLED" with the value 6.
+	CANCELLED Order
 }
 
 var Orders = ordersContainer{
@@ -62,6 +70,93 @@ func (c ordersContainer) All() []Order {
 	}
 }
 
+// AllWithInvalid returns every declared Order, including those marked invalid, in declaration order.
+func (c ordersContainer) AllWithInvalid() []Order {
+	return []Order{
+		c.CREATED,
+		c.APPROVED,
+		c.PROCESSING,
+		c.READYTOSHIP,
+		c.SHIPPED,
+		c.DELIVERED,
+		c.CANCELLED,
+	}
+}
+
+// Count returns the number of valid Order values.
+func (c ordersContainer) Count() int {
+	return 7
+}
+
+// First returns the first valid Order in declaration order.
+func (c ordersContainer) First() Order {
+	return c.CREATED
+}
+
+// Last returns the last valid Order in declaration order.
+func (c ordersContainer) Last() Order {
+	return c.CANCELLED
+}
+
+// Ordinal returns the position the Order is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p Order) Ordinal() int {
+	switch p.order {
+	case created:
+		return 0
+	case approved:
+		return 1
+	case processing:
+		return 2
+	case readyToShip:
+		return 3
+	case shipped:
+		return 4
+	case delivered:
+		return 5
+	case cancelled:
+		return 6
+	}
+	return -1
+}
+
+// Underlying returns the order constant the Order wraps.
+func (p Order) Underlying() order {
+	return p.order
+}
+
+// Base returns the order constant the Order wraps, the inverse of AsOrder.
+func (p Order) Base() order {
+	return p.order
+}
+
+// WrapOrder returns the Order wrapping the order constant, or an error when it is not valid.
+func WrapOrder(v order) (Order, error) {
+	if i := int(v); i >= 0 && i < len(Orders.AllWithInvalid()) {
+		if p := Orders.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidOrder, fmt.Errorf("failed to wrap invalid Order: %d", v)
+}
+
+// AsOrder returns the Order wrapping the order constant, or the invalid Order when it is not valid.
+func AsOrder(v order) Order {
+	p, _ := WrapOrder(v)
+	return p
+}
+
+// OrderNames returns the names of the valid Order values in declaration order.
+func OrderNames() []string {
+	return []string{"CREATED", "APPROVED", "PROCESSING", "READY_TO_SHIP", "SHIPPED", "DELIVERED", "CANCELLED"}
+}
+
+// OrderStrings returns every string ParseOrder accepts for the valid values,
+// each name followed by its tag values and aliases.
+func OrderStrings() []string {
+	return []string{"CREATED", "APPROVED", "PROCESSING", "READY_TO_SHIP", "SHIPPED", "DELIVERED", "CANCELLED"}
+}
+
 var invalidOrder = Order{}
 
 func ParseOrder(a any) (Order, error) {
@@ -118,6 +213,13 @@ func ExhaustiveOrders(f func(Order)) {
 	}
 }
 
+// ExhaustiveOrdersIncludingInvalid calls f with every declared Order, including those marked invalid.
+func ExhaustiveOrdersIncludingInvalid(f func(Order)) {
+	for _, p := range Orders.AllWithInvalid() {
+		f(p)
+	}
+}
+
 var validOrders = map[Order]bool{
 	Orders.CREATED:     true,
 	Orders.APPROVED:    true,
@@ -132,8 +234,42 @@ func (p Order) IsValid() bool {
 	return validOrders[p]
 }
 
+// IsZero reports whether the Order is unset, meaning it holds the invalid value.
+func (p Order) IsZero() bool {
+	return p.order == invalidOrder.order && !p.IsValid()
+}
+
+// IsSet reports whether the Order holds a value other than the invalid value.
+func (p Order) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the Order, for use in optional fields.
+func (p Order) Ptr() *Order {
+	return &p
+}
+
+// _orders_json holds the quoted JSON name of each declared Order.
+var _orders_json = [...]string{
+	`"CREATED"`,
+	`"APPROVED"`,
+	`"PROCESSING"`,
+	`"READY_TO_SHIP"`,
+	`"SHIPPED"`,
+	`"DELIVERED"`,
+	`"CANCELLED"`,
+}
+
+// AppendJSON appends the JSON encoding of the Order written by MarshalJSON to dst.
+func (p Order) AppendJSON(dst []byte) []byte {
+	if i := int(p.order); i >= 0 && i < len(_orders_json) {
+		return append(dst, _orders_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
 func (p Order) MarshalJSON() ([]byte, error) {
-	return []byte(`"` + p.String() + `"`), nil
+	return p.AppendJSON(nil), nil
 }
 
 func (p *Order) UnmarshalJSON(b []byte) error {
@@ -159,6 +295,63 @@ func (p Order) Value() (driver.Value, error) {
 	return p.String(), nil
 }
 
+// OrderSQLValues is the comma separated list of valid Order values as stored by Value.
+const OrderSQLValues = "'CREATED', 'APPROVED', 'PROCESSING', 'READY_TO_SHIP', 'SHIPPED', 'DELIVERED', 'CANCELLED'"
+
+// OrderCheckConstraint returns a CHECK constraint restricting col to the valid Order values.
+func OrderCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + OrderSQLValues + "))"
+}
+
+// DisplayName returns the human readable name of the Order from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p Order) DisplayName() string {
+	return p.String()
+}
+
+func (p Order) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.order)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p Order) GoString() string {
+	switch p.order {
+	case created:
+		return "Orders.CREATED"
+	case approved:
+		return "Orders.APPROVED"
+	case processing:
+		return "Orders.PROCESSING"
+	case readyToShip:
+		return "Orders.READYTOSHIP"
+	case shipped:
+		return "Orders.SHIPPED"
+	case delivered:
+		return "Orders.DELIVERED"
+	case cancelled:
+		return "Orders.CANCELLED"
+	}
+	return "Order{order: " + strconv.FormatInt(int64(p.order), 10) + "}"
+}
+
+// CacheKey returns a key for the Order namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p Order) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
 func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the goenums command to generate them again.
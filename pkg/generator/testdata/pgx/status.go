@@ -0,0 +1,14 @@
+package pgx
+
+type status int
+
+//go:generate goenums -pgx status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
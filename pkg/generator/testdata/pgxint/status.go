@@ -0,0 +1,14 @@
+package pgxint
+
+type status int
+
+//go:generate goenums -sqlint -pgx status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
@@ -0,0 +1,14 @@
+package pinned
+
+type status int
+
+//go:generate goenums -f -sqlint status.go
+
+//goenums:pinned
+const (
+	unknown  status = 1 // invalid
+	active   status = 2
+	archived status = 7
+	paused   status = 3
+	deleted  status = 5
+)
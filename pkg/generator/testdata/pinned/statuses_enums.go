@@ -0,0 +1,357 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -f -sqlint testdata/pinned/status.go
+// source checksum: e0e6b49d2056c232
+
+package pinned
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+type Status struct {
+	status
+}
+
+type statusesContainer struct {
+	// UNKNOWN is "unknown" with the value 1, marked invalid.
+	UNKNOWN Status
+	// ACTIVE is "active" with the value 2.
+	ACTIVE Status
+	// PAUSED is "paused" with the value 3.
+	PAUSED Status
+	// DELETED is "deleted" with the value 5.
+	DELETED Status
+	// ARCHIVED is "archived" with the value 7.
+	ARCHIVED Status
+}
+
+var Statuses = statusesContainer{
+	ACTIVE: Status{
+		status: active,
+	},
+	PAUSED: Status{
+		status: paused,
+	},
+	DELETED: Status{
+		status: deleted,
+	},
+	ARCHIVED: Status{
+		status: archived,
+	},
+}
+
+func (c statusesContainer) All() []Status {
+	return []Status{
+		c.ACTIVE,
+		c.PAUSED,
+		c.DELETED,
+		c.ARCHIVED,
+	}
+}
+
+// AllWithInvalid returns every declared Status, including those marked invalid, in declaration order.
+func (c statusesContainer) AllWithInvalid() []Status {
+	return []Status{
+		{status: unknown},
+		c.ACTIVE,
+		c.PAUSED,
+		c.DELETED,
+		c.ARCHIVED,
+	}
+}
+
+// Count returns the number of valid Status values.
+func (c statusesContainer) Count() int {
+	return 4
+}
+
+// First returns the first valid Status in declaration order.
+func (c statusesContainer) First() Status {
+	return c.ACTIVE
+}
+
+// Last returns the last valid Status in declaration order.
+func (c statusesContainer) Last() Status {
+	return c.ARCHIVED
+}
+
+// Ordinal returns the position the Status is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p Status) Ordinal() int {
+	switch p.status {
+	case unknown:
+		return 0
+	case active:
+		return 1
+	case paused:
+		return 2
+	case deleted:
+		return 3
+	case archived:
+		return 4
+	}
+	return -1
+}
+
+// Underlying returns the status constant the Status wraps.
+func (p Status) Underlying() status {
+	return p.status
+}
+
+// Base returns the status constant the Status wraps, the inverse of AsStatus.
+func (p Status) Base() status {
+	return p.status
+}
+
+// WrapStatus returns the Status wrapping the status constant, or an error when it is not valid.
+func WrapStatus(v status) (Status, error) {
+	p := intToStatus(int(v))
+	if p.status != v || !p.IsValid() {
+		return invalidStatus, fmt.Errorf("failed to wrap invalid Status: %d", v)
+	}
+	return p, nil
+}
+
+// AsStatus returns the Status wrapping the status constant, or the invalid Status when it is not valid.
+func AsStatus(v status) Status {
+	return intToStatus(int(v))
+}
+
+// StatusNames returns the names of the valid Status values in declaration order.
+func StatusNames() []string {
+	return []string{"active", "paused", "deleted", "archived"}
+}
+
+// StatusStrings returns every string ParseStatus accepts for the valid values,
+// each name followed by its tag values and aliases.
+func StatusStrings() []string {
+	return []string{"active", "paused", "deleted", "archived"}
+}
+
+var invalidStatus = Status{}
+
+func ParseStatus(a any) (Status, error) {
+	res := invalidStatus
+	switch v := a.(type) {
+	case Status:
+		return v, nil
+	case []byte:
+		res = stringToStatus(string(v))
+	case string:
+		res = stringToStatus(v)
+	case fmt.Stringer:
+		res = stringToStatus(v.String())
+	case int:
+		res = intToStatus(v)
+	case int64:
+		res = intToStatus(int(v))
+	case int32:
+		res = intToStatus(int(v))
+	}
+	if res == invalidStatus {
+		return res, fmt.Errorf("failed to parse invalid Status: %v", a)
+	}
+	return res, nil
+}
+
+func stringToStatus(s string) Status {
+	switch s {
+	case "unknown":
+		return Statuses.UNKNOWN
+	case "active":
+		return Statuses.ACTIVE
+	case "paused":
+		return Statuses.PAUSED
+	case "deleted":
+		return Statuses.DELETED
+	case "archived":
+		return Statuses.ARCHIVED
+	}
+	return invalidStatus
+}
+
+func intToStatus(i int) Status {
+	switch i {
+	case int(active):
+		return Statuses.ACTIVE
+	case int(paused):
+		return Statuses.PAUSED
+	case int(deleted):
+		return Statuses.DELETED
+	case int(archived):
+		return Statuses.ARCHIVED
+	}
+	return invalidStatus
+}
+
+func ExhaustiveStatuss(f func(Status)) {
+	for _, p := range Statuses.All() {
+		f(p)
+	}
+}
+
+// ExhaustiveStatussIncludingInvalid calls f with every declared Status, including those marked invalid.
+func ExhaustiveStatussIncludingInvalid(f func(Status)) {
+	for _, p := range Statuses.AllWithInvalid() {
+		f(p)
+	}
+}
+
+var validStatuses = map[Status]bool{
+	Statuses.ACTIVE:   true,
+	Statuses.PAUSED:   true,
+	Statuses.DELETED:  true,
+	Statuses.ARCHIVED: true,
+}
+
+func (p Status) IsValid() bool {
+	return validStatuses[p]
+}
+
+// IsZero reports whether the Status is unset, meaning it holds the invalid value.
+func (p Status) IsZero() bool {
+	return p.status == invalidStatus.status && !p.IsValid()
+}
+
+// IsSet reports whether the Status holds a value other than the invalid value.
+func (p Status) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the Status, for use in optional fields.
+func (p Status) Ptr() *Status {
+	return &p
+}
+
+// _statuses_json holds the quoted JSON name of each declared Status.
+var _statuses_json = [...]string{
+	`"unknown"`,
+	`"active"`,
+	`"paused"`,
+	"",
+	`"deleted"`,
+	"",
+	`"archived"`,
+}
+
+// AppendJSON appends the JSON encoding of the Status written by MarshalJSON to dst.
+func (p Status) AppendJSON(dst []byte) []byte {
+	if i := int(p.status) - 1; i >= 0 && i < len(_statuses_json) && _statuses_json[i] != "" {
+		return append(dst, _statuses_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
+func (p Status) MarshalJSON() ([]byte, error) {
+	return p.AppendJSON(nil), nil
+}
+
+func (p *Status) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(bytes.Trim(b, `"`), ` `)
+	newp, err := ParseStatus(b)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p *Status) Scan(value any) error {
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+	if s, ok := value.(string); ok {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			value = i
+		}
+	}
+	newp, err := ParseStatus(value)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p Status) Value() (driver.Value, error) {
+	return int64(p.status), nil
+}
+
+// StatusSQLValues is the comma separated list of valid Status values as stored by Value.
+const StatusSQLValues = "2, 3, 5, 7"
+
+// StatusCheckConstraint returns a CHECK constraint restricting col to the valid Status values.
+func StatusCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + StatusSQLValues + "))"
+}
+
+// DisplayName returns the human readable name of the Status from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p Status) DisplayName() string {
+	return p.String()
+}
+
+func (p Status) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.status)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p Status) GoString() string {
+	switch p.status {
+	case active:
+		return "Statuses.ACTIVE"
+	case paused:
+		return "Statuses.PAUSED"
+	case deleted:
+		return "Statuses.DELETED"
+	case archived:
+		return "Statuses.ARCHIVED"
+	}
+	return "Status{status: " + strconv.FormatInt(int64(p.status), 10) + "}"
+}
+
+// CacheKey returns a key for the Status namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p Status) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[unknown-1]
+	_ = x[active-2]
+	_ = x[paused-3]
+	_ = x[deleted-5]
+	_ = x[archived-7]
+}
+
+const _statuses_name = "unknownactivepauseddeletedarchived"
+
+var _statuses_index = [...]uint16{0, 0, 7, 13, 19, 19, 26, 26, 34}
+
+func (i status) String() string {
+	if i < 0 || i >= status(len(_statuses_index)-1) || _statuses_index[i] == _statuses_index[i+1] {
+		return "statuses(" + (strconv.FormatInt(int64(i), 10) + ")")
+	}
+	return _statuses_name[_statuses_index[i]:_statuses_index[i+1]]
+}
@@ -2,6 +2,7 @@
 // This file was generated by github.com/zarldev/goenums
 // using the command:
 // goenums testdata/planets_gravity_only/planets.go
+// source checksum: c1c10cb90a9928e1
 
 package planets_gravity_only
 
@@ -18,13 +19,21 @@ type Planet struct {
 }
 
 type planetsContainer struct {
+	// MERCURY is "mercury" with the value 0.
 	MERCURY Planet
-	VENUS   Planet
-	EARTH   Planet
-	MARS    Planet
+	// VENUS is "venus" with the value 1.
+	VENUS Planet
+	// EARTH is "earth" with the value 2.
+	EARTH Planet
+	// MARS is "mars" with the value 3.
+	MARS Planet
+	// JUPITER is "jupiter" with the value 4.
 	JUPITER Planet
-	SATURN  Planet
-	URANUS  Planet
+	// SATURN is "saturn" with the value 5.
+	SATURN Planet
+	// URANUS is "uranus" with the value 6.
+	URANUS Planet
+	// NEPTUNE is "neptune" with the value 7.
 	NEPTUNE Planet
 }
 
@@ -76,6 +85,96 @@ func (c planetsContainer) All() []Planet {
 	}
 }
 
+// AllWithInvalid returns every declared Planet, including those marked invalid, in declaration order.
+func (c planetsContainer) AllWithInvalid() []Planet {
+	return []Planet{
+		c.MERCURY,
+		c.VENUS,
+		c.EARTH,
+		c.MARS,
+		c.JUPITER,
+		c.SATURN,
+		c.URANUS,
+		c.NEPTUNE,
+	}
+}
+
+// Count returns the number of valid Planet values.
+func (c planetsContainer) Count() int {
+	return 8
+}
+
+// First returns the first valid Planet in declaration order.
+func (c planetsContainer) First() Planet {
+	return c.MERCURY
+}
+
+// Last returns the last valid Planet in declaration order.
+func (c planetsContainer) Last() Planet {
+	return c.NEPTUNE
+}
+
+// Ordinal returns the position the Planet is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p Planet) Ordinal() int {
+	switch p.planet {
+	case mercury:
+		return 0
+	case venus:
+		return 1
+	case earth:
+		return 2
+	case mars:
+		return 3
+	case jupiter:
+		return 4
+	case saturn:
+		return 5
+	case uranus:
+		return 6
+	case neptune:
+		return 7
+	}
+	return -1
+}
+
+// Underlying returns the planet constant the Planet wraps.
+func (p Planet) Underlying() planet {
+	return p.planet
+}
+
+// Base returns the planet constant the Planet wraps, the inverse of AsPlanet.
+func (p Planet) Base() planet {
+	return p.planet
+}
+
+// WrapPlanet returns the Planet wrapping the planet constant, or an error when it is not valid.
+func WrapPlanet(v planet) (Planet, error) {
+	if i := int(v); i >= 0 && i < len(Planets.AllWithInvalid()) {
+		if p := Planets.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidPlanet, fmt.Errorf("failed to wrap invalid Planet: %d", v)
+}
+
+// AsPlanet returns the Planet wrapping the planet constant, or the invalid Planet when it is not valid.
+func AsPlanet(v planet) Planet {
+	p, _ := WrapPlanet(v)
+	return p
+}
+
+// PlanetNames returns the names of the valid Planet values in declaration order.
+func PlanetNames() []string {
+	return []string{"mercury", "venus", "earth", "mars", "jupiter", "saturn", "uranus", "neptune"}
+}
+
+// PlanetStrings returns every string ParsePlanet accepts for the valid values,
+// each name followed by its tag values and aliases.
+func PlanetStrings() []string {
+	return []string{"mercury", "venus", "earth", "mars", "jupiter", "saturn", "uranus", "neptune"}
+}
+
 var invalidPlanet = Planet{}
 
 func ParsePlanet(a any) (Planet, error) {
@@ -134,6 +233,13 @@ func ExhaustivePlanets(f func(Planet)) {
 	}
 }
 
+// ExhaustivePlanetsIncludingInvalid calls f with every declared Planet, including those marked invalid.
+func ExhaustivePlanetsIncludingInvalid(f func(Planet)) {
+	for _, p := range Planets.AllWithInvalid() {
+		f(p)
+	}
+}
+
 var validPlanets = map[Planet]bool{
 	Planets.MERCURY: true,
 	Planets.VENUS:   true,
@@ -149,8 +255,43 @@ func (p Planet) IsValid() bool {
 	return validPlanets[p]
 }
 
+// IsZero reports whether the Planet is unset, meaning it holds the invalid value.
+func (p Planet) IsZero() bool {
+	return p.planet == invalidPlanet.planet && !p.IsValid()
+}
+
+// IsSet reports whether the Planet holds a value other than the invalid value.
+func (p Planet) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the Planet, for use in optional fields.
+func (p Planet) Ptr() *Planet {
+	return &p
+}
+
+// _planets_json holds the quoted JSON name of each declared Planet.
+var _planets_json = [...]string{
+	`"mercury"`,
+	`"venus"`,
+	`"earth"`,
+	`"mars"`,
+	`"jupiter"`,
+	`"saturn"`,
+	`"uranus"`,
+	`"neptune"`,
+}
+
+// AppendJSON appends the JSON encoding of the Planet written by MarshalJSON to dst.
+func (p Planet) AppendJSON(dst []byte) []byte {
+	if i := int(p.planet); i >= 0 && i < len(_planets_json) {
+		return append(dst, _planets_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
 func (p Planet) MarshalJSON() ([]byte, error) {
-	return []byte(`"` + p.String() + `"`), nil
+	return p.AppendJSON(nil), nil
 }
 
 func (p *Planet) UnmarshalJSON(b []byte) error {
@@ -176,6 +317,69 @@ func (p Planet) Value() (driver.Value, error) {
 	return p.String(), nil
 }
 
+// PlanetSQLValues is the comma separated list of valid Planet values as stored by Value.
+const PlanetSQLValues = "'mercury', 'venus', 'earth', 'mars', 'jupiter', 'saturn', 'uranus', 'neptune'"
+
+// PlanetCheckConstraint returns a CHECK constraint restricting col to the valid Planet values.
+func PlanetCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + PlanetSQLValues + "))"
+}
+
+// DisplayName returns the human readable name of the Planet from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p Planet) DisplayName() string {
+	return p.String()
+}
+
+func (p Planet) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.planet)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		if f.Flag('+') {
+			fmt.Fprintf(f, "%s{Gravity: %v}", p.String(), p.Gravity)
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p Planet) GoString() string {
+	switch p.planet {
+	case mercury:
+		return "Planets.MERCURY"
+	case venus:
+		return "Planets.VENUS"
+	case earth:
+		return "Planets.EARTH"
+	case mars:
+		return "Planets.MARS"
+	case jupiter:
+		return "Planets.JUPITER"
+	case saturn:
+		return "Planets.SATURN"
+	case uranus:
+		return "Planets.URANUS"
+	case neptune:
+		return "Planets.NEPTUNE"
+	}
+	return "Planet{planet: " + strconv.FormatInt(int64(p.planet), 10) + "}"
+}
+
+// CacheKey returns a key for the Planet namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p Planet) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
 func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the goenums command to generate them again.
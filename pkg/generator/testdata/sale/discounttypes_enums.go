@@ -2,6 +2,7 @@
 // This file was generated by github.com/zarldev/goenums
 // using the command:
 // goenums -f testdata/sale/discount.go
+// source checksum: fa031e24b6b05b51
 
 package sale
 
@@ -23,10 +24,14 @@ type DiscountType struct {
 }
 
 type discounttypesContainer struct {
-	SALE       DiscountType
+	// SALE is "sale" with the value 1.
+	SALE DiscountType
+	// PERCENTAGE is "percentage" with the value 2.
 	PERCENTAGE DiscountType
-	AMOUNT     DiscountType
-	GIVEAWAY   DiscountType
+	// AMOUNT is "amount" with the value 3.
+	AMOUNT DiscountType
+	// GIVEAWAY is "giveaway" with the value 4.
+	GIVEAWAY DiscountType
 }
 
 var DiscountTypes = discounttypesContainer{
@@ -73,6 +78,84 @@ func (c discounttypesContainer) All() []DiscountType {
 	}
 }
 
+// AllWithInvalid returns every declared DiscountType, including those marked invalid, in declaration order.
+func (c discounttypesContainer) AllWithInvalid() []DiscountType {
+	return []DiscountType{
+		c.SALE,
+		c.PERCENTAGE,
+		c.AMOUNT,
+		c.GIVEAWAY,
+	}
+}
+
+// Count returns the number of valid DiscountType values.
+func (c discounttypesContainer) Count() int {
+	return 4
+}
+
+// First returns the first valid DiscountType in declaration order.
+func (c discounttypesContainer) First() DiscountType {
+	return c.SALE
+}
+
+// Last returns the last valid DiscountType in declaration order.
+func (c discounttypesContainer) Last() DiscountType {
+	return c.GIVEAWAY
+}
+
+// Ordinal returns the position the DiscountType is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p DiscountType) Ordinal() int {
+	switch p.discountType {
+	case sale:
+		return 0
+	case percentage:
+		return 1
+	case amount:
+		return 2
+	case giveaway:
+		return 3
+	}
+	return -1
+}
+
+// Underlying returns the discountType constant the DiscountType wraps.
+func (p DiscountType) Underlying() discountType {
+	return p.discountType
+}
+
+// Base returns the discountType constant the DiscountType wraps, the inverse of AsDiscountType.
+func (p DiscountType) Base() discountType {
+	return p.discountType
+}
+
+// WrapDiscountType returns the DiscountType wrapping the discountType constant, or an error when it is not valid.
+func WrapDiscountType(v discountType) (DiscountType, error) {
+	if i := int(v) - 1; i >= 0 && i < len(DiscountTypes.AllWithInvalid()) {
+		if p := DiscountTypes.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidDiscountType, fmt.Errorf("failed to wrap invalid DiscountType: %d", v)
+}
+
+// AsDiscountType returns the DiscountType wrapping the discountType constant, or the invalid DiscountType when it is not valid.
+func AsDiscountType(v discountType) DiscountType {
+	p, _ := WrapDiscountType(v)
+	return p
+}
+
+// DiscountTypeNames returns the names of the valid DiscountType values in declaration order.
+func DiscountTypeNames() []string {
+	return []string{"sale", "percentage", "amount", "giveaway"}
+}
+
+// DiscountTypeStrings returns every string ParseDiscountType accepts for the valid values,
+// each name followed by its tag values and aliases.
+func DiscountTypeStrings() []string {
+	return []string{"sale", "percentage", "amount", "giveaway"}
+}
+
 var invalidDiscountType = DiscountType{}
 
 func ParseDiscountType(a any) (DiscountType, error) {
@@ -127,6 +210,13 @@ func ExhaustiveDiscountTypes(f func(DiscountType)) {
 	}
 }
 
+// ExhaustiveDiscountTypesIncludingInvalid calls f with every declared DiscountType, including those marked invalid.
+func ExhaustiveDiscountTypesIncludingInvalid(f func(DiscountType)) {
+	for _, p := range DiscountTypes.AllWithInvalid() {
+		f(p)
+	}
+}
+
 var validDiscountTypes = map[DiscountType]bool{
 	DiscountTypes.SALE:       true,
 	DiscountTypes.PERCENTAGE: true,
@@ -138,8 +228,39 @@ func (p DiscountType) IsValid() bool {
 	return validDiscountTypes[p]
 }
 
+// IsZero reports whether the DiscountType is unset, meaning it holds the invalid value.
+func (p DiscountType) IsZero() bool {
+	return p.discountType == invalidDiscountType.discountType && !p.IsValid()
+}
+
+// IsSet reports whether the DiscountType holds a value other than the invalid value.
+func (p DiscountType) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the DiscountType, for use in optional fields.
+func (p DiscountType) Ptr() *DiscountType {
+	return &p
+}
+
+// _discounttypes_json holds the quoted JSON name of each declared DiscountType.
+var _discounttypes_json = [...]string{
+	`"sale"`,
+	`"percentage"`,
+	`"amount"`,
+	`"giveaway"`,
+}
+
+// AppendJSON appends the JSON encoding of the DiscountType written by MarshalJSON to dst.
+func (p DiscountType) AppendJSON(dst []byte) []byte {
+	if i := int(p.discountType) - 1; i >= 0 && i < len(_discounttypes_json) {
+		return append(dst, _discounttypes_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
 func (p DiscountType) MarshalJSON() ([]byte, error) {
-	return []byte(`"` + p.String() + `"`), nil
+	return p.AppendJSON(nil), nil
 }
 
 func (p *DiscountType) UnmarshalJSON(b []byte) error {
@@ -165,6 +286,61 @@ func (p DiscountType) Value() (driver.Value, error) {
 	return p.String(), nil
 }
 
+// DiscountTypeSQLValues is the comma separated list of valid DiscountType values as stored by Value.
+const DiscountTypeSQLValues = "'sale', 'percentage', 'amount', 'giveaway'"
+
+// DiscountTypeCheckConstraint returns a CHECK constraint restricting col to the valid DiscountType values.
+func DiscountTypeCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + DiscountTypeSQLValues + "))"
+}
+
+// DisplayName returns the human readable name of the DiscountType from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p DiscountType) DisplayName() string {
+	return p.String()
+}
+
+func (p DiscountType) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.discountType)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		if f.Flag('+') {
+			fmt.Fprintf(f, "%s{Available: %v, Started: %v, Finished: %v, Cancelled: %v, Duration: %v}", p.String(), p.Available, p.Started, p.Finished, p.Cancelled, p.Duration)
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p DiscountType) GoString() string {
+	switch p.discountType {
+	case sale:
+		return "DiscountTypes.SALE"
+	case percentage:
+		return "DiscountTypes.PERCENTAGE"
+	case amount:
+		return "DiscountTypes.AMOUNT"
+	case giveaway:
+		return "DiscountTypes.GIVEAWAY"
+	}
+	return "DiscountType{discountType: " + strconv.FormatInt(int64(p.discountType), 10) + "}"
+}
+
+// CacheKey returns a key for the DiscountType namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p DiscountType) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
 func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the goenums command to generate them again.
@@ -0,0 +1,13 @@
+package sentinel
+
+type status int
+
+//go:generate goenums status.go
+const (
+	active status = iota // Active
+	// inactive is a real status even though its name mentions invalid.
+	inactive // Invalidated
+	//goenums:invalid
+	unknown // Unknown
+	pending // Pending
+)
@@ -0,0 +1,15 @@
+package shared
+
+type order int
+
+//go:generate goenums -shared orders.go
+
+const (
+	created     order = iota // CREATED
+	approved                 // APPROVED
+	processing               // PROCESSING
+	readyToShip              // READY_TO_SHIP
+	shipped                  // SHIPPED
+	delivered                // DELIVERED
+	cancelled                // CANCELLED
+)
@@ -0,0 +1,376 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -shared testdata/shared/orders.go
+// source checksum: 23d73c6e169df8e7
+
+package shared
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+type Order struct {
+	order
+}
+
+type ordersContainer struct {
+	// CREATED is "CREATED" with the value 0.
+	CREATED Order
+	// APPROVED is "APPROVED" with the value 1.
+	APPROVED Order
+	// PROCESSING is "PROCESSING" with the value 2.
+	PROCESSING Order
+	// READYTOSHIP is "READY_TO_SHIP" with the value 3.
+	READYTOSHIP Order
+	// SHIPPED is "SHIPPED" with the value 4.
+	SHIPPED Order
+	// DELIVERED is "DELIVERED" with the value 5.
+	DELIVERED Order
+	// CANCELLED is "CANCELLED" with the value 6.
+	CANCELLED Order
+}
+
+var Orders = ordersContainer{
+	CREATED: Order{
+		order: created,
+	},
+	APPROVED: Order{
+		order: approved,
+	},
+	PROCESSING: Order{
+		order: processing,
+	},
+	READYTOSHIP: Order{
+		order: readyToShip,
+	},
+	SHIPPED: Order{
+		order: shipped,
+	},
+	DELIVERED: Order{
+		order: delivered,
+	},
+	CANCELLED: Order{
+		order: cancelled,
+	},
+}
+
+func (c ordersContainer) All() []Order {
+	return []Order{
+		c.CREATED,
+		c.APPROVED,
+		c.PROCESSING,
+		c.READYTOSHIP,
+		c.SHIPPED,
+		c.DELIVERED,
+		c.CANCELLED,
+	}
+}
+
+// AllWithInvalid returns every declared Order, including those marked invalid, in declaration order.
+func (c ordersContainer) AllWithInvalid() []Order {
+	return []Order{
+		c.CREATED,
+		c.APPROVED,
+		c.PROCESSING,
+		c.READYTOSHIP,
+		c.SHIPPED,
+		c.DELIVERED,
+		c.CANCELLED,
+	}
+}
+
+// Count returns the number of valid Order values.
+func (c ordersContainer) Count() int {
+	return 7
+}
+
+// First returns the first valid Order in declaration order.
+func (c ordersContainer) First() Order {
+	return c.CREATED
+}
+
+// Last returns the last valid Order in declaration order.
+func (c ordersContainer) Last() Order {
+	return c.CANCELLED
+}
+
+// Ordinal returns the position the Order is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p Order) Ordinal() int {
+	switch p.order {
+	case created:
+		return 0
+	case approved:
+		return 1
+	case processing:
+		return 2
+	case readyToShip:
+		return 3
+	case shipped:
+		return 4
+	case delivered:
+		return 5
+	case cancelled:
+		return 6
+	}
+	return -1
+}
+
+// Underlying returns the order constant the Order wraps.
+func (p Order) Underlying() order {
+	return p.order
+}
+
+// Base returns the order constant the Order wraps, the inverse of AsOrder.
+func (p Order) Base() order {
+	return p.order
+}
+
+// WrapOrder returns the Order wrapping the order constant, or an error when it is not valid.
+func WrapOrder(v order) (Order, error) {
+	if i := int(v); i >= 0 && i < len(Orders.AllWithInvalid()) {
+		if p := Orders.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidOrder, fmt.Errorf("failed to wrap invalid Order: %d", v)
+}
+
+// AsOrder returns the Order wrapping the order constant, or the invalid Order when it is not valid.
+func AsOrder(v order) Order {
+	p, _ := WrapOrder(v)
+	return p
+}
+
+// OrderNames returns the names of the valid Order values in declaration order.
+func OrderNames() []string {
+	return []string{"CREATED", "APPROVED", "PROCESSING", "READY_TO_SHIP", "SHIPPED", "DELIVERED", "CANCELLED"}
+}
+
+// OrderStrings returns every string ParseOrder accepts for the valid values,
+// each name followed by its tag values and aliases.
+func OrderStrings() []string {
+	return []string{"CREATED", "APPROVED", "PROCESSING", "READY_TO_SHIP", "SHIPPED", "DELIVERED", "CANCELLED"}
+}
+
+var invalidOrder = Order{}
+
+func ParseOrder(a any) (Order, error) {
+	res := invalidOrder
+	switch v := a.(type) {
+	case Order:
+		return v, nil
+	case []byte:
+		res = stringToOrder(string(v))
+	case string:
+		res = stringToOrder(v)
+	case fmt.Stringer:
+		res = stringToOrder(v.String())
+	default:
+		if i, ok := enumsInt(v); ok {
+			res = intToOrder(i)
+		}
+	}
+	return res, nil
+}
+
+func stringToOrder(s string) Order {
+	switch s {
+	case "CREATED":
+		return Orders.CREATED
+	case "APPROVED":
+		return Orders.APPROVED
+	case "PROCESSING":
+		return Orders.PROCESSING
+	case "READY_TO_SHIP":
+		return Orders.READYTOSHIP
+	case "SHIPPED":
+		return Orders.SHIPPED
+	case "DELIVERED":
+		return Orders.DELIVERED
+	case "CANCELLED":
+		return Orders.CANCELLED
+	}
+	return invalidOrder
+}
+
+func intToOrder(i int) Order {
+	if i < 0 || i >= len(Orders.All()) {
+		return invalidOrder
+	}
+	return Orders.All()[i]
+}
+
+func ExhaustiveOrders(f func(Order)) {
+	for _, p := range Orders.All() {
+		f(p)
+	}
+}
+
+// ExhaustiveOrdersIncludingInvalid calls f with every declared Order, including those marked invalid.
+func ExhaustiveOrdersIncludingInvalid(f func(Order)) {
+	for _, p := range Orders.AllWithInvalid() {
+		f(p)
+	}
+}
+
+var validOrders = map[Order]bool{
+	Orders.CREATED:     true,
+	Orders.APPROVED:    true,
+	Orders.PROCESSING:  true,
+	Orders.READYTOSHIP: true,
+	Orders.SHIPPED:     true,
+	Orders.DELIVERED:   true,
+	Orders.CANCELLED:   true,
+}
+
+func (p Order) IsValid() bool {
+	return validOrders[p]
+}
+
+// IsZero reports whether the Order is unset, meaning it holds the invalid value.
+func (p Order) IsZero() bool {
+	return p.order == invalidOrder.order && !p.IsValid()
+}
+
+// IsSet reports whether the Order holds a value other than the invalid value.
+func (p Order) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the Order, for use in optional fields.
+func (p Order) Ptr() *Order {
+	return &p
+}
+
+// _orders_json holds the quoted JSON name of each declared Order.
+var _orders_json = [...]string{
+	`"CREATED"`,
+	`"APPROVED"`,
+	`"PROCESSING"`,
+	`"READY_TO_SHIP"`,
+	`"SHIPPED"`,
+	`"DELIVERED"`,
+	`"CANCELLED"`,
+}
+
+// AppendJSON appends the JSON encoding of the Order written by MarshalJSON to dst.
+func (p Order) AppendJSON(dst []byte) []byte {
+	if i := int(p.order); i >= 0 && i < len(_orders_json) {
+		return append(dst, _orders_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
+func (p Order) MarshalJSON() ([]byte, error) {
+	return p.AppendJSON(nil), nil
+}
+
+func (p *Order) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(bytes.Trim(b, `"`), ` `)
+	newp, err := ParseOrder(b)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p *Order) Scan(value any) error {
+	newp, err := ParseOrder(value)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p Order) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// OrderSQLValues is the comma separated list of valid Order values as stored by Value.
+const OrderSQLValues = "'CREATED', 'APPROVED', 'PROCESSING', 'READY_TO_SHIP', 'SHIPPED', 'DELIVERED', 'CANCELLED'"
+
+// OrderCheckConstraint returns a CHECK constraint restricting col to the valid Order values.
+func OrderCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + OrderSQLValues + "))"
+}
+
+// DisplayName returns the human readable name of the Order from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p Order) DisplayName() string {
+	return p.String()
+}
+
+func (p Order) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.order)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p Order) GoString() string {
+	switch p.order {
+	case created:
+		return "Orders.CREATED"
+	case approved:
+		return "Orders.APPROVED"
+	case processing:
+		return "Orders.PROCESSING"
+	case readyToShip:
+		return "Orders.READYTOSHIP"
+	case shipped:
+		return "Orders.SHIPPED"
+	case delivered:
+		return "Orders.DELIVERED"
+	case cancelled:
+		return "Orders.CANCELLED"
+	}
+	return "Order{order: " + strconv.FormatInt(int64(p.order), 10) + "}"
+}
+
+// CacheKey returns a key for the Order namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p Order) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[created-0]
+	_ = x[approved-1]
+	_ = x[processing-2]
+	_ = x[readyToShip-3]
+	_ = x[shipped-4]
+	_ = x[delivered-5]
+	_ = x[cancelled-6]
+}
+
+const _orders_name = "CREATEDAPPROVEDPROCESSINGREADY_TO_SHIPSHIPPEDDELIVEREDCANCELLED"
+
+var _orders_index = [...]uint16{0, 7, 15, 25, 38, 45, 54, 63}
+
+func (i order) String() string {
+	if i < 0 || i >= order(len(_orders_index)-1) {
+		return "orders(" + (strconv.FormatInt(int64(i), 10) + ")")
+	}
+	return _orders_name[_orders_index[i]:_orders_index[i+1]]
+}
@@ -0,0 +1,14 @@
+package shared
+
+type status int
+
+//go:generate goenums -shared status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
@@ -0,0 +1,13 @@
+package sparse
+
+type status int
+
+//go:generate goenums -f -zero-valid status.go
+const (
+	active status = iota
+	paused
+	_ // retired, no longer stored
+	_
+	archived
+	deleted // invalid
+)
@@ -0,0 +1,14 @@
+package sqlint
+
+type status int
+
+//go:generate goenums -sqlint status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
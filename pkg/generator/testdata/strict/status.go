@@ -0,0 +1,14 @@
+package strict
+
+type status int
+
+//go:generate goenums -strict -yaml v2 status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
@@ -0,0 +1,13 @@
+package stringer
+
+type status int
+
+//go:generate goenums -stringer status.go
+const (
+	failed status = iota // invalid
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
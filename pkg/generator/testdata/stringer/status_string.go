@@ -0,0 +1,33 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -stringer testdata/stringer/status.go
+// source checksum: 1716e7787327d1a2
+
+package stringer
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[failed-0]
+	_ = x[passed-1]
+	_ = x[skipped-2]
+	_ = x[scheduled-3]
+	_ = x[running-4]
+	_ = x[booked-5]
+}
+
+const _status_name = "failedpassedskippedscheduledrunningbooked"
+
+var _status_index = [...]uint8{0, 6, 12, 19, 28, 35, 41}
+
+func (i status) String() string {
+	if i < 0 || i >= status(len(_status_index)-1) {
+		return "status(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _status_name[_status_index[i]:_status_index[i+1]]
+}
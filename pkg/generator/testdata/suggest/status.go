@@ -0,0 +1,14 @@
+package suggest
+
+type status int
+
+//go:generate goenums -f -suggest status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
@@ -0,0 +1,14 @@
+package tags
+
+type status int
+
+//go:generate goenums -f status.go
+const (
+	unknown    status = iota // invalid
+	failed                   // json:"failed" db:"FAILED"
+	passed                   // json:"passed" db:"PASSED" display:"Passed all checks"
+	skipped                  // display:"Skipped" parse:"ignored, bypassed"
+	scheduled                // json:"scheduled"
+	inProgress               // InProgress json:"in_progress" db:"IN_PROGRESS" display:"In progress" parse:"running"
+	quoted                   // json:"\"quoted\""
+)
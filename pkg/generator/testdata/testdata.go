@@ -0,0 +1,354 @@
+// Package testdata holds the enum sources the generator is tested against and
+// the files generated from them. InputOutputTestCases pairs them up with the
+// config they are generated with, and RunGenerateContract checks any function
+// generating enums, such as generator.Generate or a wrapper around it, writes
+// the same files.
+package testdata
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/zarldev/goenums/pkg/generator"
+)
+
+// files are the enum sources and generated files of the cases.
+//
+//go:embed */*.go
+var files embed.FS
+
+// InputOutputTestCase is an enum source and the Go file generated from it.
+type InputOutputTestCase struct {
+	Name string
+	// Source is the path of the enum source relative to the generator package,
+	// which the generated header records.
+	Source string
+	// Config the source is generated with.
+	Config generator.Config
+	// Expected is the path of the generated file relative to the generator package.
+	Expected string
+}
+
+// InputOutputTestCases are the enum sources the generator is tested against.
+var InputOutputTestCases = []InputOutputTestCase{
+	{
+		Name:     "TestParseAndGenerate-Statuses-Strings",
+		Source:   "testdata/validation-strings/status.go",
+		Expected: "testdata/validation-strings/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Statuses",
+		Source:   "testdata/validation/status.go",
+		Expected: "testdata/validation/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Planets",
+		Source:   "testdata/planets/planets.go",
+		Expected: "testdata/planets/planets_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-PlanetsGravityOnly",
+		Source:   "testdata/planets_gravity_only/planets.go",
+		Expected: "testdata/planets_gravity_only/planets_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-PlanetsSimple",
+		Source:   "testdata/planets_simple/planets.go",
+		Expected: "testdata/planets_simple/planets_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-DiscountTypes",
+		Source:   "testdata/sale/discount.go",
+		Config:   generator.Config{Failfast: true},
+		Expected: "testdata/sale/discounttypes_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Orders",
+		Source:   "testdata/orders/orders.go",
+		Expected: "testdata/orders/orders_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-YAML",
+		Source:   "testdata/yaml/status.go",
+		Config:   generator.Config{YAML: generator.YAMLv2},
+		Expected: "testdata/yaml/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-YAMLv3",
+		Source:   "testdata/yamlv3/status.go",
+		Config:   generator.Config{YAML: generator.YAMLv3, Failfast: true},
+		Expected: "testdata/yamlv3/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-JSONv2",
+		Source:   "testdata/jsonv2/status.go",
+		Config:   generator.Config{JSONv2: true},
+		Expected: "testdata/jsonv2/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Descriptions",
+		Source:   "testdata/descriptions/planets.go",
+		Expected: "testdata/descriptions/planets_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-DescriptionsDirective",
+		Source:   "testdata/descriptions_directive/moons.go",
+		Expected: "testdata/descriptions_directive/moons_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Accessors",
+		Source:   "testdata/accessors/planets.go",
+		Config:   generator.Config{Accessors: true},
+		Expected: "testdata/accessors/planets_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Immutable",
+		Source:   "testdata/immutable/status.go",
+		Config:   generator.Config{Immutable: true},
+		Expected: "testdata/immutable/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-SharedStatuses",
+		Source:   "testdata/shared/status.go",
+		Config:   generator.Config{Shared: true},
+		Expected: "testdata/shared/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-SharedOrders",
+		Source:   "testdata/shared/orders.go",
+		Config:   generator.Config{Shared: true},
+		Expected: "testdata/shared/orders_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Sentinel",
+		Source:   "testdata/sentinel/status.go",
+		Expected: "testdata/sentinel/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-EmptyInvalid",
+		Source:   "testdata/emptyinvalid/status.go",
+		Config:   generator.Config{EmptyInvalid: true, JSONv2: true},
+		Expected: "testdata/emptyinvalid/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-EmptyInvalidValidZero",
+		Source:   "testdata/emptyinvalid/color.go",
+		Config:   generator.Config{EmptyInvalid: true, JSONv2: true},
+		Expected: "testdata/emptyinvalid/colors_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-SQLInt",
+		Source:   "testdata/sqlint/status.go",
+		Config:   generator.Config{SQLInt: true},
+		Expected: "testdata/sqlint/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Pgx",
+		Source:   "testdata/pgx/status.go",
+		Config:   generator.Config{Pgx: true},
+		Expected: "testdata/pgx/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-PgxInt",
+		Source:   "testdata/pgxint/status.go",
+		Config:   generator.Config{Pgx: true, SQLInt: true},
+		Expected: "testdata/pgxint/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Insensitive",
+		Source:   "testdata/insensitive/status.go",
+		Config:   generator.Config{Insensitive: true},
+		Expected: "testdata/insensitive/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Unicode",
+		Source:   "testdata/unicode/etat.go",
+		Expected: "testdata/unicode/états_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Names",
+		Source:   "testdata/names/orderstatus.go",
+		Expected: "testdata/names/orderstatuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Suggest",
+		Source:   "testdata/suggest/status.go",
+		Config:   generator.Config{Suggest: true, Failfast: true},
+		Expected: "testdata/suggest/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Suffix",
+		Source:   "testdata/affixes/status.go",
+		Config:   generator.Config{Suffix: "Enum"},
+		Expected: "testdata/affixes/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Defaults",
+		Source:   "testdata/defaults/status.go",
+		Expected: "testdata/defaults/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-EmptyDefault",
+		Source:   "testdata/emptydefault/status.go",
+		Config:   generator.Config{EmptyDefault: true, YAML: generator.YAMLv2, Failfast: true},
+		Expected: "testdata/emptydefault/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-TestFile",
+		Source:   "testdata/testonly/fixture_test.go",
+		Config:   generator.Config{Shared: true},
+		Expected: "testdata/testonly/fixtures_enums_test.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-MarshalInvalid",
+		Source:   "testdata/marshalinvalid/status.go",
+		Config:   generator.Config{MarshalInvalid: generator.MarshalInvalidError, YAML: generator.YAMLv2, JSONv2: true},
+		Expected: "testdata/marshalinvalid/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Strict",
+		Source:   "testdata/strict/status.go",
+		Config:   generator.Config{Strict: true, YAML: generator.YAMLv2},
+		Expected: "testdata/strict/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-TrimPrefix",
+		Source:   "testdata/trimprefix/status.go",
+		Config:   generator.Config{TrimPrefix: "Status"},
+		Expected: "testdata/trimprefix/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Coverage",
+		Source:   "testdata/coverage/status.go",
+		Config:   generator.Config{Coverage: true, Failfast: true},
+		Expected: "testdata/coverage/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Tags",
+		Source:   "testdata/tags/status.go",
+		Config:   generator.Config{Failfast: true},
+		Expected: "testdata/tags/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Sparse",
+		Source:   "testdata/sparse/status.go",
+		Config:   generator.Config{ZeroValid: true, Failfast: true},
+		Expected: "testdata/sparse/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-BlankFirst",
+		Source:   "testdata/blankfirst/status.go",
+		Expected: "testdata/blankfirst/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-BlankGap",
+		Source:   "testdata/blankgap/status.go",
+		Expected: "testdata/blankgap/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-BitFlags",
+		Source:   "testdata/bitflags/flags.go",
+		Expected: "testdata/bitflags/flags_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-BitFlagsWide",
+		Source:   "testdata/bitflagswide/perms.go",
+		Expected: "testdata/bitflagswide/perms_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Grouped",
+		Source:   "testdata/grouped/status.go",
+		Config:   generator.Config{ZeroValid: true, Failfast: true},
+		Expected: "testdata/grouped/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Pinned",
+		Source:   "testdata/pinned/status.go",
+		Config:   generator.Config{SQLInt: true, Failfast: true},
+		Expected: "testdata/pinned/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-FloatIntegral",
+		Source:   "testdata/floatintegral/status.go",
+		Config:   generator.Config{FloatInput: generator.FloatInputIntegral, Failfast: true},
+		Expected: "testdata/floatintegral/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-FloatRound",
+		Source:   "testdata/floatround/status.go",
+		Config:   generator.Config{FloatInput: generator.FloatInputRound, Failfast: true},
+		Expected: "testdata/floatround/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-BaseMethods",
+		Source:   "testdata/basemethods/status.go",
+		Config:   generator.Config{BaseMethods: true},
+		Expected: "testdata/basemethods/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-Stringer",
+		Source:   "testdata/stringer/status.go",
+		Config:   generator.Config{Stringer: true},
+		Expected: "testdata/stringer/status_string.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-ParseLookupSwitch",
+		Source:   "testdata/lookupswitch/status.go",
+		Config:   generator.Config{ParseLookup: generator.ParseLookupSwitch, Failfast: true},
+		Expected: "testdata/lookupswitch/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-ParseLookupLength",
+		Source:   "testdata/lookuplength/status.go",
+		Config:   generator.Config{ParseLookup: generator.ParseLookupLength, Failfast: true},
+		Expected: "testdata/lookuplength/statuses_enums.go",
+	},
+	{
+		Name:     "TestParseAndGenerate-ParseLookupHash",
+		Source:   "testdata/lookuphash/status.go",
+		Config:   generator.Config{ParseLookup: generator.ParseLookupHash, Failfast: true},
+		Expected: "testdata/lookuphash/statuses_enums.go",
+	},
+}
+
+// GenerateFunc generates the files of the enum declared in src, the contents of
+// filename, with the config. generator.Generate is one.
+type GenerateFunc func(ctx context.Context, filename string, src []byte, cfg generator.Config) ([]generator.File, error)
+
+// RunGenerateContract runs a subtest for each of the InputOutputTestCases checking
+// generate returns the expected file, byte for byte, for the source and config
+// of the case. Other files generate returns are ignored.
+func RunGenerateContract(t *testing.T, generate GenerateFunc) {
+	t.Helper()
+	for _, tc := range InputOutputTestCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			src, err := files.ReadFile(strings.TrimPrefix(tc.Source, "testdata/"))
+			if err != nil {
+				t.Fatalf("failed to read source %s, got %v", tc.Source, err)
+			}
+			expected, err := files.ReadFile(strings.TrimPrefix(tc.Expected, "testdata/"))
+			if err != nil {
+				t.Fatalf("failed to read expected file %s, got %v", tc.Expected, err)
+			}
+			generated, err := generate(context.Background(), tc.Source, src, tc.Config)
+			if err != nil {
+				t.Fatalf("failed to generate enums for %s, got %v", tc.Source, err)
+			}
+			name := path.Base(tc.Expected)
+			for _, f := range generated {
+				if f.Name != name {
+					continue
+				}
+				if !bytes.Equal(f.Content, expected) {
+					t.Errorf("expected %s to match %s", name, tc.Expected)
+				}
+				return
+			}
+			t.Errorf("expected %s to be generated", name)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package testonly
+
+type fixture int
+
+//go:generate goenums -shared fixture_test.go
+const (
+	none fixture = iota // invalid
+	small
+	large
+)
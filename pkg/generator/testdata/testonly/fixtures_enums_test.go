@@ -0,0 +1,307 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums -shared testdata/testonly/fixture_test.go
+// source checksum: 0728dff69ba82cd8
+
+package testonly
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+type Fixture struct {
+	fixture
+}
+
+type fixturesContainer struct {
+	// NONE is "none" with the value 0, marked invalid.
+	NONE Fixture
+	// SMALL is "small" with the value 1.
+	SMALL Fixture
+	// LARGE is "large" with the value 2.
+	LARGE Fixture
+}
+
+var Fixtures = fixturesContainer{
+	SMALL: Fixture{
+		fixture: small,
+	},
+	LARGE: Fixture{
+		fixture: large,
+	},
+}
+
+func (c fixturesContainer) All() []Fixture {
+	return []Fixture{
+		c.SMALL,
+		c.LARGE,
+	}
+}
+
+// AllWithInvalid returns every declared Fixture, including those marked invalid, in declaration order.
+func (c fixturesContainer) AllWithInvalid() []Fixture {
+	return []Fixture{
+		{fixture: none},
+		c.SMALL,
+		c.LARGE,
+	}
+}
+
+// Count returns the number of valid Fixture values.
+func (c fixturesContainer) Count() int {
+	return 2
+}
+
+// First returns the first valid Fixture in declaration order.
+func (c fixturesContainer) First() Fixture {
+	return c.SMALL
+}
+
+// Last returns the last valid Fixture in declaration order.
+func (c fixturesContainer) Last() Fixture {
+	return c.LARGE
+}
+
+// Ordinal returns the position the Fixture is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p Fixture) Ordinal() int {
+	switch p.fixture {
+	case none:
+		return 0
+	case small:
+		return 1
+	case large:
+		return 2
+	}
+	return -1
+}
+
+// Underlying returns the fixture constant the Fixture wraps.
+func (p Fixture) Underlying() fixture {
+	return p.fixture
+}
+
+// Base returns the fixture constant the Fixture wraps, the inverse of AsFixture.
+func (p Fixture) Base() fixture {
+	return p.fixture
+}
+
+// WrapFixture returns the Fixture wrapping the fixture constant, or an error when it is not valid.
+func WrapFixture(v fixture) (Fixture, error) {
+	if i := int(v); i >= 0 && i < len(Fixtures.AllWithInvalid()) {
+		if p := Fixtures.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidFixture, fmt.Errorf("failed to wrap invalid Fixture: %d", v)
+}
+
+// AsFixture returns the Fixture wrapping the fixture constant, or the invalid Fixture when it is not valid.
+func AsFixture(v fixture) Fixture {
+	p, _ := WrapFixture(v)
+	return p
+}
+
+// FixtureNames returns the names of the valid Fixture values in declaration order.
+func FixtureNames() []string {
+	return []string{"small", "large"}
+}
+
+// FixtureStrings returns every string ParseFixture accepts for the valid values,
+// each name followed by its tag values and aliases.
+func FixtureStrings() []string {
+	return []string{"small", "large"}
+}
+
+var invalidFixture = Fixture{}
+
+func ParseFixture(a any) (Fixture, error) {
+	res := invalidFixture
+	switch v := a.(type) {
+	case Fixture:
+		return v, nil
+	case []byte:
+		res = stringToFixture(string(v))
+	case string:
+		res = stringToFixture(v)
+	case fmt.Stringer:
+		res = stringToFixture(v.String())
+	case int:
+		res = intToFixture(v)
+	case int64:
+		res = intToFixture(int(v))
+	case int32:
+		res = intToFixture(int(v))
+	}
+	return res, nil
+}
+
+func stringToFixture(s string) Fixture {
+	switch s {
+	case "none":
+		return Fixtures.NONE
+	case "small":
+		return Fixtures.SMALL
+	case "large":
+		return Fixtures.LARGE
+	}
+	return invalidFixture
+}
+
+func intToFixture(i int) Fixture {
+	if i < 0 || i >= len(Fixtures.All()) {
+		return invalidFixture
+	}
+	return Fixtures.All()[i]
+}
+
+func ExhaustiveFixtures(f func(Fixture)) {
+	for _, p := range Fixtures.All() {
+		f(p)
+	}
+}
+
+// ExhaustiveFixturesIncludingInvalid calls f with every declared Fixture, including those marked invalid.
+func ExhaustiveFixturesIncludingInvalid(f func(Fixture)) {
+	for _, p := range Fixtures.AllWithInvalid() {
+		f(p)
+	}
+}
+
+var validFixtures = map[Fixture]bool{
+	Fixtures.SMALL: true,
+	Fixtures.LARGE: true,
+}
+
+func (p Fixture) IsValid() bool {
+	return validFixtures[p]
+}
+
+// IsZero reports whether the Fixture is unset, meaning it holds the invalid value.
+func (p Fixture) IsZero() bool {
+	return p.fixture == invalidFixture.fixture && !p.IsValid()
+}
+
+// IsSet reports whether the Fixture holds a value other than the invalid value.
+func (p Fixture) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the Fixture, for use in optional fields.
+func (p Fixture) Ptr() *Fixture {
+	return &p
+}
+
+// _fixtures_json holds the quoted JSON name of each declared Fixture.
+var _fixtures_json = [...]string{
+	`"none"`,
+	`"small"`,
+	`"large"`,
+}
+
+// AppendJSON appends the JSON encoding of the Fixture written by MarshalJSON to dst.
+func (p Fixture) AppendJSON(dst []byte) []byte {
+	if i := int(p.fixture); i >= 0 && i < len(_fixtures_json) {
+		return append(dst, _fixtures_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
+func (p Fixture) MarshalJSON() ([]byte, error) {
+	return p.AppendJSON(nil), nil
+}
+
+func (p *Fixture) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(bytes.Trim(b, `"`), ` `)
+	newp, err := ParseFixture(b)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p *Fixture) Scan(value any) error {
+	newp, err := ParseFixture(value)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p Fixture) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// FixtureSQLValues is the comma separated list of valid Fixture values as stored by Value.
+const FixtureSQLValues = "'small', 'large'"
+
+// FixtureCheckConstraint returns a CHECK constraint restricting col to the valid Fixture values.
+func FixtureCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + FixtureSQLValues + "))"
+}
+
+// DisplayName returns the human readable name of the Fixture from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p Fixture) DisplayName() string {
+	return p.String()
+}
+
+func (p Fixture) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.fixture)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p Fixture) GoString() string {
+	switch p.fixture {
+	case small:
+		return "Fixtures.SMALL"
+	case large:
+		return "Fixtures.LARGE"
+	}
+	return "Fixture{fixture: " + strconv.FormatInt(int64(p.fixture), 10) + "}"
+}
+
+// CacheKey returns a key for the Fixture namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p Fixture) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[none-0]
+	_ = x[small-1]
+	_ = x[large-2]
+}
+
+const _fixtures_name = "nonesmalllarge"
+
+var _fixtures_index = [...]uint16{0, 4, 9, 14}
+
+func (i fixture) String() string {
+	if i < 0 || i >= fixture(len(_fixtures_index)-1) {
+		return "fixtures(" + (strconv.FormatInt(int64(i), 10) + ")")
+	}
+	return _fixtures_name[_fixtures_index[i]:_fixtures_index[i+1]]
+}
@@ -0,0 +1,13 @@
+package trimprefix
+
+//goenums:names=snake
+type status int
+
+//go:generate goenums -trimprefix Status status.go
+const (
+	StatusUnknown status = iota // invalid
+	StatusActive
+	StatusInactive
+	StatusPendingReview
+	StatusArchived // ARCHIVED
+)
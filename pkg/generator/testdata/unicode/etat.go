@@ -0,0 +1,12 @@
+package unicode
+
+type état int
+
+//go:generate goenums etat.go
+const (
+	inconnu état = iota // invalid
+	prêt
+	terminé
+	échoué // Échoué
+	完了
+)
@@ -0,0 +1,339 @@
+// Code generated by goenums. DO NOT EDIT.
+// This file was generated by github.com/zarldev/goenums
+// using the command:
+// goenums testdata/unicode/etat.go
+// source checksum: da10ca66b70c8b9e
+
+package unicode
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+type État struct {
+	état
+}
+
+type étatsContainer struct {
+	// INCONNU is "inconnu" with the value 0, marked invalid.
+	INCONNU État
+	// PRÊT is "prêt" with the value 1.
+	PRÊT État
+	// TERMINÉ is "terminé" with the value 2.
+	TERMINÉ État
+	// ÉCHOUÉ is "Échoué" with the value 3.
+	ÉCHOUÉ État
+	// X完了 is "完了" with the value 4.
+	X完了 État
+}
+
+var États = étatsContainer{
+	PRÊT: État{
+		état: prêt,
+	},
+	TERMINÉ: État{
+		état: terminé,
+	},
+	ÉCHOUÉ: État{
+		état: échoué,
+	},
+	X完了: État{
+		état: 完了,
+	},
+}
+
+func (c étatsContainer) All() []État {
+	return []État{
+		c.PRÊT,
+		c.TERMINÉ,
+		c.ÉCHOUÉ,
+		c.X完了,
+	}
+}
+
+// AllWithInvalid returns every declared État, including those marked invalid, in declaration order.
+func (c étatsContainer) AllWithInvalid() []État {
+	return []État{
+		{état: inconnu},
+		c.PRÊT,
+		c.TERMINÉ,
+		c.ÉCHOUÉ,
+		c.X完了,
+	}
+}
+
+// Count returns the number of valid État values.
+func (c étatsContainer) Count() int {
+	return 4
+}
+
+// First returns the first valid État in declaration order.
+func (c étatsContainer) First() État {
+	return c.PRÊT
+}
+
+// Last returns the last valid État in declaration order.
+func (c étatsContainer) Last() État {
+	return c.X完了
+}
+
+// Ordinal returns the position the État is declared at, counting from 0 and including
+// the values marked invalid, or -1 for a value that is not declared.
+func (p État) Ordinal() int {
+	switch p.état {
+	case inconnu:
+		return 0
+	case prêt:
+		return 1
+	case terminé:
+		return 2
+	case échoué:
+		return 3
+	case 完了:
+		return 4
+	}
+	return -1
+}
+
+// Underlying returns the état constant the État wraps.
+func (p État) Underlying() état {
+	return p.état
+}
+
+// Base returns the état constant the État wraps, the inverse of AsÉtat.
+func (p État) Base() état {
+	return p.état
+}
+
+// WrapÉtat returns the État wrapping the état constant, or an error when it is not valid.
+func WrapÉtat(v état) (État, error) {
+	if i := int(v); i >= 0 && i < len(États.AllWithInvalid()) {
+		if p := États.AllWithInvalid()[i]; p.IsValid() {
+			return p, nil
+		}
+	}
+	return invalidÉtat, fmt.Errorf("failed to wrap invalid État: %d", v)
+}
+
+// AsÉtat returns the État wrapping the état constant, or the invalid État when it is not valid.
+func AsÉtat(v état) État {
+	p, _ := WrapÉtat(v)
+	return p
+}
+
+// ÉtatNames returns the names of the valid État values in declaration order.
+func ÉtatNames() []string {
+	return []string{"prêt", "terminé", "Échoué", "完了"}
+}
+
+// ÉtatStrings returns every string ParseÉtat accepts for the valid values,
+// each name followed by its tag values and aliases.
+func ÉtatStrings() []string {
+	return []string{"prêt", "terminé", "Échoué", "完了"}
+}
+
+var invalidÉtat = État{}
+
+func ParseÉtat(a any) (État, error) {
+	res := invalidÉtat
+	switch v := a.(type) {
+	case État:
+		return v, nil
+	case []byte:
+		res = stringToÉtat(string(v))
+	case string:
+		res = stringToÉtat(v)
+	case fmt.Stringer:
+		res = stringToÉtat(v.String())
+	case int:
+		res = intToÉtat(v)
+	case int64:
+		res = intToÉtat(int(v))
+	case int32:
+		res = intToÉtat(int(v))
+	}
+	return res, nil
+}
+
+func stringToÉtat(s string) État {
+	switch s {
+	case "inconnu":
+		return États.INCONNU
+	case "prêt":
+		return États.PRÊT
+	case "terminé":
+		return États.TERMINÉ
+	case "Échoué":
+		return États.ÉCHOUÉ
+	case "完了":
+		return États.X完了
+	}
+	return invalidÉtat
+}
+
+func intToÉtat(i int) État {
+	if i < 0 || i >= len(États.All()) {
+		return invalidÉtat
+	}
+	return États.All()[i]
+}
+
+func ExhaustiveÉtats(f func(État)) {
+	for _, p := range États.All() {
+		f(p)
+	}
+}
+
+// ExhaustiveÉtatsIncludingInvalid calls f with every declared État, including those marked invalid.
+func ExhaustiveÉtatsIncludingInvalid(f func(État)) {
+	for _, p := range États.AllWithInvalid() {
+		f(p)
+	}
+}
+
+var validÉtats = map[État]bool{
+	États.PRÊT:    true,
+	États.TERMINÉ: true,
+	États.ÉCHOUÉ:  true,
+	États.X完了:     true,
+}
+
+func (p État) IsValid() bool {
+	return validÉtats[p]
+}
+
+// IsZero reports whether the État is unset, meaning it holds the invalid value.
+func (p État) IsZero() bool {
+	return p.état == invalidÉtat.état && !p.IsValid()
+}
+
+// IsSet reports whether the État holds a value other than the invalid value.
+func (p État) IsSet() bool {
+	return !p.IsZero()
+}
+
+// Ptr returns a pointer to a copy of the État, for use in optional fields.
+func (p État) Ptr() *État {
+	return &p
+}
+
+// _états_json holds the quoted JSON name of each declared État.
+var _états_json = [...]string{
+	`"inconnu"`,
+	`"prêt"`,
+	`"terminé"`,
+	`"Échoué"`,
+	`"完了"`,
+}
+
+// AppendJSON appends the JSON encoding of the État written by MarshalJSON to dst.
+func (p État) AppendJSON(dst []byte) []byte {
+	if i := int(p.état); i >= 0 && i < len(_états_json) {
+		return append(dst, _états_json[i]...)
+	}
+	return append(append(append(dst, '"'), p.String()...), '"')
+}
+
+func (p État) MarshalJSON() ([]byte, error) {
+	return p.AppendJSON(nil), nil
+}
+
+func (p *État) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(bytes.Trim(b, `"`), ` `)
+	newp, err := ParseÉtat(b)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p *État) Scan(value any) error {
+	newp, err := ParseÉtat(value)
+	if err != nil {
+		return err
+	}
+	*p = newp
+	return nil
+}
+
+func (p État) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// ÉtatSQLValues is the comma separated list of valid État values as stored by Value.
+const ÉtatSQLValues = "'prêt', 'terminé', 'Échoué', '完了'"
+
+// ÉtatCheckConstraint returns a CHECK constraint restricting col to the valid État values.
+func ÉtatCheckConstraint(col string) string {
+	return "CHECK (" + col + " IN (" + ÉtatSQLValues + "))"
+}
+
+// DisplayName returns the human readable name of the État from its display tag,
+// or its name when it has none. The name is still used on the wire.
+func (p État) DisplayName() string {
+	return p.String()
+}
+
+func (p État) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.état)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), p.String())
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, p.GoString())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), p.String())
+	}
+}
+
+func (p État) GoString() string {
+	switch p.état {
+	case prêt:
+		return "États.PRÊT"
+	case terminé:
+		return "États.TERMINÉ"
+	case échoué:
+		return "États.ÉCHOUÉ"
+	case 完了:
+		return "États.X完了"
+	}
+	return "État{état: " + strconv.FormatInt(int64(p.état), 10) + "}"
+}
+
+// CacheKey returns a key for the État namespaced by prefix, built from its
+// canonical name so keys remain stable while the names do.
+func (p État) CacheKey(prefix string) string {
+	return prefix + ":" + p.String()
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the goenums command to generate them again.
+	// Does not identify newly added constant values unless order changes
+	var x [1]struct{}
+	_ = x[inconnu-0]
+	_ = x[prêt-1]
+	_ = x[terminé-2]
+	_ = x[échoué-3]
+	_ = x[完了-4]
+}
+
+const _états_name = "inconnuprêtterminéÉchoué完了"
+
+var _états_index = [...]uint16{0, 7, 12, 20, 28, 34}
+
+func (i état) String() string {
+	if i < 0 || i >= état(len(_états_index)-1) {
+		return "états(" + (strconv.FormatInt(int64(i), 10) + ")")
+	}
+	return _états_name[_états_index[i]:_états_index[i+1]]
+}
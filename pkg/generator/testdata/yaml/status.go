@@ -0,0 +1,14 @@
+package yaml
+
+type status int
+
+//go:generate goenums -yaml v2 status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
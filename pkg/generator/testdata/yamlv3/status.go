@@ -0,0 +1,14 @@
+package yamlv3
+
+type status int
+
+//go:generate goenums -f -yaml v3 status.go
+const (
+	unknown status = iota // invalid
+	failed
+	passed
+	skipped
+	scheduled
+	running
+	booked
+)
@@ -0,0 +1,231 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// EnumUsage is how often the values of an enum are referenced in a module,
+// found by FindUsage.
+type EnumUsage struct {
+	ImportPath string
+	Type       string
+	Values     []ValueUsage
+}
+
+// ValueUsage counts the references to a value of an enum, through its
+// container or, in its own package, its constant.
+type ValueUsage struct {
+	// Constant is the container value, e.g. Statuses.BOOKED.
+	Constant string
+	// Cases are the references in the case clauses of switch statements,
+	// Comparisons those compared with == or != and Other the rest.
+	Cases       int
+	Comparisons int
+	Other       int
+}
+
+// Referenced reports whether the value is referenced anywhere.
+func (u ValueUsage) Referenced() bool {
+	return u.Cases+u.Comparisons+u.Other > 0
+}
+
+// usageEnum holds the names an enum is referenced by while counting.
+type usageEnum struct {
+	usage EnumUsage
+	// pkg is the package name of the enum and container the exported name of
+	// its container, a function with Immutable.
+	pkg       string
+	container string
+	// uppers and constants map the container fields and the constants to their value.
+	uppers    map[string]int
+	constants map[string]int
+}
+
+// FindUsage parses the enums found by FindEnums with the options returned by
+// config and counts the references to each of their values in the module
+// enclosing dir, so values never referenced can be found before removing them.
+// The same files as FindEnums are read, so references from tests and generated
+// code alone leave a value unreferenced. The check is syntactic: a reference is
+// a selector of the container, through the import of its package elsewhere, or
+// a constant not declared in the file in the package of the enum.
+func FindUsage(ctx context.Context, dir string, candidates []Candidate, config func(Candidate) (Config, error)) ([]EnumUsage, error) {
+	var enums []*usageEnum
+	byContainer := make(map[string]*usageEnum)
+	for _, c := range candidates {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		cfg, err := config(c)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Filename, err)
+		}
+		src, err := os.ReadFile(c.Filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", c.Filename, err)
+		}
+		rep, err := Parse(ctx, c.Filename, src, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Filename, err)
+		}
+		e := &usageEnum{
+			usage:     EnumUsage{ImportPath: c.ImportPath, Type: rep.TypeInfo.Camel},
+			pkg:       rep.PackageName,
+			container: rep.TypeInfo.PluralCamel,
+			uppers:    make(map[string]int),
+			constants: make(map[string]int),
+		}
+		for i, v := range rep.Enums {
+			e.usage.Values = append(e.usage.Values, ValueUsage{Constant: rep.containerRef() + "." + v.Info.Upper})
+			e.uppers[v.Info.Upper] = i
+			e.constants[v.Info.Name] = i
+		}
+		enums = append(enums, e)
+		byContainer[c.ImportPath+"."+e.container] = e
+	}
+	importPaths := make(map[string]string)
+	err := walkModule(dir, false, func(_ *token.FileSet, path string, node *ast.File) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		pkgDir := filepath.Dir(path)
+		importPath, ok := importPaths[pkgDir]
+		if !ok {
+			var err error
+			if importPath, err = packageImportPath(pkgDir); err != nil {
+				return err
+			}
+			importPaths[pkgDir] = importPath
+		}
+		references(node, importPath, enums, byContainer, func(e *usageEnum, index int, _ *ast.Ident, _ bool, parent ast.Node) {
+			v := &e.usage.Values[index]
+			switch p := parent.(type) {
+			case *ast.CaseClause:
+				v.Cases++
+			case *ast.BinaryExpr:
+				if p.Op == token.EQL || p.Op == token.NEQ {
+					v.Comparisons++
+				} else {
+					v.Other++
+				}
+			default:
+				v.Other++
+			}
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find usage: %w", err)
+	}
+	usages := make([]EnumUsage, len(enums))
+	for i, e := range enums {
+		usages[i] = e.usage
+	}
+	return usages, nil
+}
+
+// references calls fn with each reference to a value of the enums in the file of
+// the package importPath, the field of a container selector, with field set, or a
+// constant, and the node it is found in.
+func references(node *ast.File, importPath string, enums []*usageEnum, byContainer map[string]*usageEnum, fn func(e *usageEnum, index int, ident *ast.Ident, field bool, parent ast.Node)) {
+	// the import paths of the packages by the name they are used with in the file
+	imports := make(map[string]string)
+	var dotImports []string
+	for _, spec := range node.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		switch {
+		case spec.Name == nil:
+			for _, e := range enums {
+				if e.usage.ImportPath == path {
+					imports[e.pkg] = path
+				}
+			}
+		case spec.Name.Name == ".":
+			dotImports = append(dotImports, path)
+		default:
+			imports[spec.Name.Name] = path
+		}
+	}
+	unresolved := make(map[*ast.Ident]bool, len(node.Unresolved))
+	for _, ident := range node.Unresolved {
+		unresolved[ident] = true
+	}
+	// container returns the enum whose container x refers to.
+	container := func(x ast.Expr) *usageEnum {
+		if call, ok := x.(*ast.CallExpr); ok && len(call.Args) == 0 {
+			x = call.Fun
+		}
+		switch x := x.(type) {
+		case *ast.Ident:
+			if !unresolved[x] {
+				return nil
+			}
+			if e := byContainer[importPath+"."+x.Name]; e != nil {
+				return e
+			}
+			for _, path := range dotImports {
+				if e := byContainer[path+"."+x.Name]; e != nil {
+					return e
+				}
+			}
+		case *ast.SelectorExpr:
+			if pkg, ok := x.X.(*ast.Ident); ok && unresolved[pkg] {
+				if path, ok := imports[pkg.Name]; ok {
+					return byContainer[path+"."+x.Sel.Name]
+				}
+			}
+		}
+		return nil
+	}
+	var stack []ast.Node
+	ast.Inspect(node, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		var parent ast.Node
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+		}
+		stack = append(stack, n)
+		var (
+			e     *usageEnum
+			index int
+			ident *ast.Ident
+			found bool
+		)
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if e = container(n.X); e != nil {
+				index, found = e.uppers[n.Sel.Name]
+				ident = n.Sel
+			}
+		case *ast.Ident:
+			if !unresolved[n] {
+				break
+			}
+			for _, candidate := range enums {
+				if candidate.usage.ImportPath != importPath {
+					continue
+				}
+				if i, ok := candidate.constants[n.Name]; ok {
+					e, index, ident, found = candidate, i, n, true
+					break
+				}
+			}
+		}
+		if !found {
+			return true
+		}
+		fn(e, index, ident, ident != n, parent)
+		return true
+	})
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/zarldev/goenums/pkg/generator"
+)
+
+// vet runs the vet command reporting the uses of the base types and constants of
+// the enums in the module, and returns the exit code, 1 when any are found. With
+// -since only the uses on lines changed since the git revision are reported, so
+// new code can be kept on the wrappers while the old code is migrated.
+func vet(args []string) int {
+	fs := flag.NewFlagSet("vet", flag.ExitOnError)
+	logFlags(fs)
+	var cfg generator.Config
+	configFlags(fs, &cfg)
+	dir := fs.String("dir", ".", "Directory inside the module to check the packages of")
+	since := fs.String("since", "", "Only report the uses on lines added or changed since the git revision (default: every use)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: goenums vet [options] [-dir dir] [-since rev]\nOptions:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	candidates, err := generator.FindEnums(*dir)
+	if err != nil {
+		slog.Error("failed to find enums", "dir", *dir, "error", err)
+		return 1
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	usages, err := generator.FindBaseUsage(ctx, candidates, func(c generator.Candidate) (generator.Config, error) {
+		return candidateConfig(c, cfg)
+	})
+	if err != nil {
+		slog.Error("failed to check enums", "error", err)
+		return 1
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		slog.Error("failed to get working directory", "error", err)
+		return 1
+	}
+	changed := make(map[string]map[int]bool)
+	found := 0
+	for _, u := range usages {
+		if rel, err := filepath.Rel(wd, u.Filename); err == nil {
+			u.Filename = rel
+		}
+		if *since != "" {
+			lines, ok := changed[u.Filename]
+			if !ok {
+				lines, err = changedLines(*since, u.Filename)
+				if err != nil {
+					slog.Error("failed to diff file", "file", u.Filename, "error", err)
+					return 1
+				}
+				changed[u.Filename] = lines
+			}
+			// a nil set is a file added since the revision
+			if lines != nil && !lines[u.Line] {
+				continue
+			}
+		}
+		fmt.Println(u)
+		found++
+	}
+	if found > 0 {
+		return 1
+	}
+	return 0
+}
+
+// changedLines returns the lines of filename added or changed since the git
+// revision rev, or nil when the file did not exist at rev.
+func changedLines(rev, filename string) (map[int]bool, error) {
+	if _, err := gitShow(rev, filename); err != nil {
+		return nil, nil
+	}
+	out, err := exec.Command("git", "diff", "-U0", "--no-color", rev, "--", filename).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff %s -- %s: %w", rev, filename, err)
+	}
+	lines := make(map[int]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		// hunk headers are @@ -old,n +new,n @@ with the count omitted for one line
+		hunk, ok := strings.CutPrefix(scanner.Text(), "@@ ")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(hunk)
+		if len(fields) < 2 || !strings.HasPrefix(fields[1], "+") {
+			continue
+		}
+		start, count, found := strings.Cut(fields[1][1:], ",")
+		first, err := strconv.Atoi(start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hunk header %q", scanner.Text())
+		}
+		n := 1
+		if found {
+			if n, err = strconv.Atoi(count); err != nil {
+				return nil, fmt.Errorf("invalid hunk header %q", scanner.Text())
+			}
+		}
+		for line := first; line < first+n; line++ {
+			lines[line] = true
+		}
+	}
+	return lines, scanner.Err()
+}